@@ -1,14 +1,17 @@
 package analysis
 
 import (
+	"flag"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"reflect"
 	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/types/typeutil"
@@ -16,30 +19,86 @@ import (
 	"github.com/serum-errors/go-serum-analyzer/analysis/scc"
 )
 
-var logf = fmt.Printf
-
-// var logf = func(_ string, _ ...interface{}) {}
-
-var cliArguments = struct{ requireErrorCodes bool }{}
+var cliArguments = struct {
+	requireErrorCodes    bool
+	ownersFile           string
+	formatDocs           bool
+	sortCodes            bool
+	unionImplementations bool
+	lockfile             string
+	updateLockfile       bool
+	causeCode            string
+	metricsFile          string
+	opaquePackages       string
+	trustedPackages      string
+	suppressionsFile     string
+	messagesFile         string
+	resultStructs        bool
+	pruneDeadBranches    bool
+	engine               string
+	maxCodeLength        int
+	maxCodeWords         int
+	shadowedErrors       bool
+	maxComponentSize     int
+	skipGenerated        bool
+}{}
 
 func init() {
 	Analyzer.Flags.BoolVar(&cliArguments.requireErrorCodes, "strict", false, "if this flag is set, exported error returning functions are required to declare error codes")
+	Analyzer.Flags.StringVar(&cliArguments.ownersFile, "owners", "", "path to a file mapping packages and error code prefixes to owners, which are added to diagnostics")
+	Analyzer.Flags.BoolVar(&cliArguments.formatDocs, "format", false, "if this flag is set, 'Errors:' blocks that are not in canonical form are reported, with a suggested fix to format them")
+	Analyzer.Flags.BoolVar(&cliArguments.sortCodes, "sort-codes", false, "if this flag is set, the canonical form of 'Errors:' blocks has its error codes sorted")
+	Analyzer.Flags.BoolVar(&cliArguments.unionImplementations, "union-implementations", false, "if this flag is set, calls of interface methods without declared error codes return the error codes of all known implementations")
+	Analyzer.Flags.StringVar(&cliArguments.lockfile, "lockfile", "", "path to a lockfile listing the error codes of all exported functions, which the declared error codes are checked against")
+	Analyzer.Flags.BoolVar(&cliArguments.updateLockfile, "update-lockfile", false, "if this flag is set together with -lockfile, the lockfile is updated with the declared error codes instead of checked")
+	Analyzer.Flags.StringVar(&cliArguments.causeCode, "cause-code", "", "error code returned by calls of \"Cause() error\" on error types, which do not declare the error codes of their causes")
+	Analyzer.Flags.StringVar(&cliArguments.metricsFile, "metrics-out", "", "path to a file, to which metrics about the analysis are written in the Prometheus textfile format")
+	Analyzer.Flags.StringVar(&cliArguments.opaquePackages, "opaque-packages", "", "comma separated list of package paths, whose functions are trusted to return no error codes without reporting their calls")
+	Analyzer.Flags.StringVar(&cliArguments.trustedPackages, "trusted-packages", "", "comma separated list of package paths, whose declared error codes are exported without verifying the function bodies")
+	Analyzer.Flags.StringVar(&cliArguments.suppressionsFile, "suppressions-out", "", "path to a file, to which all annotations overruling the analysis are written as JSON")
+	Analyzer.Flags.StringVar(&cliArguments.messagesFile, "messages", "", "path to a JSON file mapping message IDs to translated messages, defaults to the SERUM_MESSAGES environment variable")
+	Analyzer.Flags.BoolVar(&cliArguments.resultStructs, "result-structs", false, "if this flag is set, structs with error fields are error containers, so functions returning them can declare error codes")
+	Analyzer.Flags.BoolVar(&cliArguments.pruneDeadBranches, "prune-dead-branches", false, "if this flag is set, return statements in branches behind constant false conditions, e.g. 'if false', are not analysed")
+	Analyzer.Flags.IntVar(&cliArguments.maxCodeLength, "max-code-length", 0, "if set to a positive number, declared error codes longer than this number of characters or containing filler words are reported as looking like sentences")
+	Analyzer.Flags.IntVar(&cliArguments.maxCodeWords, "max-code-words", 0, "if set to a positive number, declared error codes with more dash separated words than this number or containing filler words are reported as looking like sentences")
+	Analyzer.Flags.BoolVar(&cliArguments.shadowedErrors, "shadowed-errors", false, "if this flag is set, error variables shadowing another error variable in functions declaring error codes are reported, unless they are returned themselves")
+	Analyzer.Flags.IntVar(&cliArguments.maxComponentSize, "max-component-size", 0, "if set to a positive number, functions of components of mutually recursive functions with more members are not analysed, their declared error codes are trusted instead")
+	Analyzer.Flags.BoolVar(&cliArguments.skipGenerated, "skip-generated", false, "if this flag is set, no diagnostics are reported in generated files with a 'Code generated ... DO NOT EDIT.' header, their facts are still exported")
+	Analyzer.Flags.StringVar(&cliArguments.engine, "engine", engineAST, "engine used to follow errors through functions: 'ast' walks the syntax tree, 'ssa' follows values on SSA form and falls back to 'ast' for unsupported functions")
+}
+
+// runningAsVettool checks if the analyzer is run by go vet, which passes a single config file and analyses every package in its own process.
+// Flags writing files for all packages at once can't be supported then.
+func runningAsVettool() bool {
+	args := flag.Args()
+	return len(args) == 1 && strings.HasSuffix(args[0], ".cfg")
 }
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "serum",
-	Doc:      "Checks that any function that has a structured docstring enumerating Serum-style error codes is telling the truth.",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-	Run:      runVerify,
+	Name:       "serum",
+	Doc:        "Checks that any function that has a structured docstring enumerating Serum-style error codes is telling the truth.",
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer, DocsAnalyzer},
+	Run:        runVerify,
+	ResultType: reflect.TypeOf((*Result)(nil)),
 	FactTypes: []analysis.Fact{
 		new(ErrorCodes),
 		new(ErrorConstructor),
+		new(ErrorUnion),
+		new(ErrorPassthrough),
 		new(ErrorType),
 		new(ErrorInterface),
+		new(ErrorCodeSetter),
+		new(ErrorCodeOption),
+		new(ErrorGroup),
+		new(ErrorFuncAdapter),
 	},
 }
 
 type (
+	// ErrorCodes is a fact that is used to tag functions with the error codes they may return.
+	//
+	// For example a function declaring "- some-error --" in its "Errors:" block
+	// gets an ErrorCodes{Codes: Set("some-error")} fact.
 	ErrorCodes struct {
 		Codes CodeSet
 	}
@@ -49,8 +108,34 @@ type (
 	//
 	// For example a constructor function "NewError(code, message string) error { return &Error{code, message} }"
 	// gets an ErrorConstructor{CodeParamPosition: 0} fact.
+	//
+	// The fact is exported for functions and methods, so constructors of other packages can be called.
+	// It only refers to the index of the parameter, not to its position in the source,
+	// so it stays valid for packages that import the constructor.
+	//
+	// Constructors declaring a prefix, e.g. "- param: code (prefix "storage-") --", put the prefix in front of the parameter,
+	// so the error code of a call is the prefix followed by the argument.
 	ErrorConstructor struct {
-		CodeParamPosition int
+		CodeParamPosition int    // index of the error code parameter, not counting the receiver of methods
+		Prefix            string // prefix of the error code parameter, or empty
+	}
+
+	// ErrorUnion is a fact that is used to tag functions that return the errors passed to their variadic error parameter,
+	// meaning their error codes include the error codes of all arguments passed to the parameter at each call site.
+	//
+	// For example a function "combine(message string, errs ...error) error" declaring "param: errs"
+	// gets an ErrorUnion{ParamPosition: 1} fact.
+	ErrorUnion struct {
+		ParamPosition int
+	}
+
+	// ErrorPassthrough is a fact that is used to tag functions that may return the errors passed to some of their error parameters,
+	// meaning their error codes include the error codes of the arguments passed to these parameters at each call site.
+	//
+	// For example a function "annotate(err error, message string) error" declaring "param-passthrough: err"
+	// gets an ErrorPassthrough{ParamPositions: [0]} fact.
+	ErrorPassthrough struct {
+		ParamPositions []int
 	}
 )
 
@@ -65,27 +150,55 @@ func (e *ErrorCodes) String() string {
 func (*ErrorConstructor) AFact() {}
 
 func (e *ErrorConstructor) String() string {
+	if e.Prefix != "" {
+		return fmt.Sprintf("ErrorConstructor: {CodeParamPosition:%d, Prefix:%q}", e.CodeParamPosition, e.Prefix)
+	}
 	return fmt.Sprintf("ErrorConstructor: {CodeParamPosition:%d}", e.CodeParamPosition)
 }
 
+func (*ErrorUnion) AFact() {}
+
+func (e *ErrorUnion) String() string {
+	return fmt.Sprintf("ErrorUnion: {ParamPosition:%d}", e.ParamPosition)
+}
+
+func (*ErrorPassthrough) AFact() {}
+
+func (e *ErrorPassthrough) String() string {
+	return fmt.Sprintf("ErrorPassthrough: {ParamPositions:%v}", e.ParamPositions)
+}
+
 type (
 	context struct {
-		pass     *analysis.Pass
-		lookup   *funcLookup
-		scc      scc.State
-		comments ast.CommentMap
+		pass           *analysis.Pass
+		lookup         *funcLookup
+		scc            scc.State
+		comments       ast.CommentMap
+		assignedValues map[*types.Var]*assignedValues  // cache of values assigned to package-level variables and struct fields
+		mapValues      map[*types.Var]*storedMapValues // cache of values stored in maps of errors
+
+		undeclaredCallees *undeclaredCallees // calls to functions of other packages without declared error codes
+
+		constructorCalls map[*ast.CallExpr]struct{} // calls of error constructors, whose error code argument was checked
+
+		interfaceUses map[*types.TypeName]map[*types.TypeName]*ErrorInterface // error interfaces, as which the types of the package are used
+
+		functionFlows map[funcDeclOrLit]*functionFlow // cache of the control flow of functions, used to check if values reach their uses
 	}
 
 	funcCodesMap map[*ast.FuncDecl]funcCodes
 
 	funcCodes struct {
-		codes CodeSet
-		param *funcCodeParam
+		codes       CodeSet
+		param       *funcCodeParam
+		passthrough []int // positions of the error parameters declared with "param-passthrough:"
 	}
 
 	funcCodeParam struct {
 		ident    *ast.Ident
 		position int
+		union    bool   // the parameter is a variadic error parameter instead of an error code parameter
+		prefix   string // prefix declared for the error code parameter, or empty
 	}
 
 	// funcDefinition is used to hold either an ast.FuncDecl or ast.FuncLit but not both at the same time.
@@ -99,6 +212,22 @@ type (
 	}
 )
 
+// newContext returns a context for the analysis of the given package with empty caches.
+func newContext(pass *analysis.Pass, lookup *funcLookup, scc scc.State, comments ast.CommentMap) *context {
+	return &context{
+		pass:              pass,
+		lookup:            lookup,
+		scc:               scc,
+		comments:          comments,
+		assignedValues:    map[*types.Var]*assignedValues{},
+		mapValues:         map[*types.Var]*storedMapValues{},
+		undeclaredCallees: newUndeclaredCallees(),
+		constructorCalls:  map[*ast.CallExpr]struct{}{},
+		interfaceUses:     map[*types.TypeName]map[*types.TypeName]*ErrorInterface{},
+		functionFlows:     map[funcDeclOrLit]*functionFlow{},
+	}
+}
+
 func (f *funcDefinition) node() funcDeclOrLit {
 	if f.funcDecl != nil {
 		return f.funcDecl
@@ -121,20 +250,71 @@ func (f *funcDefinition) Type() *ast.FuncType {
 }
 
 func runVerify(pass *analysis.Pass) (interface{}, error) {
+	if _, err := loadOwners(cliArguments.ownersFile); err != nil {
+		return nil, err
+	}
+	if err := loadMessageTranslations(messagesPath()); err != nil {
+		return nil, err
+	}
+	if cliArguments.causeCode != "" && !isErrorCodeValid(cliArguments.causeCode) {
+		return nil, fmt.Errorf("-cause-code: error code %q %s", cliArguments.causeCode, FormatMessage(MsgInvalidCodeFormat))
+	}
+	if err := checkEngine(); err != nil {
+		return nil, err
+	}
+	if cliArguments.metricsFile != "" && runningAsVettool() {
+		// Every package is analysed in its own process, which would replace the metrics of the other packages.
+		return nil, fmt.Errorf("-metrics-out is not supported with go vet, run go-serum-analyzer on the packages directly")
+	}
+
+	finishMetrics := startMetrics(pass)
+	skipGeneratedFileDiagnostics(pass)
+
 	lookup := collectFunctions(pass)
 	comments := createCommentMap(pass)
 
+	if isPackageWithoutErrors(pass) {
+		// Nothing in the package can carry error codes, so there are no facts to export and nothing to verify.
+		// Symbols of the package may still have to be removed from the lockfile and the reports have to be written.
+		if err := checkLockfile(pass, funcCodesMap{}); err != nil {
+			return nil, err
+		}
+		c := newContext(pass, lookup, scc.StartSCC(), comments)
+		if err := reportSuppressions(c); err != nil {
+			return nil, err
+		}
+		if err := finishMetrics(); err != nil {
+			return nil, err
+		}
+		return &Result{pass, lookup, comments}, nil
+	}
+
 	findAndTagErrorTypes(pass, lookup)
+	exportCodeOptionFacts(pass, lookup)
+	exportErrorGroupFacts(pass)
 
 	interfaces := findErrorReturningInterfaces(pass)
 	exportInterfaceFacts(pass, interfaces)
+	exportInterfaceLiteralFacts(pass)
+
+	exportFuncTypeContractFacts(pass)
+	exportFuncAdapterFacts(pass, lookup)
 
 	funcsToAnalyse := findErrorReturningFunctions(pass, lookup)
+	funcsToAnalyse = append(funcsToAnalyse, findErrorContainerProducers(pass, lookup)...)
 
 	// Out of funcsToAnalyse get all functions that declare error codes and the actual codes they declare.
 	// In the remaining analysis we only look at the functions that declare error codes or get called by an analysed function.
 	funcClaims := findClaimedErrorCodes(pass, funcsToAnalyse)
 	exportErrorConstructorFacts(pass, funcClaims)
+	exportErrorPassthroughFacts(pass, funcClaims)
+	exportForwardedErrorConstructorFacts(pass, lookup, funcClaims)
+	exportWrapHelperFacts(pass, lookup)
+	trustedPackage := trustClaimedErrorCodes(pass, lookup, funcsToAnalyse, funcClaims)
+	if !trustedPackage {
+		skipOversizedComponents(pass, lookup, funcClaims)
+		trustBodylessFunctions(pass, lookup, funcsToAnalyse, funcClaims)
+	}
 
 	// Okay -- let's look at the functions that have made claims about their error codes.
 	// We'll explore deeply to find everything that can actually affect their error return value.
@@ -142,14 +322,22 @@ func runVerify(pass *analysis.Pass) (interface{}, error) {
 	// When we reach other function calls that declare their errors, that's good enough info (assuming they're also being checked for truthfulness).
 	// Anything else is trouble.
 	scc := scc.StartSCC() // SCC for handling of recursive functions
-	c := &context{pass, lookup, scc, comments}
+	c := newContext(pass, lookup, scc, comments)
+	var engine *ssaEngine
+	if cliArguments.engine == engineSSA {
+		engine = newSSAEngine(pass, funcClaims)
+	}
 	for funcDecl, claims := range funcClaims {
 		foundCodes, ok := lookup.foundCodes[funcDecl]
+		if engine != nil && !ok {
+			foundCodes, ok = engine.codesOf(funcDecl)
+		}
 		if !ok {
 			foundCodes = findErrorCodesInFunc(c, &funcDefinition{funcDecl, nil})
 		}
 
 		reportIfCodesDoNotMatch(pass, funcDecl, foundCodes, claims.codes)
+		reportIfExcludedCodesFound(pass, funcDecl, foundCodes)
 	}
 
 	// Export all claimed error codes as facts.
@@ -157,9 +345,44 @@ func runVerify(pass *analysis.Pass) (interface{}, error) {
 	// but on caller site only the documented behaviour matters.
 	exportErrorCodeFacts(pass, funcClaims)
 
-	findConversionsToErrorReturningInterfaces(c)
+	if err := checkLockfile(pass, funcClaims); err != nil {
+		return nil, err
+	}
+
+	// The bodies of the functions of trusted packages are not verified, only their docs are used.
+	if !trustedPackage {
+		findConversionsToErrorReturningInterfaces(c)
+		checkConflictingInterfaceUses(c)
+
+		checkFuncParamContracts(c)
+		findCallbacksViolatingContracts(c)
+		findWritesViolatingErrorFieldDocs(c)
+		reportUndeclaredCallees(c)
+
+		checkCodeComparisons(c)
+		checkErrorDetails(c, funcClaims)
+		checkConstructorCallArguments(c)
+	}
+
+	if cliArguments.formatDocs {
+		findNonCanonicalErrorDocs(pass)
+	}
+	if isSentenceCodeCheckEnabled() {
+		findSentenceCodes(pass)
+	}
+	if cliArguments.shadowedErrors {
+		findShadowedErrors(pass, funcClaims)
+	}
+
+	if err := reportSuppressions(c); err != nil {
+		return nil, err
+	}
+
+	if err := finishMetrics(); err != nil {
+		return nil, err
+	}
 
-	return nil, nil
+	return &Result{pass, lookup, comments}, nil
 }
 
 var tError = types.NewInterfaceType([]*types.Func{
@@ -205,7 +428,7 @@ func isErrorCodeValid(code string) bool {
 
 func checkErrorCodeValid(code string) error {
 	if !isErrorCodeValid(code) {
-		return fmt.Errorf("should match [a-zA-Z][a-zA-Z0-9\\-]*[a-zA-Z0-9]")
+		return newMessageError(MsgInvalidCodeFormat)
 	}
 	return nil
 }
@@ -215,6 +438,27 @@ func isMethod(funcDecl *ast.FuncDecl) bool {
 	return funcDecl != nil && funcDecl.Recv != nil && len(funcDecl.Recv.List) == 1
 }
 
+// isBuiltinCall checks if the given call expression is a call of the builtin function with the given name.
+func isBuiltinCall(pass *analysis.Pass, callExpr *ast.CallExpr, name string) bool {
+	ident, ok := astutil.Unparen(callExpr.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	builtin, ok := pass.TypesInfo.ObjectOf(ident).(*types.Builtin)
+	return ok && builtin.Name() == name
+}
+
+// isMethodExpression checks if the given expression is a method expression like `T.Method` or `(*T).Method`.
+// Calls of method expressions take the receiver as their first argument.
+func isMethodExpression(pass *analysis.Pass, expr ast.Expr) bool {
+	selector, ok := astutil.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	selection, ok := pass.TypesInfo.Selections[selector]
+	return ok && selection.Kind() == types.MethodExpr
+}
+
 // getNamedType casts the given type to *types.Named if possible,
 // unpacking pointers if they occur.
 // getNamedType returns nil, if said conversion fails.
@@ -256,22 +500,11 @@ func createCommentMap(pass *analysis.Pass) ast.CommentMap {
 	return comments
 }
 
-// findErrorDocs looks at the given comments and tries to find error code declarations.
-func findErrorDocs(comments *ast.CommentGroup) (CodeSet, string, bool, error) {
-	if comments == nil {
-		return nil, "", false, nil
-	}
-	return findErrorDocsSM{}.run(comments.Text())
-}
-
-// findErrorReturningFunctions looks for functions that return an error,
-// and emits a diagnostic if a function returns an error, but not as the last argument.
+// findErrorReturningFunctions looks for functions that return an error in any of their results.
 func findErrorReturningFunctions(pass *analysis.Pass, lookup *funcLookup) []*ast.FuncDecl {
-	// Let's look only at functions that return errors;
-	// and furthermore, errors as their last result (that's a normal enough convention, isn't it?).
-	//
-	// Returning more than one error will result in anything but the last one not being analysed.
-	// Returning an error in any result field but the last one will result in it not being analysed.
+	// Let's look only at functions that return errors.
+	// Usually the error is the last result, but every result that is an error is analysed,
+	// e.g. both results of a function returning `(error, error)`.
 	//
 	// We'll actually look for anything that _implements_ `error` (!), not just the literal type.
 	// Sometimes these will also, furthermore, perhaps implement our own extended error interface...
@@ -286,28 +519,55 @@ func findErrorReturningFunctions(pass *analysis.Pass, lookup *funcLookup) []*ast
 	return funcsToAnalyse
 }
 
-// checkFunctionReturnsError determines if the given type is a function that returns an error.
-// If the last result is not an error but one of the other results is, it emits a diagnostic.
+// checkFunctionReturnsError determines if the given type is a function that returns an error in any of its results.
 func checkFunctionReturnsError(pass *analysis.Pass, funcType *ast.FuncType) bool {
-	resultsList := funcType.Results
-	if resultsList == nil {
+	if funcType.Results == nil {
 		return false
 	}
 
-	lastResult := resultsList.List[len(resultsList.List)-1]
-	typ := pass.TypesInfo.TypeOf(lastResult.Type)
-	if !types.Implements(typ, tError) {
-		// Emit diagnostic if an error is returned as non-last argument
-		for _, result := range resultsList.List {
-			typ := pass.TypesInfo.TypeOf(result.Type)
-			if types.Implements(typ, tError) {
-				pass.ReportRangef(result, "error should be returned as the last argument")
-			}
+	for _, result := range funcType.Results.List {
+		if typ := pass.TypesInfo.TypeOf(result.Type); typ != nil && types.Implements(typ, tError) {
+			return true
 		}
-		return false
 	}
+	return false
+}
 
-	return true
+// errorResult is a result of a function that is an error.
+type errorResult struct {
+	position int
+	name     *ast.Ident // nil for unnamed results
+}
+
+// findErrorResults finds all results of the given function type that are errors or error containers.
+// Error containers are only returned by functions found by findErrorContainerProducers.
+func findErrorResults(pass *analysis.Pass, funcType *ast.FuncType) []errorResult {
+	if funcType.Results == nil {
+		return nil
+	}
+
+	var result []errorResult
+	position := 0
+	for _, field := range funcType.Results.List {
+		typ := pass.TypesInfo.TypeOf(field.Type)
+		isError := typ != nil && (types.Implements(typ, tError) || isErrorContainer(typ) || isErrorIterator(typ))
+
+		if len(field.Names) == 0 {
+			if isError {
+				result = append(result, errorResult{position, nil})
+			}
+			position++
+			continue
+		}
+
+		for _, name := range field.Names {
+			if isError {
+				result = append(result, errorResult{position, name})
+			}
+			position++
+		}
+	}
+	return result
 }
 
 // findClaimedErrorCodes finds the error codes claimed by the given functions,
@@ -316,9 +576,14 @@ func checkFunctionReturnsError(pass *analysis.Pass, funcType *ast.FuncType) bool
 func findClaimedErrorCodes(pass *analysis.Pass, funcsToAnalyse []*ast.FuncDecl) funcCodesMap {
 	result := funcCodesMap{}
 	for _, funcDecl := range funcsToAnalyse {
-		codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(funcDecl.Doc)
+		// Setters of the error code field return their receiver, the code is taken from the call instead.
+		if isCodeSetterMethod(pass, funcDecl) {
+			continue
+		}
+
+		codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, funcDecl.Doc)
 		if err != nil {
-			pass.Reportf(funcDecl.Pos(), "function %q has odd docstring: %s", funcDecl.Name.Name, err)
+			reportPos(pass, funcDecl.Pos(), MsgOddDocstring, funcDecl.Name.Name, err)
 			continue
 		}
 
@@ -326,24 +591,52 @@ func findClaimedErrorCodes(pass *analysis.Pass, funcsToAnalyse []*ast.FuncDecl)
 		if !ok {
 			continue
 		}
+		if errorCodeParam != nil && !errorCodeParam.union {
+			errorCodeParam.prefix = findErrorCodeParamPrefix(pass, funcDecl.Doc)
+		}
 
-		if len(codes) == 0 && !declaredNoCodesOk && errorCodeParam == nil {
+		passthrough, ok := findPassthroughParamPositions(pass, funcDecl)
+		if !ok {
+			continue
+		}
+
+		if len(codes) == 0 && !declaredNoCodesOk && errorCodeParam == nil && len(passthrough) == 0 {
 			// Exclude Cause() methods of error types from having to declare error codes.
 			// If a Cause() method declares error codes, treat it like every other method.
 			if isMethod(funcDecl) {
 				receiverType := pass.TypesInfo.TypeOf(funcDecl.Recv.List[0].Type)
-				if types.Implements(receiverType, tReeErrorWithCause) && funcDecl.Name.Name == "Cause" {
+				if types.Implements(receiverType, tReeErrorWithCause) && funcDecl.Name.Name == causeMethod {
 					continue
 				}
 			}
 
+			// Exclude accessor methods of error containers, e.g. Err(), their codes are declared by the functions returning the container.
+			if isErrorContainerAccessorMethod(pass, funcDecl) {
+				continue
+			}
+
+			// Exclude wait methods of annotated error groups, their codes are those of the functions submitted at the call site.
+			if isErrorGroupWaitMethod(pass, funcDecl) {
+				continue
+			}
+
+			// Exclude adapter methods of function types, their codes are those of the function converted to the adapter type.
+			if isFuncAdapterMethod(pass, pass.TypesInfo.Defs[funcDecl.Name]) {
+				continue
+			}
+
+			// Exclude functions without body, they are reported by trustBodylessFunctions.
+			if funcDecl.Body == nil {
+				continue
+			}
+
 			// Warn directly about any functions that are exported if they return errors,
 			// but don't declare error codes in their docs.
 			if cliArguments.requireErrorCodes && funcDecl.Name.IsExported() {
-				pass.Reportf(funcDecl.Pos(), "function %q is exported, but does not declare any error codes", funcDecl.Name.Name)
+				reportPos(pass, funcDecl.Pos(), MsgExportedWithoutCodes, funcDecl.Name.Name)
 			}
 		} else {
-			result[funcDecl] = funcCodes{codes, errorCodeParam}
+			result[funcDecl] = funcCodes{codes, errorCodeParam, passthrough}
 		}
 	}
 
@@ -365,17 +658,21 @@ func findErrorCodeParamIdent(pass *analysis.Pass, funcType *ast.FuncType, errorC
 				continue
 			}
 
+			if isVariadicErrorParam(pass, param) {
+				return &funcCodeParam{paramIdent, position, true, ""}, true
+			}
+
 			basic, ok := pass.TypesInfo.TypeOf(paramIdent).(*types.Basic)
 			if !ok || basic.Name() != "string" {
-				pass.ReportRangef(paramIdent, "error code parameter %q has to be of type string", errorCodeParamName)
+				report(pass, paramIdent, MsgCodeParamNotString, errorCodeParamName)
 				return nil, false
 			}
 
-			return &funcCodeParam{paramIdent, position}, true
+			return &funcCodeParam{paramIdent, position, false, ""}, true
 		}
 	}
 
-	pass.Reportf(funcType.Pos(), "declared error code parameter %q could not be found in parameter list", errorCodeParamName)
+	reportPos(pass, funcType.Pos(), MsgCodeParamNotFound, errorCodeParamName)
 	return nil, false
 }
 
@@ -388,21 +685,27 @@ func exportErrorConstructorFacts(pass *analysis.Pass, codes funcCodesMap) {
 	}
 }
 
-// exportErrorConstructorFact exports the error code param for the given function as an ErrorConstructor fact.
+// exportErrorConstructorFact exports the error code param for the given function as an ErrorConstructor fact,
+// or as an ErrorUnion fact if the param is a variadic error parameter.
 func exportErrorConstructorFact(pass *analysis.Pass, funcIdent *ast.Ident, param *funcCodeParam) {
 	definition, ok := pass.TypesInfo.Defs[funcIdent]
 	if !ok {
-		logf("Could not find definition for function %q!", funcIdent.Name)
+		reportInternalError(pass, funcIdent, "could not find definition for function %q", funcIdent.Name)
 		return
 	}
 
 	fn, ok := definition.(*types.Func)
 	if !ok {
-		logf("Definition for given identifier %q is not a function!", funcIdent.Name)
+		reportInternalError(pass, funcIdent, "definition of identifier %q is not a function", funcIdent.Name)
+		return
+	}
+
+	if param.union {
+		pass.ExportObjectFact(fn, &ErrorUnion{param.position})
 		return
 	}
 
-	fact := &ErrorConstructor{param.position}
+	fact := &ErrorConstructor{param.position, param.prefix}
 	pass.ExportObjectFact(fn, fact)
 }
 
@@ -417,13 +720,13 @@ func exportErrorCodeFacts(pass *analysis.Pass, codes funcCodesMap) {
 func exportErrorCodesFact(pass *analysis.Pass, funcIdent *ast.Ident, codes CodeSet) {
 	definition, ok := pass.TypesInfo.Defs[funcIdent]
 	if !ok {
-		logf("Could not find definition for function %q!", funcIdent.Name)
+		reportInternalError(pass, funcIdent, "could not find definition for function %q", funcIdent.Name)
 		return
 	}
 
 	fn, ok := definition.(*types.Func)
 	if !ok {
-		logf("Definition for given identifier %q is not a function!", funcIdent.Name)
+		reportInternalError(pass, funcIdent, "definition of identifier %q is not a function", funcIdent.Name)
 		return
 	}
 
@@ -433,19 +736,22 @@ func exportErrorCodesFact(pass *analysis.Pass, funcIdent *ast.Ident, codes CodeS
 
 // checkIfErrorCodesMatch checks if the two given code sets match and
 // generates error messages if they don't match.
+//
+// Claimed wildcards cover all found codes with the respective prefix,
+// and are unused if they do not cover any of the found codes.
 func checkIfErrorCodesMatch(foundCodes CodeSet, claimedCodes CodeSet) (bool, string) {
-	missingCodes := Difference(foundCodes, claimedCodes).Slice()
-	unusedCodes := Difference(claimedCodes, foundCodes).Slice()
+	missingCodes := findUncoveredCodes(foundCodes, claimedCodes).Slice()
+	unusedCodes := findUnusedClaims(claimedCodes, foundCodes).Slice()
 	var errorMessages []string
 
 	if len(missingCodes) > 0 {
 		sort.Strings(missingCodes)
-		errorMessages = append(errorMessages, fmt.Sprintf("missing codes: %v", missingCodes))
+		errorMessages = append(errorMessages, FormatMessage(MsgMissingCodes, missingCodes))
 	}
 
 	if len(unusedCodes) > 0 {
 		sort.Strings(unusedCodes)
-		errorMessages = append(errorMessages, fmt.Sprintf("unused codes: %v", unusedCodes))
+		errorMessages = append(errorMessages, FormatMessage(MsgUnusedCodes, unusedCodes))
 	}
 
 	errorCodesMatch := len(errorMessages) == 0
@@ -457,7 +763,30 @@ func checkIfErrorCodesMatch(foundCodes CodeSet, claimedCodes CodeSet) (bool, str
 func reportIfCodesDoNotMatch(pass *analysis.Pass, funcDecl *ast.FuncDecl, foundCodes CodeSet, claimedCodes CodeSet) {
 	errorCodesMatch, errorMessage := checkIfErrorCodesMatch(foundCodes, claimedCodes)
 	if !errorCodesMatch {
-		pass.Reportf(funcDecl.Pos(), "function %q has a mismatch of declared and actual error codes: %s", funcDecl.Name.Name, errorMessage)
+		codes := Union(findUncoveredCodes(foundCodes, claimedCodes), findUnusedClaims(claimedCodes, foundCodes)).Slice()
+		reportPosForCodes(pass, funcDecl.Pos(), codes, MsgCodesMismatch, funcDecl.Name.Name, errorMessage)
+	}
+}
+
+// reportIfExcludedCodesFound emits a diagnostic for every code declared as never returned by a negative claim,
+// which may be returned by the given function.
+//
+// A code may be returned if it was found, or if it is covered by a found wildcard, e.g. of a called function.
+// Negative claims of wildcards are violated by all found codes they cover.
+func reportIfExcludedCodesFound(pass *analysis.Pass, funcDecl *ast.FuncDecl, foundCodes CodeSet) {
+	excludedCodes := findExcludedErrorDocs(pass, funcDecl.Doc).Slice()
+	sort.Strings(excludedCodes)
+	for _, excluded := range excludedCodes {
+		var returned []string
+		for found := range foundCodes {
+			if codeMatchesClaim(found, excluded) || codeMatchesClaim(excluded, found) {
+				returned = append(returned, found)
+			}
+		}
+		if len(returned) > 0 {
+			sort.Strings(returned)
+			reportPosForCodes(pass, funcDecl.Pos(), returned, MsgExcludedCodeFound, funcDecl.Name.Name, excluded, returned)
+		}
 	}
 }
 
@@ -473,12 +802,18 @@ func findErrorCodesInFunc(c *context, function *funcDefinition) CodeSet {
 	paramCodes := ectractErrorCodesFromConstructor(c, function)
 	result = Union(result, paramCodes)
 
-	returnCodes := findErrorCodesInFunctionReturnStmts(c, visitedIdents, function)
+	returnCodes := findErrorCodesInFunctionReturnStmts(c, visitedIdents, function, function)
 	result = Union(result, returnCodes)
 
 	assignedCodes := findCodesAssignedToErrorCodeFields(pass, function, visitedIdents)
 	result = Union(result, assignedCodes)
 
+	recoveredCodes := findErrorCodesFromDeferredRecover(pass, function)
+	result = Union(result, recoveredCodes)
+
+	recoveredLiteralCodes := findErrorCodesFromDeferredRecoverLiterals(c, visitedIdents, function)
+	result = Union(result, recoveredLiteralCodes)
+
 	lookup.foundCodes[function.node()] = result
 
 	isComponentRoot, component := scc.EndVisit(function.node())
@@ -491,21 +826,33 @@ func findErrorCodesInFunc(c *context, function *funcDefinition) CodeSet {
 
 // findErrorCodesInFunctionReturnStmts looks at all return statement of the given (error returning) function
 // and figures out which error codes may be returned by that statement.
-func findErrorCodesInFunctionReturnStmts(c *context, visitedIdents map[*ast.Object]struct{}, function *funcDefinition) CodeSet {
+//
+// The return statements are taken from returningFunc, while the returned expressions are analysed in the scope of function.
+// Both are the same, except for function literals that are analysed inline as part of the enclosing function.
+func findErrorCodesInFunctionReturnStmts(c *context, visitedIdents map[*ast.Object]struct{}, returningFunc, function *funcDefinition) CodeSet {
 	result := Set()
 
-	ast.Inspect(function.body(), func(node ast.Node) bool {
+	var inspectNode func(node ast.Node) bool
+	inspectNode = func(node ast.Node) bool {
 		switch stmt := node.(type) {
 		case *ast.FuncLit:
 			return false // We don't want to see return statements from in a nested function right now.
+		case *ast.IfStmt, *ast.SwitchStmt:
+			if cliArguments.pruneDeadBranches {
+				return inspectLiveBranches(c.pass, stmt.(ast.Stmt), inspectNode)
+			}
 		case *ast.ReturnStmt:
+			if isUnreachable(c.pass, returningFunc, stmt) {
+				return false // e.g. after a call to panic, the returned errors can never be seen by callers
+			}
+
 			annotations := getReturnStmtAnnotations(c, stmt)
 			if annotations != nil && annotations.shouldOverwrite {
 				result = Union(result, annotations.overwrite)
 				return false
 			}
 
-			returnCodes := findErrorCodesInReturnStmt(c, visitedIdents, stmt, function)
+			returnCodes := findErrorCodesInReturnStmt(c, visitedIdents, stmt, returningFunc, function)
 			if annotations != nil {
 				returnCodes = Difference(returnCodes, annotations.subCodes)
 				returnCodes = Union(returnCodes, annotations.addCodes)
@@ -515,7 +862,8 @@ func findErrorCodesInFunctionReturnStmts(c *context, visitedIdents map[*ast.Obje
 			return false
 		}
 		return true
-	})
+	}
+	ast.Inspect(returningFunc.body(), inspectNode)
 
 	return result
 }
@@ -543,33 +891,42 @@ func unifyAnalysisResultForComponent(lookup *funcLookup, component scc.Component
 	return result
 }
 
-// findErrorCodesInReturnStmt finds all error codes that originate from the given return statement.
+// findErrorCodesInReturnStmt finds all error codes that originate from the given return statement,
+// combining the error codes of all results that are errors.
 //
 // If the return statement results list is empty (i.e. `return`), then the error codes are gathered from
-// the taint spread of the named return variable for the error.
-func findErrorCodesInReturnStmt(c *context, visitedIdents map[*ast.Object]struct{}, stmt *ast.ReturnStmt, function *funcDefinition) CodeSet {
+// the taint spread of the named return variables for the errors.
+// If the return statement returns the results of a single function call (i.e. `return f()`),
+// the error codes are those of the call.
+func findErrorCodesInReturnStmt(c *context, visitedIdents map[*ast.Object]struct{}, stmt *ast.ReturnStmt, returningFunc, function *funcDefinition) CodeSet {
+	errorResults := findErrorResults(c.pass, returningFunc.Type())
+	if len(errorResults) == 0 {
+		panic("should be unreachable: we already know that the function signature contains an error result.")
+	}
+
 	// stmt.Results can also be nil, in which case you have to look back at vars in the func sig.
-	var resultExpression ast.Expr
-	if len(stmt.Results) == 0 {
-		resultTypes := function.Type().Results.List
-		if len(resultTypes) == 0 {
-			panic("should be unreachable: we already know that the function signature contains an error result.")
+	var resultExpressions []ast.Expr
+	switch {
+	case len(stmt.Results) == 0:
+		for _, errorResult := range errorResults {
+			if errorResult.name == nil {
+				panic("should be unreachable: an empty return statement requires either empty result list or named results.")
+			}
+			resultExpressions = append(resultExpressions, errorResult.name)
 		}
-
-		resultIdents := resultTypes[len(resultTypes)-1].Names
-		if len(resultIdents) == 0 {
-			panic("should be unreachable: an empty return statement requires either empty result list or named results.")
+	case len(stmt.Results) == 1:
+		resultExpressions = append(resultExpressions, stmt.Results[0])
+	default:
+		for _, errorResult := range errorResults {
+			resultExpressions = append(resultExpressions, stmt.Results[errorResult.position])
 		}
-
-		resultExpression = resultIdents[len(resultIdents)-1]
-	} else {
-		resultExpression = stmt.Results[len(stmt.Results)-1]
 	}
 
-	if resultExpression != nil {
-		return findErrorCodesInExpression(c, visitedIdents, resultExpression, function)
+	result := Set()
+	for _, resultExpression := range resultExpressions {
+		result = Union(result, findErrorCodesInExpression(c, visitedIdents, resultExpression, function))
 	}
-	return nil
+	return result
 }
 
 // findErrorCodesInExpression finds all error codes that originate from the given expression.
@@ -581,13 +938,44 @@ func findErrorCodesInExpression(c *context, visitedIdents map[*ast.Object]struct
 	// - You can have an `*ast.SelectorExpr` (returning a variable from in a structure).
 	// - You can have an `*ast.CallExpr` (aka returning the result of a function call).
 	// - You can have an `*ast.UnaryExpr` (probably about to be an '&' and then a structure literal, but could be other things too...).
+	// - You can have an `*ast.IndexExpr` (looking up a pre-built error in a map).
 	// - This is probably not an exhaustive list...
+	//
+	// The expression might also be an error container (e.g. returned from a function returning `Result[T]`)
+	// or a slice of errors, which carries the error codes of the errors stored in it.
+	if typ := pass.TypesInfo.TypeOf(expr); isErrorContainer(typ) || isErrorSlice(typ) {
+		return findErrorCodesInErrorContainer(c, visitedIdents, expr, startingFunc)
+	}
+	// The expression might also be an iterator yielding errors, e.g. returned from a function returning `iter.Seq2[T, error]`.
+	if isErrorIterator(pass.TypesInfo.TypeOf(expr)) {
+		return findErrorCodesInErrorIterator(c, visitedIdents, expr, startingFunc)
+	}
+
 	switch expr := astutil.Unparen(expr).(type) {
 	case *ast.CallExpr:
-		return findErrorCodesInCallExpression(c, expr, startingFunc)
+		return findErrorCodesInCallExpression(c, visitedIdents, expr, startingFunc)
 	case *ast.Ident:
+		if guard := getTypeSwitchGuard(expr); guard != nil {
+			// The type of the ident depends on the case clause of the type switch it is used in.
+			return findErrorCodesInTypeAssertion(c, visitedIdents, guard.X, pass.TypesInfo.TypeOf(expr), expr.Obj, startingFunc)
+		}
+		if _, ok := pass.TypesInfo.ObjectOf(expr).(*types.Const); ok {
+			// Constant errors, e.g. `const ErrTimeout CodeError = "timeout"`, are values like literals.
+			return extractErrorCodesFromAffector(pass, lookup, startingFunc, expr)
+		}
 		return findErrorCodesFromIdentTaint(c, visitedIdents, expr, startingFunc)
+	case *ast.TypeAssertExpr:
+		var assertedType types.Type
+		if expr.Type != nil { // nil for the guard of a type switch, the type then depends on the case clause
+			assertedType = pass.TypesInfo.TypeOf(expr.Type)
+		}
+		return findErrorCodesInTypeAssertion(c, visitedIdents, expr.X, assertedType, nil, startingFunc)
 	case *ast.UnaryExpr:
+		// This might be receiving an error from a channel.
+		if isChannelReceive(c, expr) {
+			return findErrorCodesInChannelReceive(c, visitedIdents, expr, startingFunc)
+		}
+
 		// This might be creating a pointer, which might fulfill the error interface.  If so, we're done (and it's important to remember the pointerness).
 		if expr.Op == token.AND && types.Implements(pass.TypesInfo.TypeOf(expr), tError) {
 			if ident, ok := astutil.Unparen(expr.X).(*ast.Ident); ok {
@@ -598,12 +986,19 @@ func findErrorCodesInExpression(c *context, visitedIdents map[*ast.Object]struct
 		}
 
 		// If it's not fulfilling the error interface it's not supported
-		pass.ReportRangef(expr, "expression does not implement valid error type")
+		report(pass, expr, MsgNotAnError)
 		return nil
+	case *ast.IndexExpr:
+		if ident, ok := astutil.Unparen(expr.X).(*ast.Ident); ok && isErrorUnionParam(pass, startingFunc, ident) {
+			return Set() // The error codes of the arguments are added at each call site.
+		}
+		return findErrorCodesInMapIndexExpression(c, visitedIdents, expr, startingFunc)
+	case *ast.SelectorExpr:
+		return findErrorCodesInFieldSelection(c, visitedIdents, expr, startingFunc)
 	case *ast.CompositeLit, *ast.BasicLit: // Actual value creation!
 		return extractErrorCodesFromAffector(pass, lookup, startingFunc, expr)
 	default:
-		pass.ReportRangef(expr, "expression is not supported in error code analysis")
+		report(pass, expr, MsgUnsupportedExpression)
 		return nil
 	}
 }
@@ -612,136 +1007,259 @@ func findErrorCodesInExpression(c *context, visitedIdents map[*ast.Object]struct
 //
 // The given CallExpr could be:
 //   - a CallExpr that targets another function that has declared error codes (yay!)
-//   - a CallExpr that crosses package boundaries (get declared error codes or fail)
+//   - a CallExpr that crosses package boundaries, calling a function or method (get declared error codes from facts or fail)
 //   - a CallExpr that's an interface (we can't really look deeper than that)
 //   - a CallExpr that targets another function in this package (recurse or load from cache)
 //   - a CallExpr that targets a function literal
-func findErrorCodesInCallExpression(c *context, callExpr *ast.CallExpr, startingFunc *funcDefinition) CodeSet {
+//   - a CallExpr that immediately invokes a function literal (analysed inline)
+//   - a CallExpr that waits for an errgroup.Group (union of the group's functions)
+//   - a CallExpr that passes errors to a variadic error parameter declared with "param:" (union of the arguments)
+//   - a CallExpr that passes errors to parameters declared with "param-passthrough:" (union with the arguments)
+func findErrorCodesInCallExpression(c *context, visitedIdents map[*ast.Object]struct{}, callExpr *ast.CallExpr, startingFunc *funcDefinition) CodeSet {
+	if funcLit, ok := astutil.Unparen(callExpr.Fun).(*ast.FuncLit); ok && isNodeInsideFunction(startingFunc, funcLit) {
+		// The function literal is called right where it's defined, e.g. `return func() error { ... }()`.
+		// Analysing it inline allows it to use variables and error code parameters of the enclosing function.
+		literal := &funcDefinition{nil, funcLit}
+		return findErrorCodesInFunctionReturnStmts(c, visitedIdents, literal, startingFunc)
+	}
+
+	// Converting to an interface, e.g. `error(err)`, keeps the error codes of the converted value.
+	if converted := unwrapInterfaceConversions(c.pass, callExpr); converted != ast.Expr(callExpr) {
+		return findErrorCodesInExpression(c, visitedIdents, converted, startingFunc)
+	}
+
 	callee := typeutil.Callee(c.pass.TypesInfo, callExpr)
-	return findErrorCodesFromFunctionCall(c, startingFunc, callExpr.Fun, callee, callExpr)
+	if callee == nil {
+		callee = getReferencedFunc(c.pass, callExpr.Fun) // e.g. instantiation of a generic function
+	}
+	if isErrorGroupWait(c.pass, callee) {
+		return findErrorCodesFromErrgroupWait(c, callExpr, startingFunc)
+	}
+	if codes, ok := findErrorCodesFromErrorContainerAccess(c, visitedIdents, callExpr, callee, startingFunc); ok {
+		return codes
+	}
+
+	unionCodes := findErrorCodesFromErrorUnionCall(c, visitedIdents, callExpr, callee, startingFunc)
+	unionCodes = Union(unionCodes, findErrorCodesFromPassthroughCall(c, visitedIdents, callExpr, callee, startingFunc))
+	return Union(findErrorCodesFromFunctionCall(c, startingFunc, callExpr.Fun, callee, callExpr), unionCodes)
+}
+
+// unwrapInterfaceConversions returns the expression converted to an interface by the given expression,
+// e.g. `err` for `error(err)` or `error(CodedError(err))`, which keeps the error codes of the converted value.
+// Other expressions are returned unchanged, apart from parentheses.
+func unwrapInterfaceConversions(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	for {
+		expr = astutil.Unparen(expr)
+		callExpr, ok := expr.(*ast.CallExpr)
+		if !ok || len(callExpr.Args) != 1 {
+			return expr
+		}
+		if conversion := pass.TypesInfo.Types[callExpr.Fun]; !conversion.IsType() || !types.IsInterface(conversion.Type) {
+			return expr
+		}
+		expr = callExpr.Args[0]
+	}
 }
 
 // findErrorCodesFromFunctionCall finds error codes that originate from the given function or method if it was called.
 //
 // The provided callExpr can be nil if no respective *ast.CallExpr exists.
 func findErrorCodesFromFunctionCall(c *context, startingFunc *funcDefinition, calledFunction ast.Expr, callee types.Object, callExpr *ast.CallExpr) CodeSet {
-	pass, lookup, scc := c.pass, c.lookup, c.scc
+	pass, lookup := c.pass, c.lookup
+
+	// Calling a setter of the error code field, e.g. `New().WithCode("test-error")`, results in the set code only.
+	if callExpr != nil && isCodeSetterCall(pass, callExpr) {
+		result := Set()
+		if code, ok := extractErrorCodeFromSetterCall(pass, startingFunc, callExpr); ok {
+			result.Add(code)
+		}
+		return result
+	}
 
 	// Get codes that originate from the callExpr itself: e.g. test-error when calling NewError("test-error")
-	result := Set()
-	code, ok := extractErrorCodeFromConstructorCall(pass, startingFunc, calledFunction, callee, callExpr)
-	if ok {
-		result.Add(code)
+	result := extractErrorCodesFromConstructorCall(c, startingFunc, calledFunction, callee, callExpr)
+
+	// Functional options passed to the call may set the error code, e.g. `New(WithCode("test-error"))`.
+	if callExpr != nil {
+		result = Union(result, extractErrorCodesFromOptionArgs(pass, startingFunc, callExpr))
 	}
 
 	// We first look if the error codes are already computed and stored as a fact.
 	// If so we use those, otherwise we try to recurse and compute error codes for that function.
+	if fn, ok := callee.(*types.Func); ok {
+		callee = getOriginMethod(fn)
+	}
 	var fact ErrorCodes
 	if callee != nil && pass.ImportObjectFact(callee, &fact) {
 		return Union(result, fact.Codes)
 	}
 
+	// Functions of opaque packages are trusted to return no error codes, as long as they do not declare any.
+	if callee != nil && callee.Pkg() != pass.Pkg && isOpaquePackage(callee.Pkg()) {
+		return result
+	}
+
+	// Calling a function value, for which a contract declares the error codes.
+	if codes, ok := findErrorCodesFromFuncContract(c, calledFunction); ok {
+		return Union(result, codes)
+	}
+
+	// Calling an interface method without declared error codes, which might be any of its implementations.
+	if iface := getInterfaceOfMethod(callee); iface != nil && cliArguments.unionImplementations {
+		if codes, ok := findErrorCodesFromImplementations(c, startingFunc, iface, callee.Name()); ok {
+			return Union(result, codes)
+		}
+	}
+
+	// Unwrapping the cause of an error, which does not declare the error codes of its causes.
+	if codes, ok := findErrorCodesFromCauseCall(callee); ok {
+		return Union(result, codes)
+	}
+
+	// Type conversion, e.g. `StringError("message")`, `(*Error)(err)` or `inner.StringError("message")`,
+	// or creation of an error value with the builtin new, e.g. `new(Error)`.
+	if pass.TypesInfo.Types[calledFunction].IsType() || (callExpr != nil && isBuiltinCall(pass, callExpr, "new")) {
+		if callExpr != nil {
+			return extractErrorCodesFromAffector(pass, lookup, startingFunc, callExpr)
+		}
+		return Set()
+	}
+
 	calledFuncDef := funcDefinition{nil, nil}
 
-	switch calledExpression := astutil.Unparen(calledFunction).(type) {
-	case *ast.Ident: // this is what calls in your own package look like.
-		if calledExpression.Obj == nil {
+	switch calledExpression := unwrapInstantiation(pass, calledFunction).(type) {
+	case *ast.Ident: // this is what calls in your own package (or of dot-imported functions) look like.
+		if obj := pass.TypesInfo.Uses[calledExpression]; obj != nil && obj.Pkg() != nil && obj.Pkg() != pass.Pkg {
+			// Dot-imported functions are resolved like qualified calls to other packages,
+			// so their error codes are only known from the facts checked above.
+			reportUndeclaredCallee(c, obj, calledExpression, MsgDotImportUndeclared, calledExpression.Name)
+			return Set()
+		}
+
+		if calledExpression.Obj == nil { // declared in another file of this package
 			function, ok := lookup.functions[calledExpression.Name]
 
 			if ok {
 				calledFuncDef.funcDecl = function
 			} else {
-				pass.ReportRangef(calledExpression, "function %q in dot-imported package does not declare error codes", calledExpression.Name)
+				// A global variable holding a function, like the ones declared in the same file.
+				if startingFunc.funcDecl != nil {
+					report(pass, calledExpression, MsgLambdaOutOfScope)
+				} else {
+					report(pass, calledExpression, MsgLambdaOutOfScopeInLit)
+				}
 				return Set()
 			}
 		} else {
 			switch funcDecl := calledExpression.Obj.Decl.(type) {
 			case *ast.FuncDecl: // Noramal function call
 				calledFuncDef.funcDecl = funcDecl
-			case *ast.TypeSpec: // Type conversion
-				if callExpr != nil {
-					return extractErrorCodesFromAffector(pass, lookup, startingFunc, callExpr)
-				} else {
-					return Set()
-				}
 			default: // Lambda function call (e.g. *ast.ValueSpec, *ast.AssignStmt)
-				return findErrorCodesFromAllAssignedLambdas(c, calledExpression, startingFunc)
+				return findErrorCodesFromAllAssignedLambdas(c, calledExpression, startingFunc, callExpr)
 			}
 		}
 	case *ast.SelectorExpr: // this is what calls to other packages look like. (but can also be method call on a type)
 		if target, ok := astutil.Unparen(calledExpression.X).(*ast.Ident); ok {
-			if obj, ok := pass.TypesInfo.ObjectOf(target).(*types.PkgName); ok {
-				// We're calling a function in a package that does not have declared error codes
-				pass.ReportRangef(calledExpression, "function %q in package %q does not declare error codes", calledExpression.Sel.Name, obj.Imported().Name())
+			// Only the type information knows whether the identifier refers to an import,
+			// as it may be an alias of the package or a local variable shadowing the package name.
+			if obj, ok := pass.TypesInfo.Uses[target].(*types.PkgName); ok {
+				// We're calling a function in a package that does not have declared error codes,
+				// which is named by its actual name, even if it was imported using an alias.
+				reportUndeclaredCallee(c, callee, calledExpression, MsgPackageFuncUndeclared, calledExpression.Sel.Name, obj.Imported().Name())
 				return Set()
 			}
 		}
 
 		// This case is gonna be harder than functions: We need to figure out which function declaration applies,
 		// because there is no object information provided for methods calls.
-		selection := pass.TypesInfo.Selections[calledExpression]
-		calledFuncDef.funcDecl = lookup.searchMethod(pass, selection.Recv(), calledExpression.Sel.Name)
+		// If no selection was recorded, the method is resolved on the type of the receiver expression instead,
+		// e.g. the result type of the inner call of a chained call like `a.B().C()`.
+		receiverType := pass.TypesInfo.TypeOf(calledExpression.X)
+		if selection, ok := pass.TypesInfo.Selections[calledExpression]; ok {
+			receiverType = selection.Recv()
+		}
+		if receiverType != nil {
+			calledFuncDef.funcDecl = lookup.searchMethod(pass, receiverType, calledExpression.Sel.Name)
+		}
 	case *ast.FuncLit:
 		calledFuncDef.funcLit = calledExpression
 	default:
-		pass.ReportRangef(calledExpression, "invalid error source: definition of the unnamed function could not be found")
+		report(pass, calledExpression, MsgUnnamedFunc)
 		return Set()
 	}
 
 	if calledFuncDef.funcDecl != nil || calledFuncDef.funcLit != nil {
-		shouldRecurse := scc.HandleEdge(startingFunc.node(), calledFuncDef.node())
-		if shouldRecurse {
-			newCodes := findErrorCodesInFunc(c, &calledFuncDef)
-			result = Union(result, newCodes)
-			scc.AfterRecurse(startingFunc.node(), calledFuncDef.node())
-		} else if cachedResult, ok := lookup.foundCodes[calledFuncDef.node()]; ok {
-			result = Union(result, cachedResult)
-		}
+		newCodes := findErrorCodesInCalledFunc(c, startingFunc, &calledFuncDef)
+		result = Union(result, newCodes)
 	} else {
 		// Could e.g. be a method which is defined in another package
-		pass.ReportRangef(calledFunction, "called function does not declare error codes")
+		reportUndeclaredCallee(c, callee, calledFunction, MsgCalleeUndeclared)
 	}
 
 	return result
 }
 
+// findErrorCodesInCalledFunc finds error codes returned by the given function declaration or literal,
+// which is called from startingFunc.
+//
+// The called function is only analysed once, further calls use the cached result.
+// Recursive calls are handled using the SCC state of the given context.
+func findErrorCodesInCalledFunc(c *context, startingFunc *funcDefinition, calledFunc *funcDefinition) CodeSet {
+	lookup, scc := c.lookup, c.scc
+
+	if _, ok := lookup.trusted[calledFunc.node()]; ok {
+		return lookup.foundCodes[calledFunc.node()]
+	}
+
+	shouldRecurse := scc.HandleEdge(startingFunc.node(), calledFunc.node())
+	if shouldRecurse {
+		result := findErrorCodesInFunc(c, calledFunc)
+		scc.AfterRecurse(startingFunc.node(), calledFunc.node())
+		return result
+	}
+	return lookup.foundCodes[calledFunc.node()]
+}
+
 // findErrorCodesFromAllAssignedLambdas finds error codes in the given function,
 // by looking into the definition of all lambdas directly or indirectly assigned to the given identifier.
-func findErrorCodesFromAllAssignedLambdas(c *context, ident *ast.Ident, function *funcDefinition) CodeSet {
+//
+// The given call expression is the call of the identifier, which is used to find the error code argument,
+// if an error constructor is assigned to the identifier, e.g. `fail := factory.Fail; fail("some-error")`.
+func findErrorCodesFromAllAssignedLambdas(c *context, ident *ast.Ident, function *funcDefinition, callExpr *ast.CallExpr) CodeSet {
 	pass := c.pass
 
 	taintResult := taintSpreadForIdentOfImmutableType(pass, map[*ast.Object]struct{}{}, ident, function)
 
 	for _, badIdent := range taintResult.identOutOfScope {
 		if function.funcDecl != nil { // expression is inside a function
-			pass.ReportRangef(badIdent, "error returning function literal may not be a parameter, receiver or global variable")
+			report(pass, badIdent, MsgLambdaOutOfScope)
 		} else { // expression is inside a lambda (function literal)
-			pass.ReportRangef(badIdent, "error returning function literal may not be a parameter, global variable or other variables declared outside of the function body")
+			report(pass, badIdent, MsgLambdaOutOfScopeInLit)
 		}
 	}
 
 	for _, destruct := range taintResult.destructAssignment {
-		pass.ReportRangef(destruct.source, "unsupported: assigning result of function call to variable %q is not allowed", destruct.target.Name)
+		report(pass, destruct.source, MsgAssignedCallResult, destruct.target.Name)
 	}
 
 	result := Set()
 	for _, expr := range taintResult.expressions {
-		newCodes := findErrorCodesInLambdaAssignment(c, ident, expr, function)
+		newCodes := findErrorCodesInLambdaAssignment(c, ident, expr, function, callExpr)
 		result = Union(result, newCodes)
 	}
 	return result
 }
 
-func findErrorCodesInLambdaAssignment(c *context, ident *ast.Ident, assignedExpr ast.Expr, function *funcDefinition) CodeSet {
+func findErrorCodesInLambdaAssignment(c *context, ident *ast.Ident, assignedExpr ast.Expr, function *funcDefinition, callExpr *ast.CallExpr) CodeSet {
 	pass := c.pass
 	var result CodeSet
 
-	switch rhsEntry := astutil.Unparen(assignedExpr).(type) {
+	switch rhsEntry := unwrapInstantiation(pass, assignedExpr).(type) {
 	case *ast.FuncLit:
-		result = findErrorCodesInFunc(c, &funcDefinition{nil, rhsEntry})
+		result = findErrorCodesInCalledFunc(c, function, &funcDefinition{nil, rhsEntry})
 	case *ast.Ident: // name of a function
 		callee := pass.TypesInfo.Uses[rhsEntry]
-		result = findErrorCodesFromFunctionCall(c, function, rhsEntry, callee, nil)
+		result = findErrorCodesFromFunctionCall(c, function, rhsEntry, callee, callExpr)
 	case *ast.SelectorExpr: // name of a function in other package
 		var callee types.Object
 		if sel, ok := pass.TypesInfo.Selections[rhsEntry]; ok {
@@ -749,9 +1267,9 @@ func findErrorCodesInLambdaAssignment(c *context, ident *ast.Ident, assignedExpr
 		} else {
 			callee = pass.TypesInfo.Uses[rhsEntry.Sel]
 		}
-		result = findErrorCodesFromFunctionCall(c, function, rhsEntry, callee, nil)
+		result = findErrorCodesFromFunctionCall(c, function, rhsEntry, callee, callExpr)
 	default:
-		pass.ReportRangef(rhsEntry, "unsupported: assignment to variable %q can only be an identifier or function literal", ident.Name)
+		report(pass, rhsEntry, MsgLambdaAssignment, ident.Name)
 	}
 
 	return result
@@ -763,22 +1281,50 @@ func findErrorCodesFromIdentTaint(c *context, visitedIdents map[*ast.Object]stru
 
 	taintResult := taintSpreadForIdentAllowLeak(pass, visitedIdents, ident, function)
 
+	result := Set()
+
 	for _, badIdent := range taintResult.identOutOfScope {
+		if isErrorUnionParam(pass, function, badIdent) || isPassthroughParam(pass, function, badIdent) {
+			continue // The error codes of the arguments are added at each call site.
+		}
+
+		if variable, ok := getPackageVariable(c, badIdent); ok {
+			newCodes := findErrorCodesInPackageVariable(c, visitedIdents, badIdent, variable, function)
+			result = Union(result, newCodes)
+			continue
+		}
+
 		if function.funcDecl != nil { // expression is inside a function
-			pass.ReportRangef(badIdent, "returned error may not be a parameter, receiver or global variable")
+			report(pass, badIdent, MsgReturnOutOfScope)
 		} else { // expression is inside a lambda (function literal)
-			pass.ReportRangef(badIdent, "returned error may not be a parameter, global variable or other variables declared outside of the function body")
+			report(pass, badIdent, MsgReturnOutOfScopeInLit)
 		}
 	}
 
-	result := Set()
-
-	for _, expr := range taintResult.expressions {
+	for i, expr := range taintResult.expressions {
+		if !canReachUse(c, expr, taintResult.targets[i]) {
+			continue // the value is always overwritten or never assigned before it's used
+		}
 		newCodes := findErrorCodesInExpression(c, visitedIdents, expr, function)
 		result = Union(result, newCodes)
 	}
 
 	for _, destruct := range taintResult.destructAssignment {
+		if !canReachUse(c, destruct.source, destruct.target) {
+			continue
+		}
+
+		// Destructuring a channel receive, map lookup or type assertion with comma-ok: the value is the first result.
+		switch source := astutil.Unparen(destruct.source).(type) {
+		case *ast.UnaryExpr, *ast.IndexExpr, *ast.TypeAssertExpr:
+			if destruct.position != 0 {
+				continue // the second result is a boolean
+			}
+			newCodes := findErrorCodesInExpression(c, visitedIdents, source, function)
+			result = Union(result, newCodes)
+			continue
+		}
+
 		callExpr, ok := astutil.Unparen(destruct.source).(*ast.CallExpr)
 		if !ok {
 			panic("should be unreachable: destructirung assignment should only originate from a call expression.")
@@ -790,19 +1336,49 @@ func findErrorCodesFromIdentTaint(c *context, visitedIdents map[*ast.Object]stru
 		}
 
 		// Destructuring mode.
-		// We're going to make some crass simplifications here, and say... if this is anything other than the last arg, you're not supported.
-		if destruct.position != funType.Results().Len()-1 {
-			pass.ReportRangef(destruct.target, "unsupported: tracking error codes for function call with error as non-last return argument")
+		// Every result that is an error carries the error codes of the called function.
+		if typ := funType.Results().At(destruct.position).Type(); !types.Implements(typ, tError) && !isErrorContainer(typ) && !isErrorIterator(typ) {
+			report(pass, destruct.target, MsgCallErrorNotLast)
 			continue
 		}
 
-		newCodes := findErrorCodesInCallExpression(c, callExpr, function)
+		newCodes := findErrorCodesInCallExpression(c, visitedIdents, callExpr, function)
 		result = Union(result, newCodes)
 	}
 
 	return result
 }
 
+// isNodeInsideFunction checks if the given node is located inside of the body of the given function.
+func isNodeInsideFunction(function *funcDefinition, node ast.Node) bool {
+	return function.body().Pos() <= node.Pos() && node.End() <= function.body().End()
+}
+
+// findEnclosingFunction returns the innermost function declaration or function literal containing the given node,
+// or nil if the node is not inside of a function of the analysed package.
+func findEnclosingFunction(pass *analysis.Pass, node ast.Node) *funcDefinition {
+	for _, file := range pass.Files {
+		if node.Pos() < file.Pos() || node.End() > file.End() {
+			continue
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, node.Pos(), node.End())
+		for _, node := range path {
+			switch node := node.(type) {
+			case *ast.FuncLit:
+				return &funcDefinition{nil, node}
+			case *ast.FuncDecl:
+				if node.Body == nil {
+					return nil
+				}
+				return &funcDefinition{node, nil}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
 // isIdentOriginOutsideFunctionScope checks if the origin of the given ident is outside of the scope of the given function.
 func isIdentOriginOutsideFunctionScope(function *funcDefinition, ident *ast.Ident) bool {
 	if ident.Name == "nil" {
@@ -839,21 +1415,27 @@ func findCodesAssignedToErrorCodeField(pass *analysis.Pass, function *funcDefini
 	}
 
 	ast.Inspect(function.node(), func(node ast.Node) bool {
-		assignment, ok := node.(*ast.AssignStmt)
-		if !ok {
-			return true
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			newCodes := findCodesAssignedToErrorCodeFieldInAssignment(pass, function, errorType, errorIdent, node)
+			result = Union(result, newCodes)
+			return false
+		case *ast.CallExpr:
+			// Calls of setters of the error code field, e.g. `err.SetCode("some-error")`.
+			if isCodeSetterCallOn(pass, node, errorIdent) {
+				if code, ok := extractErrorCodeFromSetterCall(pass, function, node); ok {
+					result.Add(code)
+				}
+			}
 		}
-
-		newCodes := findCodesAssignedToErrorCodeFieldInAssignment(pass, function, errorType, errorIdent, assignment)
-		result = Union(result, newCodes)
-
-		return false
+		return true
 	})
 
 	return result
 }
 
 // findCodesAssignedToErrorCodeField searches through the given assignment and returns every constant code assigned to the error code field.
+// The field may also be assigned by replacing the whole error through a pointer, e.g. `*err = Error{"some-error"}`.
 // For invalid assignments to the error code field, diagnostics are emitted.
 func findCodesAssignedToErrorCodeFieldInAssignment(pass *analysis.Pass, function *funcDefinition, errorType *ErrorType, errorIdent *ast.Object, assignment *ast.AssignStmt) CodeSet {
 	result := Set()
@@ -863,21 +1445,32 @@ func findCodesAssignedToErrorCodeFieldInAssignment(pass *analysis.Pass, function
 	}
 
 	for i, lhsEntry := range assignment.Lhs {
-		lhsEntry, ok := lhsEntry.(*ast.SelectorExpr)
-		if !ok {
+		var objExpr ast.Expr
+		var fieldSelector *ast.SelectorExpr
+		switch lhsEntry := astutil.Unparen(lhsEntry).(type) {
+		case *ast.SelectorExpr:
+			// The error may also be dereferenced explicitly, e.g. `(*err).TheCode = "some-error"`.
+			objExpr = astutil.Unparen(lhsEntry.X)
+			if star, ok := objExpr.(*ast.StarExpr); ok {
+				objExpr = astutil.Unparen(star.X)
+			}
+			fieldSelector = lhsEntry
+		case *ast.StarExpr:
+			objExpr = astutil.Unparen(lhsEntry.X)
+		default:
 			continue
 		}
 
-		objIdent, ok := lhsEntry.X.(*ast.Ident)
+		objIdent, ok := objExpr.(*ast.Ident)
 		if !ok || objIdent.Obj == nil {
 			continue // Cannot inspect assignments to more complicated expressions. (yet?)
 		}
 
-		if objIdent.Obj != errorIdent {
+		if objIdent.Obj != errorIdent && !isPointerToIdent(objIdent, errorIdent) {
 			continue // Not the ident we're looking for.
 		}
 
-		// Found an assignment to a field of the error we're looking at.
+		// Found an assignment to the error we're looking at.
 		// Try to get the error type for the ident to see if the assignment is to the error code field.
 		if errorType == nil {
 			var err error
@@ -889,16 +1482,25 @@ func findCodesAssignedToErrorCodeFieldInAssignment(pass *analysis.Pass, function
 
 		// Found valid error type, that has a error code field defined:
 		// Check if fields match and if they do try to get the error code from the assignment.
-		if errorType.Field.Name != lhsEntry.Sel.Name {
+		if fieldSelector != nil && errorType.Field.Name != fieldSelector.Sel.Name {
 			continue
 		}
 
 		if len(assignment.Lhs) != len(assignment.Rhs) {
-			pass.ReportRangef(assignment.Rhs[0], "error code has to be constant value or error code parameter")
+			report(pass, assignment.Rhs[0], MsgNonConstantCode)
 			continue
 		}
 
-		code, ok := extractErrorCodeFromStringExpression(pass, function, assignment.Rhs[i])
+		codeExpr := assignment.Rhs[i]
+		if fieldSelector == nil {
+			// The whole error is replaced, so the code is the one of the new value.
+			codeExpr = findFieldInitExpression(pass, codeExpr, errorType.Field)
+			if codeExpr == nil {
+				continue
+			}
+		}
+
+		code, ok := extractErrorCodeFromStringExpression(pass, function, codeExpr)
 		if ok {
 			result.Add(code)
 		}
@@ -907,6 +1509,38 @@ func findCodesAssignedToErrorCodeFieldInAssignment(pass *analysis.Pass, function
 	return result
 }
 
+// isPointerToIdent checks if the given ident is declared as pointer to the given object, e.g. `ptr := &err`.
+func isPointerToIdent(ident *ast.Ident, obj *ast.Object) bool {
+	var lhs []*ast.Ident
+	var rhs []ast.Expr
+	switch decl := ident.Obj.Decl.(type) {
+	case *ast.AssignStmt:
+		for _, lhsEntry := range decl.Lhs {
+			lhsIdent, _ := lhsEntry.(*ast.Ident)
+			lhs = append(lhs, lhsIdent)
+		}
+		rhs = decl.Rhs
+	case *ast.ValueSpec:
+		lhs, rhs = decl.Names, decl.Values
+	}
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lhsIdent := range lhs {
+		if lhsIdent == nil || lhsIdent.Obj != ident.Obj {
+			continue
+		}
+		unary, ok := astutil.Unparen(rhs[i]).(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			return false
+		}
+		target, ok := astutil.Unparen(unary.X).(*ast.Ident)
+		return ok && target.Obj == obj
+	}
+	return false
+}
+
 // checkErrorTypeHasLegibleCode makes sure that the `Code() string` function
 // on a type either returns a constant or a single struct field.
 // If you want to write your own error types and have them be recognized, it should be this simple.
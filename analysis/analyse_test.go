@@ -13,18 +13,53 @@ func TestVerifyAnalyzer(t *testing.T) {
 	for _, pattern := range []string{
 		"001",
 		"annotation",
+		"bodyless",
+		"call_expressions/inner", "call_expressions",
+		"channels",
+		"code_comparisons",
+		"control_flow",
+		"conversions",
+		"dereference_assignment",
+		"details",
 		"docformat",
 		"dotimport/inner1", "dotimport",
-		"error_constructor",
+		"error_alias",
+		"error_collections",
+		"error_constructor/inner", "error_constructor",
+		"error_results",
+		"error_struct",
+		"error_union",
+		"errgroup",
 		"errortypes",
 		"examples",
+		"external_tests",
 		"field_assignment",
+		"formatted_codes",
+		"func_adapters/inner", "func_adapters",
+		"func_contracts/inner", "func_contracts",
 		"func_literal",
+		"globals",
+		"interface_conflicts",
 		"interfaces/inner1", "interfaces",
+		"map_lookup",
+		"method_values",
 		"methods",
 		"multifile",
 		"multipackage/inner1", "multipackage",
+		"negative_claims",
+		"options/inner", "options",
+		"passthrough/inner", "passthrough",
+		"reachability",
+		"recover",
 		"recursion",
+		"struct_fields/inner", "struct_fields",
+		"swaps",
+		"test_helpers",
+		"type_switch/inner", "type_switch",
+		"typecast",
+		"wildcards/storage", "wildcards",
+		"worker_pool/pool", "worker_pool",
+		"wrap",
 	} {
 		t.Run(pattern, func(t *testing.T) {
 			pattern := pattern
@@ -33,6 +68,39 @@ func TestVerifyAnalyzer(t *testing.T) {
 	}
 }
 
+// TestFlags runs the analyzer on the testdata of flags, which change the analysis, with the flag set.
+func TestFlags(t *testing.T) {
+	dir := analysistest.TestData()
+	for _, testcase := range []struct {
+		name     string
+		flag     string
+		value    string
+		patterns []string
+	}{
+		{"union implementations", "union-implementations", "true", []string{"implementations/inner", "implementations"}},
+		{"opaque packages", "opaque-packages", "opaque/legacy", []string{"opaque"}},
+		{"trusted packages", "trusted-packages", "trusted_claims/legacy", []string{"trusted_claims/legacy", "trusted_claims"}},
+		{"result structs", "result-structs", "true", []string{"result_structs/inner", "result_structs"}},
+		{"prune dead branches", "prune-dead-branches", "true", []string{"dead_branches"}},
+		{"shadowed errors", "shadowed-errors", "true", []string{"shadowed_errors"}},
+		{"max component size", "max-component-size", "2", []string{"component_size"}},
+		{"skip generated", "skip-generated", "true", []string{"skip_generated"}},
+		{"ssa engine", "engine", "ssa", []string{"ssa_engine"}},
+		{"cause code", "cause-code", "cause-unknown", []string{"cause/inner", "cause"}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			saved := cliArguments
+			defer func() { cliArguments = saved }()
+
+			Analyzer.Flags.Set("strict", "true")
+			if err := Analyzer.Flags.Set(testcase.flag, testcase.value); err != nil {
+				t.Fatal(err)
+			}
+			analysistest.Run(t, dir, Analyzer, testcase.patterns...)
+		})
+	}
+}
+
 type collector struct {
 	data map[string]struct{}
 }
@@ -55,38 +123,6 @@ func (c *collector) assert(t *testing.T, data ...string) {
 	}
 }
 
-//
-func TestNotImplemented(t *testing.T) {
-	Analyzer.Flags.Set("strict", "true")
-	dir := analysistest.TestData()
-	for _, testcase := range []struct {
-		pattern  string
-		expected []string
-	}{
-		{
-			pattern: "typecast",
-			expected: []string{
-				`typecast/cast.go:7:9: unexpected diagnostic: function "StringError" in dot-imported package does not declare error codes`,
-				`typecast/cast.go:6:1: unexpected diagnostic: function "TypeCast" has a mismatch of declared and actual error codes: unused codes: [string-error]`,
-			},
-		},
-		{
-			pattern: "dereference_assignment",
-			expected: []string{
-				`dereference_assignment/assign.go:7:1: unexpected diagnostic: function "DereferenceAssignment" has a mismatch of declared and actual error codes: unused codes: [other-error]`,
-				`dereference_assignment/assign.go:18:1: unexpected diagnostic: function "DereferenceAssignment2" has a mismatch of declared and actual error codes: unused codes: [other-error]`,
-			},
-		},
-	} {
-		t.Run(testcase.pattern, func(t *testing.T) {
-			testcase := testcase
-			c := &collector{data: map[string]struct{}{}}
-			analysistest.Run(c, dir, Analyzer, testcase.pattern)
-			c.assert(t, testcase.expected...)
-		})
-	}
-}
-
 func TestIsErrorCodeValid(t *testing.T) {
 	tests := []struct {
 		code  string
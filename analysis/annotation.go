@@ -1,7 +1,6 @@
 package analysis
 
 import (
-	"fmt"
 	"go/ast"
 	"strings"
 )
@@ -58,12 +57,12 @@ func getReturnStmtAnnotations(c *context, stmt *ast.ReturnStmt) *annotationRetur
 		var err error
 		line = strings.TrimSpace(line)
 		if len(line) == 0 {
-			pass.ReportRangef(stmt, "error in annotation: expected '=', '+=', '-=', '+code', or '-code' after '%s' indicator", annotationIndicatorReturnStmt)
+			report(pass, stmt, MsgAnnotationSyntax, annotationIndicatorReturnStmt)
 			return nil
 		}
 
 		if result != nil {
-			pass.ReportRangef(stmt, "found multiple annotations for the same return statement: only one is allowed")
+			report(pass, stmt, MsgAnnotationMultiple)
 		}
 		result = &annotationReturnStmt{false, Set(), Set(), Set()}
 
@@ -84,11 +83,11 @@ func getReturnStmtAnnotations(c *context, stmt *ast.ReturnStmt) *annotationRetur
 				err = extractCodeModificationsFromStringAnnotation(result, line)
 			}
 		default:
-			err = fmt.Errorf("error in annotation: expected '=', '+=', '-=', '+code', or '-code' after '%s' indicator", annotationIndicatorReturnStmt)
+			err = newMessageError(MsgAnnotationSyntax, annotationIndicatorReturnStmt)
 		}
 
 		if err != nil {
-			pass.ReportRangef(stmt, "%v", err)
+			reportError(pass, stmt, err)
 			return nil
 		}
 	}
@@ -107,7 +106,7 @@ func extractCodesFromStringAnnotation(annotation string) (CodeSet, error) {
 	for _, code := range strings.Split(annotation, ",") {
 		code = strings.TrimSpace(code)
 		if err := checkErrorCodeValid(code); err != nil {
-			return nil, fmt.Errorf("invalid error code in annotation: %v", err)
+			return nil, newMessageError(MsgAnnotationInvalidCode, err)
 		}
 
 		result.Add(code)
@@ -127,11 +126,11 @@ func extractCodeModificationsFromStringAnnotation(result *annotationReturnStmt,
 		}
 
 		if code[0] != '+' && code[0] != '-' {
-			return fmt.Errorf("invalid error code in annotation: code has to start with '+' or '-'")
+			return newMessageError(MsgAnnotationMissingSign)
 		}
 
 		if err := checkErrorCodeValid(code[1:]); err != nil {
-			return fmt.Errorf("invalid error code in annotation: %v", err)
+			return newMessageError(MsgAnnotationInvalidCode, err)
 		}
 
 		switch code[0] {
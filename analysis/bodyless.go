@@ -0,0 +1,32 @@
+package analysis
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// trustBodylessFunctions handles the given error returning functions without Go body,
+// e.g. functions implemented in assembly or declared with a go:linkname directive.
+//
+// Their error codes can't be searched, so their declared error codes are trusted as-is.
+// Functions without body, which do not declare error codes, are reported and return no error codes to their callers.
+func trustBodylessFunctions(pass *analysis.Pass, lookup *funcLookup, funcsToAnalyse []*ast.FuncDecl, funcClaims funcCodesMap) {
+	for _, funcDecl := range funcsToAnalyse {
+		if funcDecl.Body != nil {
+			continue
+		}
+		if _, ok := lookup.trusted[funcDecl]; ok {
+			continue
+		}
+
+		codes := Set()
+		if claims, ok := funcClaims[funcDecl]; ok {
+			codes = claims.codes
+		} else if !hasErrorDocs(pass, funcDecl) {
+			reportPos(pass, funcDecl.Pos(), MsgBodylessUndeclared, funcDecl.Name.Name)
+		}
+		lookup.foundCodes[funcDecl] = codes
+		lookup.trusted[funcDecl] = struct{}{}
+	}
+}
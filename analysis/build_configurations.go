@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// BuildConfiguration is a target platform, for which packages are analysed by AnalyzeBuildConfigurations.
+type BuildConfiguration struct {
+	GOOS   string
+	GOARCH string
+}
+
+// ParseBuildConfiguration parses a build configuration in the form "GOOS/GOARCH", e.g. "linux/amd64".
+func ParseBuildConfiguration(value string) (BuildConfiguration, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return BuildConfiguration{}, fmt.Errorf("invalid build configuration %q, expected GOOS/GOARCH", value)
+	}
+	return BuildConfiguration{parts[0], parts[1]}, nil
+}
+
+func (c BuildConfiguration) String() string {
+	return c.GOOS + "/" + c.GOARCH
+}
+
+// BuildConfigurationResult is the result of analysing packages for one build configuration.
+type BuildConfigurationResult struct {
+	Configuration BuildConfiguration
+	Diagnostics   []OverlayDiagnostic // diagnostics of the analysed packages, sorted by position
+}
+
+// ConfigurationCodes holds the error codes of a function, or another object with an ErrorCodes fact, in each analysed build configuration.
+//
+// Functions declared in platform-specific files, e.g. "open_linux.go" and "open_windows.go",
+// may return different error codes depending on the build configuration.
+type ConfigurationCodes struct {
+	Package string                         // path of the package declaring the object
+	Object  string                         // name of the object, qualified with the receiver type for methods, e.g. "(*File).Close"
+	Codes   map[BuildConfiguration]CodeSet // error codes per configuration; configurations without ErrorCodes fact are missing
+}
+
+// Union returns the error codes of the object in any of the analysed build configurations.
+func (c *ConfigurationCodes) Union() CodeSet {
+	result := Set()
+	for _, codes := range c.Codes {
+		result = Union(result, codes)
+	}
+	return result
+}
+
+// Mismatches checks if the error codes of the object differ between the given build configurations,
+// also if the object has no error codes in some of them.
+func (c *ConfigurationCodes) Mismatches(configurations []BuildConfiguration) bool {
+	var first CodeSet
+	for i, configuration := range configurations {
+		codes, ok := c.Codes[configuration]
+		if !ok {
+			return true
+		}
+		if i == 0 {
+			first = codes
+		} else if len(Difference(first, codes)) > 0 || len(Difference(codes, first)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalyzeBuildConfigurations analyses the packages matching the given patterns once for each of the given build configurations,
+// by loading them with GOOS and GOARCH set accordingly. Files excluded by build constraints for a configuration are not analysed for it.
+//
+// It returns the results of every configuration, in the given order, and the error codes of all objects of the analysed packages,
+// which have an ErrorCodes fact in at least one configuration, sorted by package and name.
+// The given config is used to load the packages, like for AnalyzeOverlay.
+func AnalyzeBuildConfigurations(config *packages.Config, configurations []BuildConfiguration, patterns ...string) ([]BuildConfigurationResult, []*ConfigurationCodes, error) {
+	var results []BuildConfigurationResult
+	codes := map[string]*ConfigurationCodes{}
+
+	for _, configuration := range configurations {
+		cfg := *config
+		env := cfg.Env
+		if env == nil {
+			env = os.Environ()
+		}
+		cfg.Env = append(append([]string{}, env...), "GOOS="+configuration.GOOS, "GOARCH="+configuration.GOARCH)
+
+		diagnostics, roots, driver, err := analyzePackages(&cfg, patterns...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", configuration, err)
+		}
+		results = append(results, BuildConfigurationResult{configuration, diagnostics})
+
+		isRoot := make(map[*types.Package]bool, len(roots))
+		for _, pkg := range roots {
+			isRoot[pkg.Types] = true
+		}
+		for key, fact := range driver.facts {
+			errorCodes, ok := fact.(*ErrorCodes)
+			if key.analyzer != Analyzer || key.obj == nil || !ok || !isRoot[key.pkg] {
+				continue
+			}
+
+			name := objectName(key.obj)
+			id := key.pkg.Path() + " " + name
+			entry, ok := codes[id]
+			if !ok {
+				entry = &ConfigurationCodes{key.pkg.Path(), name, map[BuildConfiguration]CodeSet{}}
+				codes[id] = entry
+			}
+			entry.Codes[configuration] = errorCodes.Codes
+		}
+	}
+
+	sortedCodes := make([]*ConfigurationCodes, 0, len(codes))
+	for _, entry := range codes {
+		sortedCodes = append(sortedCodes, entry)
+	}
+	sort.Slice(sortedCodes, func(i, j int) bool {
+		a, b := sortedCodes[i], sortedCodes[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Object < b.Object
+	})
+	return results, sortedCodes, nil
+}
+
+// objectName returns the name of the given object, qualified with the receiver type for methods, e.g. "(*File).Close".
+func objectName(obj types.Object) string {
+	if function, ok := obj.(*types.Func); ok {
+		if recv := function.Type().(*types.Signature).Recv(); recv != nil {
+			return fmt.Sprintf("(%s).%s", types.TypeString(recv.Type(), types.RelativeTo(obj.Pkg())), obj.Name())
+		}
+	}
+	return obj.Name()
+}
@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAnalyzeBuildConfigurations(t *testing.T) {
+	dir := analysistest.TestData()
+	config := &packages.Config{
+		Dir: filepath.Join(dir, "src", "buildconfigs"),
+		Env: append(os.Environ(), "GOPATH="+dir, "GO111MODULE=off", "GOPROXY=off", "CGO_ENABLED=0"),
+	}
+	linux := BuildConfiguration{"linux", "amd64"}
+	windows := BuildConfiguration{"windows", "amd64"}
+	configurations := []BuildConfiguration{linux, windows}
+
+	results, codes, err := AnalyzeBuildConfigurations(config, configurations, "buildconfigs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0].Configuration != linux || results[1].Configuration != windows {
+		t.Fatalf("expected results for linux and windows, got %v", results)
+	}
+	if len(results[0].Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics for linux, got %v", results[0].Diagnostics)
+	}
+	if len(results[1].Diagnostics) != 1 || !strings.Contains(results[1].Diagnostics[0].Message, "buildconfigs-error-undeclared") ||
+		filepath.Base(results[1].Diagnostics[0].Position.Filename) != "open_windows.go" {
+		t.Errorf("expected one diagnostic about the undeclared code in open_windows.go, got %v", results[1].Diagnostics)
+	}
+
+	tests := []struct {
+		object   string
+		union    []string
+		mismatch bool
+	}{
+		{"Close", []string{"buildconfigs-error-closed"}, false},
+		{"Lock", []string{"buildconfigs-error-locked"}, true},
+		{"Open", []string{"buildconfigs-error-not-found", "buildconfigs-error-sharing-violation"}, true},
+	}
+	if len(codes) != len(tests) {
+		t.Fatalf("expected codes of %d functions, got %d", len(tests), len(codes))
+	}
+	for i, test := range tests {
+		entry := codes[i]
+		if entry.Package != "buildconfigs" || entry.Object != test.object {
+			t.Errorf("expected codes of buildconfigs.%s, got %s.%s", test.object, entry.Package, entry.Object)
+			continue
+		}
+		union := entry.Union().Slice()
+		sort.Strings(union)
+		if !reflect.DeepEqual(union, test.union) {
+			t.Errorf("%s: expected union %v, got %v", test.object, test.union, union)
+		}
+		if mismatch := entry.Mismatches(configurations); mismatch != test.mismatch {
+			t.Errorf("%s: expected mismatch %v, got %v", test.object, test.mismatch, mismatch)
+		}
+	}
+}
+
+func TestParseBuildConfiguration(t *testing.T) {
+	configuration, err := ParseBuildConfiguration("linux/arm64")
+	if err != nil || configuration != (BuildConfiguration{"linux", "arm64"}) || configuration.String() != "linux/arm64" {
+		t.Errorf("unexpected result for linux/arm64: %v, %v", configuration, err)
+	}
+	for _, value := range []string{"", "linux", "linux/", "/amd64", "linux/amd64/v2"} {
+		if _, err := ParseBuildConfiguration(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
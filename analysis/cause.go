@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"go/types"
+)
+
+// causeMethod is the name of the method returning the cause of an error, e.g. in "return err.Cause()".
+const causeMethod = "Cause"
+
+// isCauseMethod checks if the given function is the method "Cause() error" of a type implementing the ree error interface.
+func isCauseMethod(callee types.Object) bool {
+	fn, ok := callee.(*types.Func)
+	if !ok || fn.Name() != causeMethod {
+		return false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && types.Implements(recv.Type(), tReeErrorWithCause)
+}
+
+// findErrorCodesFromCauseCall finds error codes for a call of the given method, if it is the cause method of an error,
+// which does not declare the codes of its causes.
+//
+// The cause is then treated as returning the code set with the -cause-code flag. If the flag is not set, false is returned.
+func findErrorCodesFromCauseCall(callee types.Object) (CodeSet, bool) {
+	if cliArguments.causeCode == "" || !isCauseMethod(callee) {
+		return nil, false
+	}
+	return Set(cliArguments.causeCode), true
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// isChannelReceive checks if the given expression receives a value from a channel.
+//
+// This is either a receive operation (`<-errs`) or
+// the range clause of a for-range statement over a channel (`for err := range errs`),
+// as it is attached to the declaration of the iteration variable.
+func isChannelReceive(c *context, expr *ast.UnaryExpr) bool {
+	if expr.Op != token.ARROW && expr.Op != token.RANGE {
+		return false
+	}
+
+	_, ok := getUnderlyingType(c.pass.TypesInfo.TypeOf(expr.X)).(*types.Chan)
+	return ok
+}
+
+// findErrorCodesInChannelReceive finds error codes that originate from receiving an error from a channel.
+//
+// The analysis is conservative: the result is the union of the error codes of all values sent
+// on the channel within the given function, including sends from within nested function literals (e.g. goroutines).
+// Therefore the channel has to be a local variable of the function, which is not passed to other functions.
+func findErrorCodesInChannelReceive(c *context, visitedIdents map[*ast.Object]struct{}, expr *ast.UnaryExpr, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	chanIdent, ok := astutil.Unparen(expr.X).(*ast.Ident)
+	if !ok {
+		report(pass, expr.X, MsgChannelNotVariable)
+		return nil
+	}
+
+	if isIdentOriginOutsideFunctionScope(function, chanIdent) {
+		report(pass, chanIdent, MsgChannelOutOfScope)
+		return nil
+	}
+
+	isChannel := func(expr ast.Expr) bool {
+		ident, ok := astutil.Unparen(expr).(*ast.Ident)
+		return ok && ident.Obj == chanIdent.Obj
+	}
+
+	result := Set()
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SendStmt:
+			if isChannel(node.Chan) {
+				newCodes := findErrorCodesInExpression(c, visitedIdents, node.Value, function)
+				result = Union(result, newCodes)
+			}
+		case *ast.CallExpr:
+			if _, ok := getCalledBuiltin(c, node); ok {
+				return true // Builtins like close(errs) or len(errs) can not send on the channel.
+			}
+
+			for _, arg := range node.Args {
+				if isChannel(arg) {
+					report(pass, arg, MsgChannelLeaked)
+				}
+			}
+		}
+		return true
+	})
+
+	return result
+}
+
+// getCalledBuiltin returns the builtin function called by the given call expression, if any.
+func getCalledBuiltin(c *context, callExpr *ast.CallExpr) (*types.Builtin, bool) {
+	ident, ok := astutil.Unparen(callExpr.Fun).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	builtin, ok := c.pass.TypesInfo.Uses[ident].(*types.Builtin)
+	return builtin, ok
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis/scc"
+)
+
+const rerrPackagePath = "github.com/serum-errors/go-serum-analyzer/rerr"
+
+// checkCodeComparisons reports comparisons of errors with constant error codes, which the compared error can never carry,
+// e.g. because of a typo in `err.Code() == "not-fuond"`.
+//
+// Comparisons are `e.Code() == "code"` or `!=`, cases of switch statements on `e.Code()` or `rerr.CodeOf(err)`,
+// and calls of rerr.HasCode(err, "code"), which is the recommended way to branch on error codes.
+// The codes an error can carry are found like the codes of returned errors.
+// If they can not be determined exactly, e.g. because the error is a parameter, the comparison is not checked.
+func checkCodeComparisons(c *context) {
+	for _, file := range c.pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.BinaryExpr:
+				if node.Op != token.EQL && node.Op != token.NEQ {
+					break
+				}
+				if err, ok := getCodeCallReceiver(c.pass, node.X); ok {
+					checkCodeComparison(c, err, node.Y)
+				} else if err, ok := getCodeCallReceiver(c.pass, node.Y); ok {
+					checkCodeComparison(c, err, node.X)
+				}
+			case *ast.SwitchStmt:
+				err, ok := getCodeCallReceiver(c.pass, node.Tag)
+				if !ok {
+					break
+				}
+				for _, stmt := range node.Body.List {
+					for _, expr := range stmt.(*ast.CaseClause).List {
+						checkCodeComparison(c, err, expr)
+					}
+				}
+			case *ast.CallExpr:
+				if isRerrCall(c.pass, node, "HasCode") && len(node.Args) == 2 {
+					checkCodeComparison(c, node.Args[0], node.Args[1])
+				}
+			}
+			return true
+		})
+	}
+}
+
+// getCodeCallReceiver returns the error of the given expression, if it is a call of the Code() method of an error, e.g. `err.Code()`,
+// or a call of rerr.CodeOf, e.g. `rerr.CodeOf(err)`.
+func getCodeCallReceiver(pass *analysis.Pass, expr ast.Expr) (ast.Expr, bool) {
+	callExpr, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	if isRerrCall(pass, callExpr, "CodeOf") && len(callExpr.Args) == 1 {
+		return callExpr.Args[0], true
+	}
+	if len(callExpr.Args) != 0 {
+		return nil, false
+	}
+
+	selector, ok := astutil.Unparen(callExpr.Fun).(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Code" {
+		return nil, false
+	}
+
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.MethodVal || !types.Implements(selection.Recv(), tError) {
+		return nil, false
+	}
+	return selector.X, true
+}
+
+// isRerrCall checks if the given call is a call of the function with the given name of the rerr package, e.g. rerr.HasCode.
+func isRerrCall(pass *analysis.Pass, callExpr *ast.CallExpr, name string) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Name() != name {
+		return false
+	}
+
+	// Also accept vendored copies of the package.
+	path := fn.Pkg().Path()
+	return path == rerrPackagePath || strings.HasSuffix(path, "/vendor/"+rerrPackagePath)
+}
+
+// checkCodeComparison reports the given code expression, if it is a constant error code which the given error can never carry.
+func checkCodeComparison(c *context, err ast.Expr, codeExpr ast.Expr) {
+	value := getConstantValue(c.pass, codeExpr)
+	if value == nil || value.Kind() != constant.String {
+		return
+	}
+	code := constant.StringVal(value)
+	if code == "" {
+		return // the code of errors without code, which is always possible
+	}
+
+	codes, ok := findExactErrorCodes(c, err)
+	if !ok || isCodeCovered(code, codes) {
+		return
+	}
+
+	possible := codes.Slice()
+	sort.Strings(possible)
+	reportPosForCodes(c.pass, codeExpr.Pos(), []string{code}, MsgImpossibleCodeComparison, code, possible)
+}
+
+// findExactErrorCodes finds the error codes of the given error expression.
+// The second result is false, if the analysis of the expression found any problem,
+// in which case the codes might be incomplete. Problems are not reported, they are reported where the error is returned.
+func findExactErrorCodes(c *context, expr ast.Expr) (CodeSet, bool) {
+	function := findEnclosingFunction(c.pass, expr)
+	if function == nil {
+		return nil, false
+	}
+
+	problems := 0
+	pass := *c.pass
+	pass.Report = func(analysis.Diagnostic) { problems++ }
+
+	scc := scc.StartSCC()
+	exact := newContext(&pass, c.lookup, scc, c.comments)
+
+	scc.Visit(function.node())
+	codes := findErrorCodesInExpression(exact, map[*ast.Object]struct{}{}, expr, function)
+	scc.EndVisit(function.node())
+
+	return codes, problems == 0 && len(exact.undeclaredCallees.callees) == 0
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// findErrorCodesFromCodeOfCall finds the error codes of the given expression,
+// if it is a call of rerr.CodeOf or rerr.CodeOfOr, e.g. `NewError(rerr.CodeOfOr(err, "some-error"))`.
+//
+// The codes are those of the error passed to the call, which is analysed like a returned error,
+// plus the fallback code of rerr.CodeOfOr, which has to be constant or an error code parameter.
+// The empty code returned for errors without code is never reported.
+// The given prefix is added in front of each code, as done by error constructors declaring a prefix.
+func findErrorCodesFromCodeOfCall(c *context, expr ast.Expr, function *funcDefinition, prefix string) (CodeSet, bool) {
+	callExpr, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+
+	var fallback ast.Expr
+	switch {
+	case isRerrCall(c.pass, callExpr, "CodeOf") && len(callExpr.Args) == 1:
+	case isRerrCall(c.pass, callExpr, "CodeOfOr") && len(callExpr.Args) == 2:
+		fallback = callExpr.Args[1]
+	default:
+		return nil, false
+	}
+
+	result := Set()
+	for code := range findErrorCodesInExpression(c, map[*ast.Object]struct{}{}, callExpr.Args[0], function) {
+		result.Add(prefix + code)
+	}
+	if fallback != nil {
+		if code, ok := extractPrefixedErrorCode(c.pass, function, fallback, prefix); ok {
+			result.Add(code)
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ErrorCodeOption is a fact that is used to tag functional options of error types, which set the error code field to one of their parameters.
+//
+// For example the option "func WithCode(code string) Option { return func(e *Error) { e.TheCode = code } }"
+// gets an ErrorCodeOption{CodeParamPosition: 0} fact.
+//
+// Options are passed to constructors using the options pattern, e.g. `New(WithCode("some-error"))`,
+// and add their code to the error codes of the call.
+type ErrorCodeOption struct {
+	CodeParamPosition int // index of the parameter assigned to the error code field
+}
+
+func (*ErrorCodeOption) AFact() {}
+
+func (e *ErrorCodeOption) String() string {
+	return fmt.Sprintf("ErrorCodeOption: {CodeParamPosition:%d}", e.CodeParamPosition)
+}
+
+// exportCodeOptionFacts exports ErrorCodeOption facts for the functional options of error types in the package.
+func exportCodeOptionFacts(pass *analysis.Pass, lookup *funcLookup) {
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		if position, ok := findCodeOptionParam(pass, funcDecl); ok {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[funcDecl.Name], &ErrorCodeOption{position})
+		}
+	})
+}
+
+// findCodeOptionParam checks if the given function is a functional option setting the error code field of an error type,
+// and returns the position of the parameter assigned to the error code field.
+//
+// Options only consist of a return statement with a function literal, which takes a pointer to the error
+// and only consists of assignments to its fields, where the error code field is assigned a string parameter of the option.
+func findCodeOptionParam(pass *analysis.Pass, funcDecl *ast.FuncDecl) (int, bool) {
+	if funcDecl.Recv != nil || funcDecl.Body == nil || len(funcDecl.Body.List) != 1 {
+		return -1, false
+	}
+	returnStmt, ok := funcDecl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(returnStmt.Results) != 1 {
+		return -1, false
+	}
+	funcLit, ok := astutil.Unparen(returnStmt.Results[0]).(*ast.FuncLit)
+	if !ok || funcLit.Type.Results.NumFields() != 0 || len(funcLit.Type.Params.List) != 1 || len(funcLit.Type.Params.List[0].Names) != 1 {
+		return -1, false
+	}
+
+	target := funcLit.Type.Params.List[0].Names[0]
+	pointer, ok := pass.TypesInfo.TypeOf(target).(*types.Pointer)
+	if !ok || getNamedType(pointer.Elem()) == nil {
+		return -1, false // Setting a field of a copy of the error has no effect.
+	}
+	errorType, err := getErrorTypeForError(pass, pointer.Elem())
+	if err != nil || errorType == nil || errorType.Field == nil {
+		return -1, false
+	}
+
+	paramPosition := -1
+	for _, stmt := range funcLit.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return -1, false
+		}
+		field, ok := getReceiverField(assign.Lhs[0], target)
+		if !ok {
+			return -1, false
+		}
+		if field != errorType.Field.Name {
+			continue
+		}
+
+		param, ok := astutil.Unparen(assign.Rhs[0]).(*ast.Ident)
+		if !ok || paramPosition != -1 {
+			return -1, false
+		}
+		paramPosition = getParamPosition(funcDecl.Type, param)
+		if paramPosition == -1 {
+			return -1, false
+		}
+	}
+
+	return paramPosition, paramPosition != -1
+}
+
+// extractErrorCodesFromOptionArgs returns the error codes set by the functional options passed to the given call,
+// e.g. "some-error" for `New(WithCode("some-error"), WithMessage("message"))`.
+func extractErrorCodesFromOptionArgs(pass *analysis.Pass, function *funcDefinition, callExpr *ast.CallExpr) CodeSet {
+	result := Set()
+	for _, arg := range callExpr.Args {
+		optionCall, ok := astutil.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		var fact ErrorCodeOption
+		callee := typeutil.Callee(pass.TypesInfo, optionCall)
+		if callee == nil || !pass.ImportObjectFact(callee, &fact) || fact.CodeParamPosition >= len(optionCall.Args) {
+			continue
+		}
+		if code, ok := extractErrorCodeFromStringExpression(pass, function, optionCall.Args[fact.CodeParamPosition]); ok {
+			result.Add(code)
+		}
+	}
+	return result
+}
+
+// hasCodeOptionArgs checks if a functional option setting the error code is passed to the given call.
+func hasCodeOptionArgs(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	for _, arg := range callExpr.Args {
+		optionCall, ok := astutil.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if callee := typeutil.Callee(pass.TypesInfo, optionCall); callee != nil && pass.ImportObjectFact(callee, new(ErrorCodeOption)) {
+			return true
+		}
+	}
+	return false
+}
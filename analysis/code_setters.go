@@ -0,0 +1,169 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ErrorCodeSetter is a fact that is used to tag methods of error types, which set the error code field to one of their parameters.
+//
+// For example the setter "func (e *Error) SetCode(code string) { e.TheCode = code }"
+// or the builder-style method "func (e *Error) WithCode(code string) *Error { e.TheCode = code; return e }"
+// gets an ErrorCodeSetter{CodeParamPosition: 0} fact.
+//
+// Calls of setters set the error code of the receiver, e.g. `New().WithCode("some-error")` has the code "some-error".
+type ErrorCodeSetter struct {
+	CodeParamPosition int // index of the parameter assigned to the error code field, not counting the receiver
+}
+
+func (*ErrorCodeSetter) AFact() {}
+
+func (e *ErrorCodeSetter) String() string {
+	return fmt.Sprintf("ErrorCodeSetter: {CodeParamPosition:%d}", e.CodeParamPosition)
+}
+
+// findCodeSetterParam checks if the given method of an error type is a setter of the error code field,
+// and returns the position of the parameter assigned to the error code field.
+//
+// Setters have a pointer receiver and only consist of assignments to fields of the receiver,
+// where the error code field is assigned a string parameter. They may return the receiver, to allow chaining calls.
+func findCodeSetterParam(pass *analysis.Pass, method *ast.FuncDecl, receiver *ast.Ident, errorType *ErrorType) (int, bool) {
+	if errorType.Field == nil || method.Body == nil || len(method.Body.List) == 0 {
+		return -1, false
+	}
+	if _, ok := pass.TypesInfo.TypeOf(method.Recv.List[0].Type).(*types.Pointer); !ok {
+		return -1, false // Setting a field of a copy of the error has no effect.
+	}
+	if results := method.Type.Results; results != nil && results.NumFields() > 1 {
+		return -1, false
+	}
+
+	paramPosition := -1
+	for i, stmt := range method.Body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+				return -1, false
+			}
+			field, ok := getReceiverField(stmt.Lhs[0], receiver)
+			if !ok {
+				return -1, false
+			}
+			if field != errorType.Field.Name {
+				continue
+			}
+
+			param, ok := astutil.Unparen(stmt.Rhs[0]).(*ast.Ident)
+			if !ok || paramPosition != -1 {
+				return -1, false
+			}
+			paramPosition = getParamPosition(method.Type, param)
+			if paramPosition == -1 {
+				return -1, false
+			}
+		case *ast.ReturnStmt:
+			// Only a final `return e` is allowed, returning the receiver for chained calls.
+			if i != len(method.Body.List)-1 || len(stmt.Results) != 1 {
+				return -1, false
+			}
+			ident, ok := astutil.Unparen(stmt.Results[0]).(*ast.Ident)
+			if !ok || ident.Obj == nil || ident.Obj != receiver.Obj {
+				return -1, false
+			}
+		default:
+			return -1, false
+		}
+	}
+
+	return paramPosition, paramPosition != -1
+}
+
+// getReceiverField returns the name of the field of the given receiver, which is selected by the given expression,
+// e.g. "TheCode" for `e.TheCode` or `(*e).TheCode`.
+func getReceiverField(expr ast.Expr, receiver *ast.Ident) (string, bool) {
+	selector, ok := astutil.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	objExpr := astutil.Unparen(selector.X)
+	if star, ok := objExpr.(*ast.StarExpr); ok {
+		objExpr = astutil.Unparen(star.X)
+	}
+
+	ident, ok := objExpr.(*ast.Ident)
+	if !ok || ident.Obj == nil || ident.Obj != receiver.Obj {
+		return "", false
+	}
+	return selector.Sel.Name, true
+}
+
+// isCodeSetterMethod checks if the given function declaration is a setter of the error code field of an error type.
+func isCodeSetterMethod(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
+	obj := pass.TypesInfo.Defs[funcDecl.Name]
+	return obj != nil && pass.ImportObjectFact(obj, new(ErrorCodeSetter))
+}
+
+// extractErrorCodeFromSetterCall returns the error code set by the given call of a setter of the error code field.
+//
+// If the call is not a call of a setter, false is returned.
+func extractErrorCodeFromSetterCall(pass *analysis.Pass, function *funcDefinition, callExpr *ast.CallExpr) (string, bool) {
+	var fact ErrorCodeSetter
+	callee := typeutil.Callee(pass.TypesInfo, callExpr)
+	if callee == nil || !pass.ImportObjectFact(callee, &fact) {
+		return "", false
+	}
+
+	position := fact.CodeParamPosition
+	if isMethodExpression(pass, callExpr.Fun) {
+		position++ // The receiver is passed as first argument, e.g. `(*Error).SetCode(err, "code")`.
+	}
+	if position >= len(callExpr.Args) {
+		return "", false
+	}
+
+	return extractErrorCodeFromStringExpression(pass, function, callExpr.Args[position])
+}
+
+// isCodeSetterCall checks if the given call expression is a call of a setter of the error code field.
+func isCodeSetterCall(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	callee := typeutil.Callee(pass.TypesInfo, callExpr)
+	return callee != nil && pass.ImportObjectFact(callee, new(ErrorCodeSetter))
+}
+
+// isCodeSetterCallOn checks if the given call expression is a call of a setter of the error code field on the given error,
+// e.g. `err.SetCode("some-error")` or `(*Error).SetCode(err, "some-error")`.
+func isCodeSetterCallOn(pass *analysis.Pass, callExpr *ast.CallExpr, errorIdent *ast.Object) bool {
+	if !isCodeSetterCall(pass, callExpr) {
+		return false
+	}
+
+	selector, ok := astutil.Unparen(callExpr.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	receiver := selector.X
+	if isMethodExpression(pass, selector) {
+		if len(callExpr.Args) == 0 {
+			return false
+		}
+		receiver = callExpr.Args[0]
+	}
+
+	receiver = astutil.Unparen(receiver)
+	if unary, ok := receiver.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		receiver = astutil.Unparen(unary.X)
+	}
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = astutil.Unparen(star.X)
+	}
+
+	ident, ok := receiver.(*ast.Ident)
+	return ok && ident.Obj != nil && ident.Obj == errorIdent
+}
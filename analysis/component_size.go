@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis/scc"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// skipOversizedComponents finds the components of mutually recursive functions of the package,
+// which have more members than allowed by the -max-component-size flag, e.g. the functions of generated parsers.
+//
+// The error codes of these functions are not searched, as unifying the results of huge components takes very long.
+// Instead, their declared error codes are trusted and functions without declared error codes return no error codes.
+// Each skipped component is reported once, at its first function.
+func skipOversizedComponents(pass *analysis.Pass, lookup *funcLookup, funcClaims funcCodesMap) {
+	maxSize := cliArguments.maxComponentSize
+	if maxSize <= 0 {
+		return
+	}
+
+	for _, component := range findCallComponents(pass, lookup) {
+		if len(component) <= maxSize {
+			continue
+		}
+
+		for _, funcDecl := range component {
+			codes := Set()
+			if claims, ok := funcClaims[funcDecl]; ok {
+				codes = claims.codes
+			}
+			lookup.foundCodes[funcDecl] = codes
+			lookup.trusted[funcDecl] = struct{}{}
+		}
+		report(pass, component[0].Name, MsgComponentTooLarge, component[0].Name.Name, len(component), maxSize)
+	}
+}
+
+// findCallComponents finds the strongly connected components of the static call graph of the functions in the given lookup.
+// The functions of each component are sorted by their position.
+//
+// Only calls of functions and methods declared in the package are part of the call graph,
+// including calls in function literals of the calling function.
+func findCallComponents(pass *analysis.Pass, lookup *funcLookup) [][]*ast.FuncDecl {
+	declarations := map[*types.Func]*ast.FuncDecl{}
+	var funcDecls []*ast.FuncDecl
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		if fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok && funcDecl.Body != nil {
+			declarations[fn] = funcDecl
+			funcDecls = append(funcDecls, funcDecl)
+		}
+	})
+	sort.Slice(funcDecls, func(i, j int) bool { return funcDecls[i].Pos() < funcDecls[j].Pos() })
+
+	calls := map[*ast.FuncDecl][]*ast.FuncDecl{}
+	for _, funcDecl := range funcDecls {
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			callExpr, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func); ok {
+				if callee, ok := declarations[getOriginMethod(fn)]; ok {
+					calls[funcDecl] = append(calls[funcDecl], callee)
+				}
+			}
+			return true
+		})
+	}
+
+	state := scc.StartSCC()
+	visited := map[*ast.FuncDecl]struct{}{}
+	var result [][]*ast.FuncDecl
+
+	var visit func(funcDecl *ast.FuncDecl)
+	visit = func(funcDecl *ast.FuncDecl) {
+		visited[funcDecl] = struct{}{}
+		state.Visit(funcDecl)
+		for _, callee := range calls[funcDecl] {
+			if state.HandleEdge(funcDecl, callee) {
+				visit(callee)
+				state.AfterRecurse(funcDecl, callee)
+			}
+		}
+
+		if isComponentRoot, component := state.EndVisit(funcDecl); isComponentRoot {
+			members := make([]*ast.FuncDecl, len(component))
+			for i, element := range component {
+				members[i] = element.(*ast.FuncDecl)
+			}
+			sort.Slice(members, func(i, j int) bool { return members[i].Pos() < members[j].Pos() })
+			result = append(result, members)
+		}
+	}
+	for _, funcDecl := range funcDecls {
+		if _, ok := visited[funcDecl]; !ok {
+			visit(funcDecl)
+		}
+	}
+	return result
+}
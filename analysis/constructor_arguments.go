@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkConstructorCallArguments checks the calls of error constructors in the package, which were not analysed yet,
+// e.g. because the created error is not returned by a function declaring error codes, but logged or stored.
+//
+// Their error code argument has to be a constant, or an error code parameter declared by the calling function,
+// otherwise the codes of the created errors are lost. Non-constant arguments are checked like in analysed calls,
+// so the same diagnostics are reported, e.g. when passing a parameter of a function without "param:" declaration.
+// Constant arguments are not checked, as their codes are tracked wherever the created errors are returned.
+func checkConstructorCallArguments(c *context) {
+	pass := c.pass
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			function := &funcDefinition{funcDecl, nil}
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				callExpr, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if _, checked := c.constructorCalls[callExpr]; checked {
+					return true
+				}
+
+				callee := typeutil.Callee(pass.TypesInfo, callExpr)
+				if callee == nil {
+					callee = getReferencedFunc(pass, callExpr.Fun)
+				}
+				var fact ErrorConstructor
+				if callee == nil || !pass.ImportObjectFact(callee, &fact) {
+					return true
+				}
+
+				position := fact.CodeParamPosition
+				if isMethodExpression(pass, callExpr.Fun) {
+					position++
+				}
+				if position >= len(callExpr.Args) || getConstantValue(pass, callExpr.Args[position]) != nil {
+					return true
+				}
+
+				extractErrorCodesFromConstructorCall(c, function, callExpr.Fun, callee, callExpr)
+				return true
+			})
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// exportForwardedErrorConstructorFacts exports ErrorConstructor facts for helper functions of the package,
+// to which error constructors forward their error code parameter, e.g. `newErrorInternal` in:
+//
+//	// Errors:
+//	//
+//	//    - param: code --
+//	func NewError(code string) error {
+//		return newErrorInternal(code, "")
+//	}
+//
+// Such helpers may use the parameter as an error code without declaring it,
+// so they do not need an "Errors:" block of their own. Helpers with an "Errors:" block keep what they declare.
+//
+// The facts are propagated through nested helpers, with the prefix of the forwarding constructor.
+// If a helper is forwarded different parameters, only the first one found is used.
+func exportForwardedErrorConstructorFacts(pass *analysis.Pass, lookup *funcLookup, claims funcCodesMap) {
+	declarations := map[*types.Func]*ast.FuncDecl{}
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		if fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+			declarations[fn] = funcDecl
+		}
+	})
+
+	var queue []*ast.FuncDecl
+	for funcDecl, codes := range claims {
+		if codes.param != nil && !codes.param.union {
+			queue = append(queue, funcDecl)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool { return queue[i].Pos() < queue[j].Pos() })
+
+	for len(queue) > 0 {
+		constructor := queue[0]
+		queue = queue[1:]
+
+		var fact ErrorConstructor
+		if !pass.ImportObjectFact(pass.TypesInfo.Defs[constructor.Name], &fact) {
+			continue
+		}
+		param := getParamIdent(constructor.Type, fact.CodeParamPosition)
+		if param == nil || constructor.Body == nil {
+			continue
+		}
+
+		ast.Inspect(constructor.Body, func(node ast.Node) bool {
+			callExpr, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			callee, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+			if !ok || callee.Pkg() != pass.Pkg || isMethodExpression(pass, callExpr.Fun) {
+				return true
+			}
+			helper, ok := declarations[callee]
+			if !ok || hasErrorDocs(pass, helper) || isCodeSetterMethod(pass, helper) || pass.ImportObjectFact(callee, new(ErrorConstructor)) || pass.ImportObjectFact(callee, new(ErrorCodeOption)) {
+				return true
+			}
+
+			position, ok := findForwardedCodeParam(pass, callExpr, callee, param)
+			if !ok {
+				return true
+			}
+			pass.ExportObjectFact(callee, &ErrorConstructor{position, fact.Prefix})
+			queue = append(queue, helper)
+			return true
+		})
+	}
+}
+
+// findForwardedCodeParam finds the position of the parameter of the given callee, to which the given error code parameter is passed.
+// The parameter has to be a string parameter, which is not variadic.
+func findForwardedCodeParam(pass *analysis.Pass, callExpr *ast.CallExpr, callee *types.Func, param *ast.Ident) (int, bool) {
+	signature := callee.Type().(*types.Signature)
+	for i, arg := range callExpr.Args {
+		ident, ok := astutil.Unparen(arg).(*ast.Ident)
+		if !ok || ident.Obj == nil || ident.Obj != param.Obj {
+			continue
+		}
+
+		if i >= signature.Params().Len() || (signature.Variadic() && i >= signature.Params().Len()-1) {
+			return 0, false
+		}
+		if basic, ok := signature.Params().At(i).Type().(*types.Basic); !ok || basic.Kind() != types.String {
+			return 0, false
+		}
+		return i, true
+	}
+	return 0, false
+}
+
+// hasErrorDocs checks if the doc comments of the given function contain an "Errors:" block, also if it is invalid.
+func hasErrorDocs(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
+	codes, param, declaredNoCodesOk, err := findErrorDocs(pass, funcDecl.Doc)
+	return err != nil || len(codes) > 0 || param != "" || declaredNoCodesOk || len(findErrorPassthroughParams(pass, funcDecl.Doc)) > 0
+}
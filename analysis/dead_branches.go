@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// inspectLiveBranches inspects the given if or switch statement with the given function,
+// skipping all branches that can not be reached because of constant conditions, e.g. `if false { ... }`
+// or `if debug { ... }` with a constant `debug` set to false.
+//
+// For switch statements only tagless switches are pruned, e.g. `switch { case false: ... }`.
+// The result is meant to be returned by the function passed to ast.Inspect, as the statement has been fully inspected.
+func inspectLiveBranches(pass *analysis.Pass, stmt ast.Stmt, inspect func(ast.Node) bool) bool {
+	switch stmt := stmt.(type) {
+	case *ast.IfStmt:
+		condition, ok := getConstantCondition(pass, stmt.Cond)
+		if !ok {
+			return true
+		}
+
+		if stmt.Init != nil {
+			ast.Inspect(stmt.Init, inspect)
+		}
+		if condition {
+			ast.Inspect(stmt.Body, inspect)
+		} else if stmt.Else != nil {
+			ast.Inspect(stmt.Else, inspect)
+		}
+		return false
+	case *ast.SwitchStmt:
+		if stmt.Tag != nil {
+			return true
+		}
+
+		if stmt.Init != nil {
+			ast.Inspect(stmt.Init, inspect)
+		}
+		for _, clause := range stmt.Body.List {
+			clause := clause.(*ast.CaseClause)
+			if !isDeadCaseClause(pass, clause) {
+				ast.Inspect(clause, inspect)
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isDeadCaseClause checks if the given clause of a tagless switch can never be reached,
+// because all of its expressions are constant false.
+func isDeadCaseClause(pass *analysis.Pass, clause *ast.CaseClause) bool {
+	if len(clause.List) == 0 {
+		return false // default clause
+	}
+
+	for _, expr := range clause.List {
+		if condition, ok := getConstantCondition(pass, expr); !ok || condition {
+			return false
+		}
+	}
+	return true
+}
+
+// getConstantCondition returns the value of the given condition, if it is a constant boolean expression.
+func getConstantCondition(pass *analysis.Pass, expr ast.Expr) (bool, bool) {
+	value := pass.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.Bool {
+		return false, false
+	}
+	return constant.BoolVal(value), true
+}
@@ -0,0 +1,251 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// detailsPrefix starts the declaration of the keys of the details of an error code in the comment of its entry,
+// e.g. `- storage-not-found -- details: id, kind; if the key does not exist`.
+// The keys are separated by commas, the description of the code may follow after a semicolon.
+const detailsPrefix = "details:"
+
+// parseDetailKeys splits the comment of an entry in an "Errors:" block into the declared detail keys and the remaining description.
+//
+// If the comment does not declare details, the keys are nil and the description is the whole comment.
+func parseDetailKeys(comment string) ([]string, string, error) {
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, detailsPrefix) {
+		return nil, comment, nil
+	}
+
+	declaration, description := comment[len(detailsPrefix):], ""
+	if end := strings.Index(declaration, ";"); end != -1 {
+		declaration, description = declaration[:end], strings.TrimSpace(declaration[end+1:])
+	}
+
+	keys := []string{}
+	seen := map[string]struct{}{}
+	for _, key := range strings.Split(declaration, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, "", newMessageError(MsgDocInvalidDetailKey, key)
+		}
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, description, nil
+}
+
+// findDeclaredDetails returns the declared detail keys of the error codes in the "Errors:" block of the given doc comment.
+// Codes without declared details are not part of the result.
+func findDeclaredDetails(doc *ast.CommentGroup) map[string][]string {
+	if doc == nil {
+		return nil
+	}
+
+	lines := strings.Split(doc.Text(), "\n")
+	start, end := findErrorDocsBlock(lines)
+	if start == -1 {
+		return nil
+	}
+
+	result := map[string][]string{}
+	_, entries := parseErrorDocEntries(lines[start:end])
+	for _, entry := range entries {
+		if entry.isParam || entry.isExcluded {
+			continue
+		}
+		keys, _, err := parseDetailKeys(entry.comment)
+		if err == nil && keys != nil {
+			result[entry.code] = keys
+		}
+	}
+	return result
+}
+
+// checkErrorDetails reports errors created in the functions, which declare the detail keys of error codes,
+// whose details are initialised with a map literal that does not contain exactly the declared keys.
+//
+// Only composite literals of error types of the analysed package are checked,
+// whose error code is constant and whose "Details() map[string]string" method returns a field of the error.
+func checkErrorDetails(c *context, funcClaims funcCodesMap) {
+	pass := c.pass
+
+	for funcDecl := range funcClaims {
+		declared := findDeclaredDetails(funcDecl.Doc)
+		if len(declared) == 0 || funcDecl.Body == nil {
+			continue
+		}
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+
+			code, details, ok := findConstructedDetails(c, lit)
+			if !ok {
+				return true
+			}
+			keys, ok := declared[code]
+			if !ok {
+				return true
+			}
+
+			found := Set()
+			for _, element := range details.Elts {
+				value := pass.TypesInfo.Types[element.(*ast.KeyValueExpr).Key].Value
+				found.Add(constant.StringVal(value))
+			}
+			reportIfDetailsDoNotMatch(pass, details, code, found, SliceToSet(keys))
+			return true
+		})
+	}
+}
+
+// findConstructedDetails finds the error code and the map literal initialising the details of the error created by the given composite literal.
+//
+// If the error code is not constant or the details are not initialised with a map literal with constant keys, false is returned.
+func findConstructedDetails(c *context, lit *ast.CompositeLit) (string, *ast.CompositeLit, bool) {
+	pass := c.pass
+
+	if getNamedType(pass.TypesInfo.TypeOf(lit)) == nil {
+		return "", nil, false // e.g. a map literal
+	}
+	errorType, err := getErrorTypeForError(pass, pass.TypesInfo.TypeOf(lit))
+	if err != nil || errorType == nil {
+		return "", nil, false
+	}
+
+	var code string
+	switch {
+	case errorType.Field != nil:
+		codeExpr := findFieldInitExpression(pass, lit, errorType.Field)
+		if codeExpr == nil {
+			return "", nil, false
+		}
+		value := pass.TypesInfo.Types[codeExpr].Value
+		if value == nil || value.Kind() != constant.String {
+			return "", nil, false
+		}
+		code = constant.StringVal(value)
+	case len(errorType.Codes) == 1:
+		code = errorType.Codes[0]
+	default:
+		return "", nil, false
+	}
+
+	field := findDetailsField(c, pass.TypesInfo.TypeOf(lit))
+	if field == nil {
+		return "", nil, false
+	}
+	details, ok := astutil.Unparen(findFieldInitExpression(pass, lit, field)).(*ast.CompositeLit)
+	if !ok {
+		return "", nil, false
+	}
+	for _, element := range details.Elts {
+		element, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			return "", nil, false
+		}
+		if value := pass.TypesInfo.Types[element.Key].Value; value == nil || value.Kind() != constant.String {
+			return "", nil, false
+		}
+	}
+	return code, details, true
+}
+
+// findDetailsField finds the field returned by the "Details() map[string]string" method of the given error type.
+//
+// The method has to be declared in the analysed package and has to return the field directly, e.g. `return e.Details`,
+// otherwise nil is returned.
+func findDetailsField(c *context, errorType types.Type) *ErrorCodeField {
+	pass, lookup := c.pass, c.lookup
+
+	named := getNamedType(errorType)
+	if named == nil {
+		return nil
+	}
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	method := lookup.searchMethod(pass, types.NewPointer(named), "Details")
+	if method == nil || method.Body == nil || len(method.Body.List) != 1 || !isDetailsSignature(pass, method) {
+		return nil
+	}
+	receiver := method.Recv.List[0]
+	if len(receiver.Names) != 1 {
+		return nil
+	}
+
+	returnStmt, ok := method.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(returnStmt.Results) != 1 {
+		return nil
+	}
+	selector, ok := astutil.Unparen(returnStmt.Results[0]).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if ident, ok := astutil.Unparen(selector.X).(*ast.Ident); !ok || ident.Obj == nil || ident.Obj != receiver.Names[0].Obj {
+		return nil
+	}
+
+	for i := 0; i < structType.NumFields(); i++ {
+		if structType.Field(i).Name() == selector.Sel.Name {
+			return &ErrorCodeField{selector.Sel.Name, i}
+		}
+	}
+	return nil
+}
+
+// isDetailsSignature checks if the given method has the signature "Details() map[string]string".
+func isDetailsSignature(pass *analysis.Pass, method *ast.FuncDecl) bool {
+	fn, ok := pass.TypesInfo.Defs[method.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	signature := fn.Type().(*types.Signature)
+	if signature.Params().Len() != 0 || signature.Results().Len() != 1 {
+		return false
+	}
+	mapType, ok := signature.Results().At(0).Type().Underlying().(*types.Map)
+	if !ok {
+		return false
+	}
+	key, keyOk := mapType.Key().Underlying().(*types.Basic)
+	elem, elemOk := mapType.Elem().Underlying().(*types.Basic)
+	return keyOk && elemOk && key.Kind() == types.String && elem.Kind() == types.String
+}
+
+// reportIfDetailsDoNotMatch emits a diagnostic if the found detail keys of an error with the given code differ from the declared ones.
+func reportIfDetailsDoNotMatch(pass *analysis.Pass, details *ast.CompositeLit, code string, foundKeys, declaredKeys CodeSet) {
+	missingKeys := Difference(declaredKeys, foundKeys).Slice()
+	unexpectedKeys := Difference(foundKeys, declaredKeys).Slice()
+	var errorMessages []string
+
+	if len(missingKeys) > 0 {
+		sort.Strings(missingKeys)
+		errorMessages = append(errorMessages, FormatMessage(MsgMissingDetailKeys, missingKeys))
+	}
+
+	if len(unexpectedKeys) > 0 {
+		sort.Strings(unexpectedKeys)
+		errorMessages = append(errorMessages, FormatMessage(MsgUnexpectedDetailKeys, unexpectedKeys))
+	}
+
+	if len(errorMessages) > 0 {
+		reportPosForCodes(pass, details.Pos(), []string{code}, MsgDetailsMismatch, code, strings.Join(errorMessages, " "))
+	}
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"go/ast"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// DocsAnalyzer parses the "Errors:" blocks of all comments of a package once.
+//
+// The serum analyzer and other analyzers requiring DocsAnalyzer share the parsed declarations,
+// so they do not parse the same comments again and agree on which docs are invalid.
+var DocsAnalyzer = &analysis.Analyzer{
+	Name:       "serumdocs",
+	Doc:        "Parses the error code declarations in the doc comments of a package.",
+	Run:        runDocs,
+	ResultType: reflect.TypeOf((*Docs)(nil)),
+}
+
+// Docs is the result of the DocsAnalyzer, holding the parsed "Errors:" blocks of the comments of a package.
+type Docs struct {
+	parsed map[*ast.CommentGroup]*parsedErrorDocs
+}
+
+// parsedErrorDocs holds the error code declarations found in a comment by findErrorDocsSM.
+type parsedErrorDocs struct {
+	codes       CodeSet
+	param       string
+	noCodesOk   bool
+	err         error
+	excluded    CodeSet
+	prefix      string
+	passthrough []string
+}
+
+func runDocs(pass *analysis.Pass) (interface{}, error) {
+	docs := &Docs{map[*ast.CommentGroup]*parsedErrorDocs{}}
+	for _, file := range pass.Files {
+		for _, comments := range file.Comments {
+			docs.parsed[comments] = parseErrorDocs(comments)
+		}
+	}
+	return docs, nil
+}
+
+// parseErrorDocs parses the error code declarations of the given comments.
+func parseErrorDocs(comments *ast.CommentGroup) *parsedErrorDocs {
+	sm := &findErrorDocsSM{}
+	codes, param, noCodesOk, err := sm.run(comments.Text())
+	if err != nil {
+		return &parsedErrorDocs{err: err, excluded: Set()}
+	}
+	return &parsedErrorDocs{codes, param, noCodesOk, nil, sm.excluded, sm.prefix, sm.passthrough}
+}
+
+// lookup returns the parsed error code declarations of the given comments.
+// Comments which are not part of the files of the package, are parsed on demand.
+func (d *Docs) lookup(comments *ast.CommentGroup) *parsedErrorDocs {
+	if parsed, ok := d.parsed[comments]; ok {
+		return parsed
+	}
+	return parseErrorDocs(comments)
+}
+
+// ErrorCodes returns the sorted error codes declared in the "Errors:" block of the given comments.
+//
+// The result is false if the comments do not declare error codes, neither by listing codes nor by "Errors: none".
+// Invalid blocks result in an error, which describes the problem.
+func (d *Docs) ErrorCodes(comments *ast.CommentGroup) ([]string, bool, error) {
+	if comments == nil {
+		return nil, false, nil
+	}
+
+	parsed := d.lookup(comments)
+	if parsed.err != nil {
+		return nil, false, parsed.err
+	}
+	codes := parsed.codes.Slice()
+	sort.Strings(codes)
+	return codes, len(codes) > 0 || parsed.noCodesOk || parsed.param != "" || len(parsed.passthrough) > 0, nil
+}
+
+// getDocs returns the parsed docs of the package of the given pass.
+// Passes of analyzers not requiring the DocsAnalyzer parse the docs on demand.
+func getDocs(pass *analysis.Pass) *Docs {
+	if docs, ok := pass.ResultOf[DocsAnalyzer].(*Docs); ok {
+		return docs
+	}
+	return &Docs{map[*ast.CommentGroup]*parsedErrorDocs{}}
+}
+
+// findErrorDocs looks at the given comments and tries to find error code declarations.
+// The returned set is a copy, which may be modified by the caller.
+func findErrorDocs(pass *analysis.Pass, comments *ast.CommentGroup) (CodeSet, string, bool, error) {
+	if comments == nil {
+		return nil, "", false, nil
+	}
+	parsed := getDocs(pass).lookup(comments)
+	if parsed.err != nil {
+		return nil, "", false, parsed.err
+	}
+	return Union(parsed.codes, nil), parsed.param, parsed.noCodesOk, nil
+}
+
+// findExcludedErrorDocs finds the error codes declared as never returned by negative claims in the given doc comments.
+// Invalid doc comments are reported by findErrorDocs, so they result in an empty set here.
+func findExcludedErrorDocs(pass *analysis.Pass, comments *ast.CommentGroup) CodeSet {
+	if comments == nil {
+		return Set()
+	}
+	return Union(getDocs(pass).lookup(comments).excluded, nil)
+}
+
+// findErrorCodeParamPrefix finds the prefix declared for the error code parameter in the given doc comments,
+// e.g. "storage-" for "- param: code (prefix "storage-") --".
+// Invalid doc comments are reported by findErrorDocs, so they result in an empty prefix here.
+func findErrorCodeParamPrefix(pass *analysis.Pass, comments *ast.CommentGroup) string {
+	if comments == nil {
+		return ""
+	}
+	return getDocs(pass).lookup(comments).prefix
+}
+
+// findErrorPassthroughParams finds the names of the error parameters declared with "param-passthrough:" in the given doc comments.
+// Invalid doc comments are reported by findErrorDocs, so they result in no parameters here.
+// The result is a copy, as the parsed doc comments are shared by all lookups.
+func findErrorPassthroughParams(pass *analysis.Pass, comments *ast.CommentGroup) []string {
+	if comments == nil {
+		return nil
+	}
+	return append([]string(nil), getDocs(pass).lookup(comments).passthrough...)
+}
@@ -0,0 +1,39 @@
+package analysis
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// docCodesAnalyzer reports the error codes declared in the doc comments of every function, using the result of the docs analyzer.
+var docCodesAnalyzer = &analysis.Analyzer{
+	Name:     "doccodes",
+	Doc:      "Test analyzer using the Result of the docs analyzer.",
+	Requires: []*analysis.Analyzer{DocsAnalyzer},
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		docs := pass.ResultOf[DocsAnalyzer].(*Docs)
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				codes, ok, err := docs.ErrorCodes(funcDecl.Doc)
+				if err != nil {
+					pass.Reportf(funcDecl.Pos(), "invalid: %v", err)
+				} else if ok {
+					pass.Reportf(funcDecl.Pos(), "codes: [%s]", strings.Join(codes, " "))
+				}
+			}
+		}
+		return nil, nil
+	},
+}
+
+func TestDocsErrorCodes(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), docCodesAnalyzer, "docs")
+}
@@ -0,0 +1,335 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	errgroupPackagePath = "golang.org/x/sync/errgroup"
+
+	// annotationIndicatorErrorGroup starts the annotation of a custom error group type in its doc comment.
+	annotationIndicatorErrorGroup = "Error Group:"
+)
+
+// ErrorGroup is a fact that is used to tag custom error group types, e.g. in-house worker pools,
+// whose wait method returns an error of one of the functions submitted to the group.
+//
+// For example a type annotated with "Error Group: Submit -> Wait" in its doc comment
+// gets an ErrorGroup{SubmitMethods: []string{"Submit"}, WaitMethod: "Wait"} fact.
+type ErrorGroup struct {
+	SubmitMethods []string // names of the methods taking the functions to run
+	WaitMethod    string   // name of the method returning the error of one of the functions
+}
+
+func (*ErrorGroup) AFact() {}
+
+func (e *ErrorGroup) String() string {
+	return fmt.Sprintf("ErrorGroup: {Submit:%v, Wait:%s}", e.SubmitMethods, e.WaitMethod)
+}
+
+// exportErrorGroupFacts exports ErrorGroup facts for all types of the package, which are annotated as error group:
+//
+//	// Pool runs submitted tasks on a fixed number of workers.
+//	//
+//	// Error Group: Submit -> Wait
+//	type Pool struct { ... }
+//
+// The methods before the arrow submit functions to the group and have to take a single function returning an error.
+// The method after the arrow waits for the submitted functions and has to return an error.
+func exportErrorGroupFacts(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				if fact, ok := findErrorGroupAnnotation(pass, typeSpec, doc); ok {
+					pass.ExportObjectFact(pass.TypesInfo.Defs[typeSpec.Name], fact)
+				}
+			}
+		}
+	}
+}
+
+// findErrorGroupAnnotation parses the error group annotation in the given doc comment of the given type, if there is one,
+// and checks that the annotated methods exist with the required signatures.
+func findErrorGroupAnnotation(pass *analysis.Pass, typeSpec *ast.TypeSpec, doc *ast.CommentGroup) (*ErrorGroup, bool) {
+	if doc == nil {
+		return nil, false
+	}
+
+	line, found := "", false
+	for _, text := range strings.Split(doc.Text(), "\n") {
+		text = strings.TrimSpace(text)
+		if strings.HasPrefix(text, annotationIndicatorErrorGroup) {
+			line, found = strings.TrimPrefix(text, annotationIndicatorErrorGroup), true
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	parts := strings.Split(line, "->")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		report(pass, typeSpec.Name, MsgErrorGroupSyntax, annotationIndicatorErrorGroup)
+		return nil, false
+	}
+
+	typeName, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	methods := types.NewMethodSet(types.NewPointer(typeName.Type()))
+	findMethod := func(name string) *types.Signature {
+		selection := methods.Lookup(pass.Pkg, name)
+		if selection == nil {
+			report(pass, typeSpec.Name, MsgErrorGroupMethodMissing, typeName.Name(), name)
+			return nil
+		}
+		return selection.Type().(*types.Signature)
+	}
+
+	submit, wait := parts[0], strings.TrimSpace(parts[1])
+	fact := &ErrorGroup{WaitMethod: wait}
+	for _, name := range strings.Split(submit, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			report(pass, typeSpec.Name, MsgErrorGroupSyntax, annotationIndicatorErrorGroup)
+			return nil, false
+		}
+		signature := findMethod(name)
+		if signature == nil {
+			return nil, false
+		}
+		if !isErrorGroupSubmitSignature(signature) {
+			report(pass, typeSpec.Name, MsgErrorGroupSubmit, name)
+			return nil, false
+		}
+		fact.SubmitMethods = append(fact.SubmitMethods, name)
+	}
+
+	signature := findMethod(wait)
+	if signature == nil {
+		return nil, false
+	}
+	if results := signature.Results(); results.Len() == 0 || !types.Implements(results.At(results.Len()-1).Type(), tError) {
+		report(pass, typeSpec.Name, MsgErrorGroupWait, wait)
+		return nil, false
+	}
+	return fact, true
+}
+
+// isErrorGroupSubmitSignature checks if the given signature takes a single function, whose last result is an error.
+func isErrorGroupSubmitSignature(signature *types.Signature) bool {
+	if signature.Params().Len() != 1 || signature.Variadic() {
+		return false
+	}
+	task, ok := signature.Params().At(0).Type().Underlying().(*types.Signature)
+	return ok && task.Results().Len() > 0 && types.Implements(task.Results().At(task.Results().Len()-1).Type(), tError)
+}
+
+// isErrorGroupWait checks if the given callee is the Wait() method of an errgroup.Group,
+// or the wait method of a type annotated as error group.
+func isErrorGroupWait(pass *analysis.Pass, callee types.Object) bool {
+	if isErrgroupMethod(callee, "Wait") {
+		return true
+	}
+	fact, ok := getErrorGroupFact(pass, callee)
+	return ok && callee.Name() == fact.WaitMethod
+}
+
+// isErrorGroupSubmit checks if the given callee is the Go() or TryGo() method of an errgroup.Group,
+// or one of the submit methods of a type annotated as error group.
+func isErrorGroupSubmit(pass *analysis.Pass, callee types.Object) bool {
+	if isErrgroupMethod(callee, "Go", "TryGo") {
+		return true
+	}
+	fact, ok := getErrorGroupFact(pass, callee)
+	if !ok {
+		return false
+	}
+	for _, name := range fact.SubmitMethods {
+		if callee.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorGroupWaitMethod checks if the given function declaration is the wait method of a type annotated as error group.
+// Like the Wait() method of an errgroup.Group, it does not have to declare error codes, as they are taken from the call site.
+func isErrorGroupWaitMethod(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
+	fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	fact, ok := getErrorGroupFact(pass, fn)
+	return ok && fn.Name() == fact.WaitMethod
+}
+
+// getErrorGroupFact returns the ErrorGroup fact of the receiver type of the given method.
+func getErrorGroupFact(pass *analysis.Pass, callee types.Object) (*ErrorGroup, bool) {
+	fn, ok := callee.(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil, false
+	}
+	named := getNamedType(recv.Type())
+	if named == nil {
+		return nil, false
+	}
+
+	fact := new(ErrorGroup)
+	if !pass.ImportObjectFact(named.Obj(), fact) {
+		return nil, false
+	}
+	return fact, true
+}
+
+// isErrgroupMethod checks if the given callee is one of the given methods of errgroup.Group.
+func isErrgroupMethod(callee types.Object, methodNames ...string) bool {
+	fn, ok := callee.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+
+	// Also accept vendored copies of the package.
+	path := fn.Pkg().Path()
+	if path != errgroupPackagePath && !strings.HasSuffix(path, "/vendor/"+errgroupPackagePath) {
+		return false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+
+	named := getNamedType(recv.Type())
+	if named == nil || named.Obj().Name() != "Group" {
+		return false
+	}
+
+	for _, name := range methodNames {
+		if fn.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findErrorCodesFromErrgroupWait finds the error codes returned by a call to Wait() on an errgroup.Group,
+// or to the wait method of a type annotated as error group.
+//
+// Wait() returns the first error returned by any of the functions started with Go() or TryGo() on the same group.
+// Therefore the result is the union of the error codes of all functions passed to Go() or TryGo() within the given function,
+// or to the submit methods of an annotated error group.
+// To make this analysis possible, the group has to be a local variable, which is not passed to other functions.
+func findErrorCodesFromErrgroupWait(c *context, callExpr *ast.CallExpr, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	selector, ok := astutil.Unparen(callExpr.Fun).(*ast.SelectorExpr)
+	if !ok {
+		report(pass, callExpr.Fun, MsgErrgroupNotVariable)
+		return nil
+	}
+
+	groupIdent := getErrgroupIdent(selector.X)
+	if groupIdent == nil {
+		report(pass, selector.X, MsgErrgroupNotVariable)
+		return nil
+	}
+
+	if isIdentOriginOutsideFunctionScope(function, groupIdent) {
+		report(pass, groupIdent, MsgErrgroupOutOfScope)
+		return nil
+	}
+
+	isGroup := func(expr ast.Expr) bool {
+		ident := getErrgroupIdent(expr)
+		return ident != nil && ident.Obj == groupIdent.Obj
+	}
+
+	result := Set()
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		callee := typeutil.Callee(pass.TypesInfo, call)
+		if isErrorGroupSubmit(pass, callee) {
+			selector, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+			if ok && isGroup(selector.X) && len(call.Args) == 1 {
+				newCodes := findErrorCodesInErrgroupTask(c, callee.Name(), call.Args[0], function)
+				result = Union(result, newCodes)
+			}
+			return true
+		}
+
+		for _, arg := range call.Args {
+			if isGroup(arg) {
+				report(pass, arg, MsgErrgroupLeaked)
+			}
+		}
+		return true
+	})
+
+	return result
+}
+
+// findErrorCodesInErrgroupTask finds the error codes of a function passed to Go() or TryGo() of an errgroup.Group,
+// or to a submit method of an annotated error group.
+func findErrorCodesInErrgroupTask(c *context, methodName string, task ast.Expr, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	switch task := astutil.Unparen(task).(type) {
+	case *ast.FuncLit:
+		return findErrorCodesInCalledFunc(c, function, &funcDefinition{nil, task})
+	case *ast.Ident:
+		return findErrorCodesFromFunctionCall(c, function, task, pass.TypesInfo.Uses[task], nil)
+	case *ast.SelectorExpr:
+		var callee types.Object
+		if selection, ok := pass.TypesInfo.Selections[task]; ok {
+			callee = selection.Obj()
+		} else {
+			callee = pass.TypesInfo.Uses[task.Sel]
+		}
+		return findErrorCodesFromFunctionCall(c, function, task, callee, nil)
+	default:
+		report(pass, task, MsgErrgroupTask, methodName)
+		return nil
+	}
+}
+
+// getErrgroupIdent returns the identifier of the group variable in expressions like `g` or `&g`,
+// or nil if the expression has another shape.
+func getErrgroupIdent(expr ast.Expr) *ast.Ident {
+	expr = astutil.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = astutil.Unparen(unary.X)
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident.Obj == nil {
+		return nil
+	}
+	return ident
+}
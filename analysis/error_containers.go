@@ -0,0 +1,255 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// errorContainerAccessors are the names of the methods returning the error stored in an error container.
+// "First" and "AsError" are the usual accessors of error collections, which hold multiple errors.
+var errorContainerAccessors = []string{"Err", "First", "AsError"}
+
+// isErrorContainer checks if the given type is an error container like `Result[T]`,
+// or an error collection like `type Errors []error`.
+//
+// An error container is a concrete type, which is not an error itself, but carries errors that are returned by
+// one of its accessor methods: `Err() error`, `First() error` or `AsError() error`.
+// Functions returning an error container may declare error codes like functions returning an error,
+// and calls to an accessor on a container return the error codes of the errors stored in it.
+//
+// If the -result-structs flag is set, result structs like `Result{Value: v, Err: err}` are error containers as well.
+func isErrorContainer(typ types.Type) bool {
+	for _, name := range errorContainerAccessors {
+		if getErrorContainerAccessor(typ, name) != nil {
+			return true
+		}
+	}
+	return cliArguments.resultStructs && isResultStruct(typ)
+}
+
+// isResultStruct checks if the given type is a struct or a pointer to a struct, which is not an error itself,
+// but has fields that are errors, e.g. `Result{Value: v, Err: err}`.
+// The error codes of a result struct are the error codes of all errors stored in its fields.
+func isResultStruct(typ types.Type) bool {
+	if typ == nil || types.Implements(typ, tError) {
+		return false
+	}
+	if pointer, ok := getUnderlyingType(typ).(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	if types.Implements(typ, tError) || types.Implements(types.NewPointer(typ), tError) {
+		return false
+	}
+
+	structType, ok := getUnderlyingType(typ).(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		if types.Implements(structType.Field(i).Type(), tError) {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorSlice checks if the given type is a slice of errors, e.g. `[]error`.
+// Slices of errors have no accessors, but are used to build error collections.
+func isErrorSlice(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	slice, ok := getUnderlyingType(typ).(*types.Slice)
+	return ok && types.Implements(slice.Elem(), tError)
+}
+
+// getErrorContainerAccessor returns the accessor method with the given name of the given error container type, or nil if there is none.
+func getErrorContainerAccessor(typ types.Type, name string) *types.Func {
+	if typ == nil || types.IsInterface(typ) || types.Implements(typ, tError) {
+		return nil
+	}
+
+	object, _, _ := types.LookupFieldOrMethod(typ, true, nil, name)
+	accessor, ok := object.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	signature := accessor.Type().(*types.Signature)
+	if signature.Params().Len() != 0 || signature.Results().Len() != 1 || !types.Implements(signature.Results().At(0).Type(), tError) {
+		return nil
+	}
+	return accessor
+}
+
+// findErrorContainerProducers finds all functions that return an error container or an error iterator as last result and declare error codes.
+// For those functions the declared error codes are checked, like for functions returning an error.
+func findErrorContainerProducers(pass *analysis.Pass, lookup *funcLookup) []*ast.FuncDecl {
+	var result []*ast.FuncDecl
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		results := funcDecl.Type.Results
+		if results == nil || funcDecl.Doc == nil {
+			return
+		}
+
+		lastResult := results.List[len(results.List)-1]
+		if typ := pass.TypesInfo.TypeOf(lastResult.Type); !isErrorContainer(typ) && !isErrorIterator(typ) {
+			return
+		}
+
+		codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, funcDecl.Doc)
+		if err != nil || len(codes) > 0 || errorCodeParamName != "" || declaredNoCodesOk {
+			result = append(result, funcDecl)
+		}
+	})
+	return result
+}
+
+// isErrorContainerAccessorMethod checks if the given method is an accessor method of an error container.
+func isErrorContainerAccessorMethod(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
+	if !isMethod(funcDecl) {
+		return false
+	}
+
+	receiverType := pass.TypesInfo.TypeOf(funcDecl.Recv.List[0].Type)
+	accessor := getErrorContainerAccessor(receiverType, funcDecl.Name.Name)
+	return accessor != nil && isErrorContainer(receiverType) && getOriginMethod(accessor) == pass.TypesInfo.Defs[funcDecl.Name]
+}
+
+// findErrorCodesFromErrorContainerAccess finds the error codes returned by a call to an accessor method of an error container,
+// e.g. `res.Err()` or `errs.First()`, which are the error codes of the errors stored in the container.
+//
+// If the call is not an access to an error container, false is returned.
+func findErrorCodesFromErrorContainerAccess(c *context, visitedIdents map[*ast.Object]struct{}, callExpr *ast.CallExpr, callee types.Object, startingFunc *funcDefinition) (CodeSet, bool) {
+	pass := c.pass
+
+	selector, ok := astutil.Unparen(callExpr.Fun).(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) != 0 {
+		return nil, false
+	}
+
+	accessor := getErrorContainerAccessor(pass.TypesInfo.TypeOf(selector.X), selector.Sel.Name)
+	calledMethod, ok := callee.(*types.Func)
+	if accessor == nil || !ok || getOriginMethod(accessor) != getOriginMethod(calledMethod) {
+		return nil, false
+	}
+
+	// Accessors that declare error codes on their own are handled like every other method.
+	var fact ErrorCodes
+	if pass.ImportObjectFact(getOriginMethod(accessor), &fact) {
+		return nil, false
+	}
+
+	if accessor.Pkg() == pass.Pkg {
+		// Facts of the current package are only exported after the analysis, so the docs are checked instead.
+		if funcDecl := c.lookup.searchMethod(pass, pass.TypesInfo.TypeOf(selector.X), selector.Sel.Name); funcDecl != nil {
+			codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, funcDecl.Doc)
+			if err != nil || len(codes) > 0 || errorCodeParamName != "" || declaredNoCodesOk {
+				return nil, false
+			}
+		}
+		return findErrorCodesInErrorContainer(c, visitedIdents, selector.X, startingFunc), true
+	}
+
+	// Containers of other packages are supported if they are returned by a function declaring error codes.
+	if call, ok := astutil.Unparen(selector.X).(*ast.CallExpr); ok {
+		producer := getReferencedFunc(pass, astutil.Unparen(call.Fun))
+		if producer != nil && pass.ImportObjectFact(producer, &fact) {
+			return fact.Codes, true
+		}
+	}
+	return nil, false
+}
+
+// findErrorCodesInErrorContainer finds the error codes of the errors stored in the given error container expression.
+func findErrorCodesInErrorContainer(c *context, visitedIdents map[*ast.Object]struct{}, expr ast.Expr, startingFunc *funcDefinition) CodeSet {
+	if c.pass.TypesInfo.Types[expr].IsNil() {
+		return Set()
+	}
+
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		return findErrorCodesInErrorContainerLiteral(c, visitedIdents, expr, startingFunc)
+	case *ast.UnaryExpr:
+		if literal, ok := astutil.Unparen(expr.X).(*ast.CompositeLit); ok && expr.Op == token.AND {
+			return findErrorCodesInErrorContainerLiteral(c, visitedIdents, literal, startingFunc)
+		}
+	case *ast.CallExpr:
+		if isBuiltinAppend(c.pass, expr) {
+			return findErrorCodesInErrorCollectionAppend(c, visitedIdents, expr, startingFunc)
+		}
+		return findErrorCodesInCallExpression(c, visitedIdents, expr, startingFunc)
+	case *ast.Ident:
+		return findErrorCodesFromIdentTaint(c, visitedIdents, expr, startingFunc)
+	}
+
+	report(c.pass, expr, MsgUnsupportedExpression)
+	return nil
+}
+
+// findErrorCodesInErrorContainerLiteral finds the error codes of all errors stored in the fields of the given error container literal,
+// or in the elements of the given error collection literal.
+func findErrorCodesInErrorContainerLiteral(c *context, visitedIdents map[*ast.Object]struct{}, literal *ast.CompositeLit, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+
+	if _, ok := getUnderlyingType(pass.TypesInfo.TypeOf(literal)).(*types.Slice); ok {
+		result := Set()
+		for _, element := range literal.Elts {
+			newCodes := findErrorCodesInExpression(c, visitedIdents, element, startingFunc)
+			result = Union(result, newCodes)
+		}
+		return result
+	}
+
+	structType, ok := getUnderlyingType(pass.TypesInfo.TypeOf(literal)).(*types.Struct)
+	if !ok {
+		report(pass, literal, MsgUnsupportedExpression)
+		return nil
+	}
+
+	result := Set()
+	for i, element := range literal.Elts {
+		var field *types.Var
+		if keyValue, ok := element.(*ast.KeyValueExpr); ok {
+			if key, ok := keyValue.Key.(*ast.Ident); ok {
+				field, _ = pass.TypesInfo.ObjectOf(key).(*types.Var)
+			}
+			element = keyValue.Value
+		} else if i < structType.NumFields() {
+			field = structType.Field(i)
+		}
+
+		if field == nil || !types.Implements(field.Type(), tError) {
+			continue
+		}
+
+		newCodes := findErrorCodesInExpression(c, visitedIdents, element, startingFunc)
+		result = Union(result, newCodes)
+	}
+	return result
+}
+
+// findErrorCodesInErrorCollectionAppend finds the error codes of the error collection resulting from the given call of append,
+// e.g. `append(errs, err)` or `append(errs, other...)`.
+func findErrorCodesInErrorCollectionAppend(c *context, visitedIdents map[*ast.Object]struct{}, callExpr *ast.CallExpr, startingFunc *funcDefinition) CodeSet {
+	result := findErrorCodesInErrorContainer(c, visitedIdents, callExpr.Args[0], startingFunc)
+	for i, arg := range callExpr.Args[1:] {
+		var newCodes CodeSet
+		if callExpr.Ellipsis.IsValid() && i == len(callExpr.Args)-2 {
+			newCodes = findErrorCodesInErrorContainer(c, visitedIdents, arg, startingFunc)
+		} else {
+			newCodes = findErrorCodesInExpression(c, visitedIdents, arg, startingFunc)
+		}
+		result = Union(result, newCodes)
+	}
+	return result
+}
+
+// isBuiltinAppend checks if the given call expression is a call of the builtin function append.
+func isBuiltinAppend(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	return isBuiltinCall(pass, callExpr, "append")
+}
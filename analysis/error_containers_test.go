@@ -0,0 +1,16 @@
+//go:build go1.18
+// +build go1.18
+
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrorContainers(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	analysistest.Run(t, dir, Analyzer, "error_containers/inner", "error_containers")
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isVariadicErrorParam checks if the given parameter is a variadic error parameter, e.g. `errs ...error`.
+func isVariadicErrorParam(pass *analysis.Pass, param *ast.Field) bool {
+	ellipsis, ok := param.Type.(*ast.Ellipsis)
+	if !ok {
+		return false
+	}
+
+	elementType := pass.TypesInfo.TypeOf(ellipsis.Elt)
+	return elementType != nil && types.Implements(elementType, tError)
+}
+
+// isErrorUnionParam checks if the given ident refers to the variadic error parameter of the given function,
+// which was declared with "param:" in the function's docs.
+func isErrorUnionParam(pass *analysis.Pass, function *funcDefinition, ident *ast.Ident) bool {
+	if function == nil || function.funcDecl == nil {
+		return false
+	}
+
+	var fact ErrorUnion
+	if !pass.ImportObjectFact(pass.TypesInfo.ObjectOf(function.funcDecl.Name), &fact) {
+		return false
+	}
+	return getParamPosition(function.Type(), ident) == fact.ParamPosition
+}
+
+// findErrorCodesFromErrorUnionCall finds the error codes of the arguments passed to the variadic error parameter
+// of the called function, if the function declares to return them.
+//
+// For example, calling `combine(errs ...error) error` as `combine(errA, errB)` returns the error codes of errA and errB.
+func findErrorCodesFromErrorUnionCall(c *context, visitedIdents map[*ast.Object]struct{}, callExpr *ast.CallExpr, callee types.Object, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+	result := Set()
+
+	fn, ok := callee.(*types.Func)
+	if !ok {
+		return result
+	}
+
+	var fact ErrorUnion
+	if !pass.ImportObjectFact(getOriginMethod(fn), &fact) {
+		return result
+	}
+
+	position := fact.ParamPosition
+	if isMethodExpression(pass, callExpr.Fun) {
+		position++ // The receiver is passed as first argument, e.g. `(*T).Combine(t, errs...)`.
+	}
+
+	// For `combine(errs...)` the only argument is a slice of errors, which is handled like an error collection.
+	for i := position; i < len(callExpr.Args); i++ {
+		newCodes := findErrorCodesInExpression(c, visitedIdents, callExpr.Args[i], startingFunc)
+		result = Union(result, newCodes)
+	}
+	return result
+}
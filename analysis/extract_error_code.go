@@ -6,10 +6,11 @@ import (
 	"go/constant"
 	"go/token"
 	"go/types"
-	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 // extractErrorCodesFromAffector extracts all error codes from the given affectors and returns them.
@@ -18,17 +19,15 @@ func extractErrorCodesFromAffector(pass *analysis.Pass, lookup *funcLookup, func
 
 	// Make sure method "Code() string" is present
 	if !checkErrorTypeHasLegibleCode(pass, affector) {
-		pass.ReportRangef(affector, "expression does not define an error code")
+		report(pass, affector, MsgNoErrorCode)
 		return result
 	}
 
 	errorType, err := getErrorTypeForError(pass, pass.TypesInfo.Types[affector].Type)
 	if err != nil || errorType == nil {
-		pass.ReportRangef(affector, "expression is not a valid error: error types must return constant error codes or a single field")
+		report(pass, affector, MsgInvalidErrorValue)
 	}
-	if err != nil {
-		logf("Error while looking at affector: %v (Affector: %#v)\n", err, affector)
-	} else if errorType != nil {
+	if err == nil && errorType != nil {
 		if len(errorType.Codes) > 0 {
 			result = Union(result, SliceToSet(errorType.Codes))
 		}
@@ -39,6 +38,13 @@ func extractErrorCodesFromAffector(pass *analysis.Pass, lookup *funcLookup, func
 				result.Add(code)
 			}
 		}
+
+		if errorType.Underlying {
+			code, ok := extractUnderlyingErrorCode(pass, affector, function)
+			if ok {
+				result.Add(code)
+			}
+		}
 	}
 
 	return result
@@ -60,6 +66,27 @@ func extractFieldErrorCode(pass *analysis.Pass, expr ast.Expr, function *funcDef
 	return extractErrorCodeFromStringExpression(pass, function, fieldExpr)
 }
 
+// extractUnderlyingErrorCode finds a possible error code from the given expression,
+// which creates an error whose value is its error code, e.g. `CodeError("some-code")` or `CodeError(code)`.
+func extractUnderlyingErrorCode(pass *analysis.Pass, expr ast.Expr, function *funcDefinition) (string, bool) {
+	expr = astutil.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = astutil.Unparen(unary.X)
+	}
+
+	// The value of a pointer, e.g. `(*CodeError)(nil)`, is tracked where it is created instead.
+	if _, ok := pass.TypesInfo.TypeOf(expr).(*types.Pointer); ok {
+		return "", false
+	}
+
+	// Constant expressions have their value recorded, also for conversions.
+	// Otherwise the converted expression may be an error code parameter.
+	if callExpr, ok := expr.(*ast.CallExpr); ok && pass.TypesInfo.Types[expr].Value == nil && len(callExpr.Args) == 1 {
+		expr = callExpr.Args[0]
+	}
+	return extractErrorCodeFromStringExpression(pass, function, expr)
+}
+
 func findFieldInitExpression(pass *analysis.Pass, constructExpr ast.Expr, field *ErrorCodeField) ast.Expr {
 	switch expr := astutil.Unparen(constructExpr).(type) {
 	case *ast.CompositeLit:
@@ -81,7 +108,7 @@ func findFieldInitExpression(pass *analysis.Pass, constructExpr ast.Expr, field
 
 			ident, ok := element.Key.(*ast.Ident)
 			if !ok {
-				logf("found weird key %#v in composite literal %#v\n", element.Key, expr)
+				reportInternalError(pass, element.Key, "key of struct literal is not a field name")
 				break
 			}
 
@@ -105,42 +132,125 @@ func findFieldInitExpression(pass *analysis.Pass, constructExpr ast.Expr, field
 		if expr.Op == token.AND {
 			return findFieldInitExpression(pass, expr.X, field)
 		}
-	default:
-		logf("findFieldInitExpression did not yet handle: %#v\n", expr)
+	case *ast.CallExpr:
+		if isBuiltinCall(pass, expr, "new") {
+			// Like for an empty composite literal, the code is being initialised to empty string.
+			return nil
+		}
 	}
 
-	pass.ReportRangef(constructExpr, "could not find initialiser for error code field in contructor expression")
+	report(pass, constructExpr, MsgFieldInitNotFound)
 	return nil
 }
 
-func extractErrorCodeFromConstructorCall(pass *analysis.Pass, startingFunc *funcDefinition, reportRange analysis.Range, callee types.Object, callExpr *ast.CallExpr) (string, bool) {
+// extractErrorCodesFromConstructorCall returns the error codes passed to the error code parameter of the given call,
+// if the callee is an error constructor.
+//
+// The argument may be a constant, an error code parameter of the calling constructor,
+// a local variable, whose codes are those of all constants assigned to it, e.g. `code := "some-error"; return NewError(code)`,
+// or the code of another error, e.g. `NewError(rerr.CodeOfOr(err, "some-error"))`.
+// The prefix declared for the error code parameter is added in front of each code.
+func extractErrorCodesFromConstructorCall(c *context, startingFunc *funcDefinition, calledFunction ast.Expr, callee types.Object, callExpr *ast.CallExpr) CodeSet {
+	pass := c.pass
+	result := Set()
+
 	var fact ErrorConstructor
 	if callee == nil || !pass.ImportObjectFact(callee, &fact) {
-		return "", false
+		return result
 	}
 
 	if callExpr == nil {
-		pass.ReportRangef(reportRange, "unsupported use of error constructor %q", callee.Name())
-		return "", false
+		report(pass, calledFunction, MsgConstructorUnsupported, callee.Name())
+		return result
+	}
+	c.constructorCalls[callExpr] = struct{}{}
+
+	position := fact.CodeParamPosition
+	if isMethodExpression(pass, calledFunction) {
+		position++ // The receiver is passed as first argument, e.g. `(*T).New(t, "code")`.
 	}
 
-	if fact.CodeParamPosition >= len(callExpr.Args) {
+	if position >= len(callExpr.Args) {
 		panic("should be unreachable: found function call using less arguments than defined in the function's parameter list")
 	}
 
-	return extractErrorCodeFromStringExpression(pass, startingFunc, callExpr.Args[fact.CodeParamPosition])
+	codeExpr := callExpr.Args[position]
+	if codes, ok := findErrorCodesFromCodeOfCall(c, codeExpr, startingFunc, fact.Prefix); ok {
+		return codes
+	}
+
+	ident, ok := astutil.Unparen(codeExpr).(*ast.Ident)
+	if !ok || !isLocalVariable(pass, startingFunc, ident) {
+		if code, ok := extractPrefixedErrorCode(pass, startingFunc, codeExpr, fact.Prefix); ok {
+			result.Add(code)
+		}
+		return result
+	}
+
+	taintResult := taintSpreadForIdentOfImmutableType(pass, map[*ast.Object]struct{}{}, ident, startingFunc)
+
+	// Identifiers outside of the function may still be the error code parameter of a calling constructor.
+	for _, badIdent := range taintResult.identOutOfScope {
+		if code, ok := extractPrefixedErrorCode(pass, startingFunc, badIdent, fact.Prefix); ok {
+			result.Add(code)
+		}
+	}
+
+	for _, destruct := range taintResult.destructAssignment {
+		report(pass, destruct.source, MsgNonConstantCode)
+	}
+
+	for _, expr := range taintResult.expressions {
+		if code, ok := extractPrefixedErrorCode(pass, startingFunc, expr, fact.Prefix); ok {
+			result.Add(code)
+		}
+	}
+
+	return result
+}
+
+// isLocalVariable checks if the given identifier refers to a variable declared inside of the body of the given function.
+// Parameters and results of the function are not local variables.
+func isLocalVariable(pass *analysis.Pass, function *funcDefinition, ident *ast.Ident) bool {
+	variable, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || ident.Obj == nil {
+		return false
+	}
+	return function.body().Pos() <= variable.Pos() && variable.Pos() < function.body().End()
 }
 
 func extractErrorCodeFromStringExpression(pass *analysis.Pass, function *funcDefinition, codeExpr ast.Expr) (string, bool) {
-	info, ok := pass.TypesInfo.Types[codeExpr]
-	if ok && info.Value != nil {
-		code, err := getErrorCodeFromConstant(info.Value)
+	return extractPrefixedErrorCode(pass, function, codeExpr, "")
+}
+
+// extractPrefixedErrorCode finds the error code of the given expression, to which the given prefix is added in front.
+// The prefix is added by error constructors declaring a prefix for their error code parameter.
+//
+// A constant prefix may also be added by the expression itself, e.g. `"storage-" + code`,
+// where code is an error code parameter declared with that prefix.
+func extractPrefixedErrorCode(pass *analysis.Pass, function *funcDefinition, codeExpr ast.Expr, prefix string) (string, bool) {
+	if value := getConstantValue(pass, codeExpr); value != nil {
+		if value.Kind() == constant.String && constant.StringVal(value) == "" {
+			return "", false // no error code, even if a prefix is added
+		}
+		if prefix != "" && value.Kind() == constant.String {
+			value = constant.MakeString(prefix + constant.StringVal(value))
+		}
+
+		code, err := getErrorCodeFromConstant(value)
 		if err != nil {
-			pass.ReportRangef(codeExpr, "%v", err)
+			reportError(pass, codeExpr, err)
 		}
 		return code, err == nil && code != ""
 	}
 
+	// The expression might add a prefix to an error code parameter, e.g. `"storage-" + code`.
+	if binary, ok := astutil.Unparen(codeExpr).(*ast.BinaryExpr); ok && binary.Op == token.ADD {
+		if value := getConstantValue(pass, binary.X); value != nil && value.Kind() == constant.String {
+			return extractPrefixedErrorCode(pass, function, binary.Y, prefix+constant.StringVal(value))
+		}
+	}
+
 	// function might be an error constructor and codeExpr the error code parameter.
 	fieldExprIdent, ok := astutil.Unparen(codeExpr).(*ast.Ident)
 	paramPosition := -1
@@ -149,31 +259,98 @@ func extractErrorCodeFromStringExpression(pass *analysis.Pass, function *funcDef
 	}
 
 	if paramPosition >= 0 {
-		checkIfExprIsErrorCodeParam(pass, function, &funcCodeParam{fieldExprIdent, paramPosition})
+		checkIfExprIsErrorCodeParam(pass, function, &funcCodeParam{fieldExprIdent, paramPosition, false, prefix})
 	} else {
-		pass.ReportRangef(codeExpr, "error code has to be constant value or error code parameter")
+		report(pass, codeExpr, MsgNonConstantCode)
 	}
 
 	return "", false
 }
 
+// getConstantValue returns the constant value of the given expression, or nil if it is not constant.
+//
+// Calls of fmt.Sprintf with only constant arguments are folded into a constant as well,
+// e.g. `fmt.Sprintf("%s-timeout", prefix)` with a constant prefix,
+// and so are concatenations with such calls, e.g. `fmt.Sprintf("%s-error", prefix) + "-timeout"`.
+func getConstantValue(pass *analysis.Pass, expr ast.Expr) constant.Value {
+	expr = astutil.Unparen(expr)
+	if value := pass.TypesInfo.Types[expr].Value; value != nil {
+		return value
+	}
+
+	if binary, ok := expr.(*ast.BinaryExpr); ok {
+		if binary.Op != token.ADD {
+			return nil
+		}
+		x, y := getConstantValue(pass, binary.X), getConstantValue(pass, binary.Y)
+		if x == nil || y == nil || x.Kind() != constant.String || y.Kind() != constant.String {
+			return nil
+		}
+		return constant.BinaryOp(x, token.ADD, y)
+	}
+
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok || callExpr.Ellipsis.IsValid() || len(callExpr.Args) == 0 {
+		return nil
+	}
+	callee, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || callee.Pkg() == nil || callee.Pkg().Path() != "fmt" || callee.Name() != "Sprintf" {
+		return nil
+	}
+
+	format := getConstantValue(pass, callExpr.Args[0])
+	if format == nil || format.Kind() != constant.String {
+		return nil
+	}
+
+	var args []interface{}
+	for _, arg := range callExpr.Args[1:] {
+		// Values of types with methods might be formatted by their String() method, which can not be folded.
+		if typ := pass.TypesInfo.TypeOf(arg); typ == nil || types.NewMethodSet(typ).Len() > 0 {
+			return nil
+		}
+
+		value := getConstantValue(pass, arg)
+		if value == nil {
+			return nil
+		}
+
+		switch value.Kind() {
+		case constant.String:
+			args = append(args, constant.StringVal(value))
+		case constant.Bool:
+			args = append(args, constant.BoolVal(value))
+		case constant.Int:
+			number, exact := constant.Int64Val(value)
+			if !exact {
+				return nil
+			}
+			args = append(args, number)
+		default:
+			return nil
+		}
+	}
+
+	result := fmt.Sprintf(constant.StringVal(format), args...)
+	if strings.Contains(result, "%!") { // wrong number or type of arguments
+		return nil
+	}
+	return constant.MakeString(result)
+}
+
 func getErrorCodeFromConstant(value constant.Value) (string, error) {
 	if value.Kind() != constant.String {
 		// Should not be reachable, because we already checked the signature of Code() to return a string.
 		// And the value is in the end one that gets returned by Code().
 		// So there should be a compiler error if value is not of type string.
-		return "", fmt.Errorf("error code has to be of type string")
-	}
-
-	result := value.String()
-	result, err := strconv.Unquote(result)
-	if err != nil {
-		return "", fmt.Errorf("problem unquoting string constant value: %v", err)
+		return "", newMessageError(MsgCodeNotString)
 	}
 
+	// StringVal instead of unquoting String(), which shortens long values, e.g. of concatenated constants.
+	result := constant.StringVal(value)
 	if result != "" {
 		if err := checkErrorCodeValid(result); err != nil {
-			return "", fmt.Errorf("error code has invalid format: %v", err)
+			return "", newMessageError(MsgCodeInvalidFormat, err)
 		}
 	}
 
@@ -182,19 +359,18 @@ func getErrorCodeFromConstant(value constant.Value) (string, error) {
 
 // checkIfExprIsErrorCodeParam checks if the given function is an error constructor and
 // the error code parameter is at the given position in the function definition.
+// The parameter has to be used with the prefix declared for it.
 //
 // If none of these are the case, diagnostics are emitted.
 func checkIfExprIsErrorCodeParam(pass *analysis.Pass, function *funcDefinition, param *funcCodeParam) {
-	ok := func() bool {
-		var fact ErrorConstructor
-		if !importErrorConstructorFact(pass, function, &fact) {
-			return false
-		}
-		return param.position == fact.CodeParamPosition
-	}()
+	var fact ErrorConstructor
+	if !importErrorConstructorFact(pass, function, &fact) || param.position != fact.CodeParamPosition {
+		report(pass, param.ident, MsgCodeParamRequired, param.ident.Name)
+		return
+	}
 
-	if !ok {
-		pass.ReportRangef(param.ident, "require an error code parameter declaration to use %q as an error code", param.ident.Name)
+	if param.prefix != fact.Prefix {
+		report(pass, param.ident, MsgCodeParamPrefixMismatch, param.ident.Name, param.prefix, fact.Prefix)
 	}
 }
 
@@ -216,18 +392,18 @@ func ectractErrorCodesFromConstructor(c *context, function *funcDefinition) Code
 
 	paramIdent := getParamIdent(function.Type(), fact.CodeParamPosition)
 	if paramIdent == nil {
-		logf("%v invalid fact: %v\n", pass.Fset.Position(function.node().Pos()), fact)
-		panic("should be unreachable: error constructor fact points to parameter that does not exist")
+		reportInternalError(pass, function.node(), "error constructor fact points to parameter %d, which does not exist", fact.CodeParamPosition)
+		return result
 	}
 
 	taintResult := taintSpreadForParamIdentOfImmutableType(pass, paramIdent, function)
 
 	for _, badIdent := range taintResult.identOutOfScope {
-		pass.ReportRangef(badIdent, "error code parameter may not be assigned an other parameter, receiver or global variable")
+		report(pass, badIdent, MsgCodeParamOutOfScope)
 	}
 
 	for _, destruct := range taintResult.destructAssignment {
-		pass.ReportRangef(destruct.source, "unsupported: assigning result of function call to error code parameter %q is not allowed", destruct.target.Name)
+		report(pass, destruct.source, MsgCodeParamAssignedCallRes, destruct.target.Name)
 	}
 
 	for _, expr := range taintResult.expressions {
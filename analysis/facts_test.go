@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestFactString(t *testing.T) {
+	tests := []struct {
+		fact     analysis.Fact
+		expected string
+	}{
+		{&ErrorCodes{Set("b-error", "a-error")}, "ErrorCodes: a-error b-error"},
+		{&ErrorCodes{Set()}, "ErrorCodes: "},
+		{&ErrorConstructor{CodeParamPosition: 2}, "ErrorConstructor: {CodeParamPosition:2}"},
+		{&ErrorConstructor{CodeParamPosition: 0, Prefix: "storage-"}, `ErrorConstructor: {CodeParamPosition:0, Prefix:"storage-"}`},
+		{&ErrorUnion{ParamPosition: 1}, "ErrorUnion: {ParamPosition:1}"},
+		{&ErrorPassthrough{ParamPositions: []int{0, 2}}, "ErrorPassthrough: {ParamPositions:[0 2]}"},
+		{&ErrorCodeSetter{CodeParamPosition: 0}, "ErrorCodeSetter: {CodeParamPosition:0}"},
+		{&ErrorCodeOption{CodeParamPosition: 1}, "ErrorCodeOption: {CodeParamPosition:1}"},
+		{&ErrorGroup{SubmitMethods: []string{"Go", "TryGo"}, WaitMethod: "Wait"}, "ErrorGroup: {Submit:[Go TryGo], Wait:Wait}"},
+		{&ErrorFuncAdapter{Methods: []string{"Handle", "ServeHTTP"}}, "ErrorFuncAdapter: Handle ServeHTTP"},
+	}
+
+	for _, test := range tests {
+		if actual := test.fact.(interface{ String() string }).String(); actual != test.expected {
+			t.Errorf("String() of %#v should return %q, but returned %q", test.fact, test.expected, actual)
+		}
+	}
+}
+
+// TestFactEncoding checks that facts survive the gob encoding, which is used to pass facts between packages.
+func TestFactEncoding(t *testing.T) {
+	for _, fact := range []analysis.Fact{
+		&ErrorCodes{Set("a-error", "b-error")},
+		&ErrorConstructor{CodeParamPosition: 1},
+		&ErrorConstructor{CodeParamPosition: 0, Prefix: "storage-"},
+		&ErrorUnion{ParamPosition: 2},
+		&ErrorPassthrough{ParamPositions: []int{1}},
+		&ErrorCodeSetter{CodeParamPosition: 1},
+		&ErrorCodeOption{CodeParamPosition: 2},
+		&ErrorGroup{SubmitMethods: []string{"Submit"}, WaitMethod: "Wait"},
+		&ErrorFuncAdapter{Methods: []string{"Handle"}},
+		&ErrorType{Codes: []string{"a-error"}, Field: &ErrorCodeField{"TheCode", 1}},
+	} {
+		var buffer bytes.Buffer
+		if err := gob.NewEncoder(&buffer).Encode(fact); err != nil {
+			t.Fatalf("encoding %#v failed: %v", fact, err)
+		}
+
+		decoded := reflect.New(reflect.TypeOf(fact).Elem()).Interface()
+		if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+			t.Fatalf("decoding %#v failed: %v", fact, err)
+		}
+		if !reflect.DeepEqual(fact, decoded) {
+			t.Errorf("decoded fact %#v should be equal to %#v", decoded, fact)
+		}
+	}
+}
@@ -1,7 +1,8 @@
 package analysis
 
 import (
-	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -25,8 +26,19 @@ type state interface {
 //       and we'll consider it an error if the rest of the pattern doesn't follow.
 //     - the capture group can be stripped for whitespace again. (perhaps the author wanted to align things.)
 //     - the error code has to be valid, which means it has to match against: "^[a-zA-Z][a-zA-Z0-9\-]*[a-zA-Z0-9]$" or "^[a-zA-Z]$"
+//     - instead of an error code, a wildcard like "storage-*" may be declared, which covers all codes with the given prefix.
+//     - the comment after "--" may start with "details:" and the comma separated keys of the details of errors with the code,
+//       e.g. "- storage-not-found -- details: id, kind; if the key does not exist".
+//     - codes prefixed with "!" are negative claims: the function promises to never return them.
+//       a code may not be declared as returned and as never returned at the same time, also not by a negative wildcard.
+//       a block containing only negative claims declares that no codes are returned at all.
 //   - for error constructors lines like "^- param: (.*) --" are allowed.
 //     - the captured group has to be a parameter of type string
+//     - the parameter may be followed by a prefix, which the constructor puts in front of the parameter,
+//       e.g. "- param: code (prefix "storage-") --" for a constructor creating `&Error{"storage-" + code}`.
+//   - lines like "^- param-passthrough: (.*) --" declare an error parameter, which the function may return.
+//     - the codes of the error passed to the parameter are added to the codes of each call.
+//     - multiple parameters may be declared by repeating the line.
 //   - this may repeat. if lines do not start that that pattern, they are skipped.
 //      - note that the same code may appear multiple times. this is acceptable, and should be deduplicated.
 //   - when there's another fully blank line, the parse is ended.
@@ -39,31 +51,54 @@ type state interface {
 // If there are no error declarations, (nil, nil) is returned.
 // If there's what looks like an error declaration, but funny looking, an error is returned.
 type findErrorDocsSM struct {
-	seen      CodeSet
-	state     state
-	noCodesOk bool
-	param     string
+	seen        CodeSet
+	excluded    CodeSet
+	state       state
+	noCodesOk   bool
+	param       string
+	prefix      string   // prefix of the error code parameter, or empty
+	passthrough []string // names of the error parameters declared with "param-passthrough:"
 }
 
+// negativeClaimPrefix marks a declared error code as never returned, e.g. `- !context-canceled -- handled internally`.
+const negativeClaimPrefix = "!"
+
 // run runs the state machine to find error codes in the provided doc string.
 //
 // The method returns a set of found codes,
 // a bool which is true if the function declared "Errors: none",
 // an error in case of invalid doc strings or nil otherwise.
-func (sm findErrorDocsSM) run(doc string) (CodeSet, string, bool, error) {
+// Codes declared by negative claims are kept in the excluded set of the state machine.
+func (sm *findErrorDocsSM) run(doc string) (CodeSet, string, bool, error) {
 	sm.seen = CodeSet{}
+	sm.excluded = CodeSet{}
 	sm.state = stateInit{}
 	sm.noCodesOk = false
 	sm.param = ""
+	sm.prefix = ""
+	sm.passthrough = nil
 
 	for _, line := range strings.Split(doc, "\n") {
 		line := strings.TrimSpace(line)
-		err := sm.state.step(&sm, line)
+		err := sm.state.step(sm, line)
 		if err != nil {
 			return nil, "", false, err
 		}
 	}
 
+	excluded := sm.excluded.Slice()
+	sort.Strings(excluded)
+	for _, code := range excluded {
+		for seen := range sm.seen {
+			if codeMatchesClaim(seen, code) {
+				return nil, "", false, newMessageError(MsgDocContradictingCode, code)
+			}
+		}
+	}
+	if len(sm.seen) == 0 && len(sm.excluded) > 0 && sm.param == "" && len(sm.passthrough) == 0 {
+		sm.noCodesOk = true
+	}
+
 	return sm.seen, sm.param, sm.noCodesOk, nil
 }
 
@@ -89,7 +124,7 @@ func (stateNeedBlankLine) step(sm *findErrorDocsSM, line string) error {
 		sm.state = stateParsing{}
 		return nil
 	} else {
-		return fmt.Errorf("need a blank line after the 'Errors:' block indicator")
+		return newMessageError(MsgDocNeedBlankLine)
 	}
 }
 
@@ -98,20 +133,34 @@ func (stateParsing) step(sm *findErrorDocsSM, line string) error {
 	case line == "":
 		sm.state = stateDone{}
 	case strings.HasPrefix(line, "Errors:"):
-		return fmt.Errorf("repeated 'Errors:' block indicator")
+		return newMessageError(MsgDocRepeatedBlock)
 	case strings.HasPrefix(line, "- "):
 		end := strings.Index(line, " --")
 		if end == -1 {
-			return fmt.Errorf("mid block, a line leading with '- ' didnt contain a '--' to mark the end of the code name")
+			return newMessageError(MsgDocMissingDashes)
 		}
 
 		if end < 2 {
-			return fmt.Errorf("an error code can't be purely whitespace")
+			return newMessageError(MsgDocWhitespaceCode)
 		}
 		code := line[2:end]
 		code = strings.TrimSpace(code)
 		if code == "" {
-			return fmt.Errorf("an error code can't be purely whitespace")
+			return newMessageError(MsgDocWhitespaceCode)
+		}
+
+		if strings.HasPrefix(code, "param-passthrough:") {
+			param := strings.TrimSpace(code[len("param-passthrough:"):])
+			if param == "" {
+				return newMessageError(MsgDocEmptyPassthrough)
+			}
+			for _, existing := range sm.passthrough {
+				if existing == param {
+					return nil
+				}
+			}
+			sm.passthrough = append(sm.passthrough, param)
+			return nil
 		}
 
 		if strings.HasPrefix(code, "param:") {
@@ -119,17 +168,36 @@ func (stateParsing) step(sm *findErrorDocsSM, line string) error {
 			param = strings.TrimSpace(param)
 			switch {
 			case param == "":
-				return fmt.Errorf("an error code parameter can't be purely whitespace")
+				return newMessageError(MsgDocWhitespaceParam)
 			case sm.param != "":
-				return fmt.Errorf("cannot define more than one error code parameter (found multiple 'param:' inidicators)")
-			default:
-				sm.param = param
-				return nil
+				return newMessageError(MsgDocMultipleParams)
 			}
+
+			name, prefix, err := parseCodeParamPrefix(param)
+			if err != nil {
+				return err
+			}
+			sm.param = name
+			sm.prefix = prefix
+			return nil
+		}
+
+		isExcluded := strings.HasPrefix(code, negativeClaimPrefix)
+		if isExcluded {
+			code = strings.TrimSpace(code[len(negativeClaimPrefix):])
 		}
 
-		if err := checkErrorCodeValid(code); err != nil {
-			return fmt.Errorf("declared error code has invalid format: %v", err)
+		if err := checkDeclaredCodeValid(code); err != nil {
+			return newMessageError(MsgDocInvalidCode, err)
+		}
+
+		if isExcluded {
+			sm.excluded.Add(code)
+			return nil
+		}
+
+		if _, _, err := parseDetailKeys(line[end+len(" --"):]); err != nil {
+			return err
 		}
 
 		if _, exists := sm.seen[code]; !exists {
@@ -141,7 +209,37 @@ func (stateParsing) step(sm *findErrorDocsSM, line string) error {
 
 func (stateDone) step(sm *findErrorDocsSM, line string) error {
 	if strings.HasPrefix(line, "Errors:") {
-		return fmt.Errorf("repeated 'Errors:' block indicator")
+		return newMessageError(MsgDocRepeatedBlock)
 	}
 	return nil
 }
+
+// parseCodeParamPrefix splits the declaration of an error code parameter into the name of the parameter and its prefix,
+// e.g. `code (prefix "storage-")` into "code" and "storage-".
+// Without a prefix, the prefix is empty.
+func parseCodeParamPrefix(param string) (string, string, error) {
+	start := strings.Index(param, "(")
+	if start == -1 {
+		return param, "", nil
+	}
+
+	name := strings.TrimSpace(param[:start])
+	option := strings.TrimSpace(param[start:])
+	if name == "" {
+		return "", "", newMessageError(MsgDocWhitespaceParam)
+	}
+	if !strings.HasSuffix(option, ")") || !strings.HasPrefix(option[1:], "prefix ") {
+		return "", "", newMessageError(MsgDocInvalidParamOption, option)
+	}
+
+	prefix, err := strconv.Unquote(strings.TrimSpace(option[len("(prefix ") : len(option)-1]))
+	if err != nil {
+		return "", "", newMessageError(MsgDocInvalidParamOption, option)
+	}
+
+	// The prefix has to be the start of valid error codes.
+	if prefix == "" || !isErrorCodeValid(prefix+"a") {
+		return "", "", newMessageError(MsgDocInvalidParamPrefix, prefix)
+	}
+	return name, prefix, nil
+}
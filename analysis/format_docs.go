@@ -0,0 +1,241 @@
+package analysis
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	// errorDocEntryIndent is the indentation of entries in a canonical "Errors:" block, relative to the comment marker.
+	// It is the indentation gofmt uses for lists in doc comments since Go 1.19, so formatted blocks are kept by gofmt.
+	errorDocEntryIndent = "   "
+
+	// errorDocEntryIndentLegacy is the indentation of entries used before gofmt formatted lists in doc comments.
+	// Blocks whose entries all use it are canonical as well, so they are not reported when analysing with older versions of Go.
+	errorDocEntryIndentLegacy = "    "
+)
+
+type (
+	// errorDocLine is a single line of a doc comment, split into its parts.
+	errorDocLine struct {
+		indent  string // whitespace in front of the comment marker
+		marker  string // the comment marker "//", or empty if the line has none
+		content string // the rest of the line
+	}
+
+	// errorDocEntry is a single "- code -- comment" entry of an "Errors:" block,
+	// together with any further lines that belong to it.
+	errorDocEntry struct {
+		line       errorDocLine
+		code       string
+		comment    string
+		isParam    bool
+		isExcluded bool
+		rest       []errorDocLine
+	}
+)
+
+// FormatErrorDocs validates the "Errors:" block in the given doc comment and rewrites it into canonical form.
+// The doc comment can be given with or without the leading "//" of each line.
+//
+// In canonical form, every entry of the block is indented by three spaces after the comment marker, like gofmt indents lists,
+// and the "--" separators of all entries are aligned:
+//
+//	// Errors:
+//	//
+//	//   - some-error    -- if something happens.
+//	//   - another-error --
+//
+// Blocks whose entries are all indented by four spaces keep their indentation.
+//
+// If sortCodes is true, the entries are also sorted by error code, with "param:" and "param-passthrough:" entries first and negative claims last.
+// Lines of the doc comment outside of the block, and lines in the block that are not entries, are kept unchanged.
+// A doc comment without an "Errors:" block is returned as is.
+//
+// If the doc comment is not valid, an error describing the problem is returned.
+func FormatErrorDocs(doc string, sortCodes bool) (string, error) {
+	if _, _, _, err := (&findErrorDocsSM{}).run(stripCommentMarkers(doc)); err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(doc, "\n")
+	start, end := findErrorDocsBlock(lines)
+	if start == -1 {
+		return doc, nil
+	}
+
+	prefix, entries := parseErrorDocEntries(lines[start:end])
+
+	if sortCodes {
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].isParam != entries[j].isParam {
+				return entries[i].isParam
+			}
+			if entries[i].isExcluded != entries[j].isExcluded {
+				return entries[j].isExcluded
+			}
+			return entries[i].code < entries[j].code
+		})
+	}
+
+	width := 0
+	indent := errorDocEntryIndentLegacy
+	for _, entry := range entries {
+		if len(entry.code) > width {
+			width = len(entry.code)
+		}
+		if !strings.HasPrefix(entry.line.content, errorDocEntryIndentLegacy+"- ") {
+			indent = errorDocEntryIndent
+		}
+	}
+
+	result := make([]string, 0, len(lines))
+	result = append(result, lines[:start]...)
+	for _, line := range prefix {
+		result = append(result, line.String())
+	}
+	for _, entry := range entries {
+		line := entry.line
+		line.content = indent + "- " + entry.code + strings.Repeat(" ", width-len(entry.code)) + " --"
+		if entry.comment != "" {
+			line.content += " " + entry.comment
+		}
+		result = append(result, line.String())
+		for _, line := range entry.rest {
+			result = append(result, line.String())
+		}
+	}
+	result = append(result, lines[end:]...)
+
+	return strings.Join(result, "\n"), nil
+}
+
+// parseErrorDocEntries splits the lines of a validated "Errors:" block, as found by findErrorDocsBlock, into its entries.
+// Lines in front of the first entry are returned as prefix.
+func parseErrorDocEntries(lines []string) (prefix []errorDocLine, entries []*errorDocEntry) {
+	for _, raw := range lines {
+		line := splitErrorDocLine(raw)
+		trimmed := strings.TrimSpace(line.content)
+		if !strings.HasPrefix(trimmed, "- ") {
+			if len(entries) == 0 {
+				prefix = append(prefix, line)
+			} else {
+				last := entries[len(entries)-1]
+				last.rest = append(last.rest, line)
+			}
+			continue
+		}
+
+		// The separator is known to exist, as the doc comment was validated already.
+		separator := strings.Index(trimmed, " --")
+		entry := &errorDocEntry{
+			line:    line,
+			code:    strings.TrimSpace(trimmed[2:separator]),
+			comment: strings.TrimSpace(trimmed[separator+len(" --"):]),
+		}
+		if strings.HasPrefix(entry.code, "param:") {
+			entry.code = "param: " + strings.TrimSpace(entry.code[len("param:"):])
+			entry.isParam = true
+		}
+		if strings.HasPrefix(entry.code, "param-passthrough:") {
+			entry.code = "param-passthrough: " + strings.TrimSpace(entry.code[len("param-passthrough:"):])
+			entry.isParam = true
+		}
+		if strings.HasPrefix(entry.code, negativeClaimPrefix) {
+			entry.code = negativeClaimPrefix + strings.TrimSpace(entry.code[len(negativeClaimPrefix):])
+			entry.isExcluded = true
+		}
+		entries = append(entries, entry)
+	}
+	return prefix, entries
+}
+
+// findNonCanonicalErrorDocs reports all doc comments in the package with an "Errors:" block that is not in canonical form.
+// Each diagnostic comes with a suggested fix that formats the block using FormatErrorDocs.
+//
+// Doc comments with invalid "Errors:" blocks are skipped, they are reported where the docs are used.
+func findNonCanonicalErrorDocs(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			checkErrorDocsCanonical(pass, group)
+		}
+	}
+}
+
+func checkErrorDocsCanonical(pass *analysis.Pass, group *ast.CommentGroup) {
+	lines := make([]string, len(group.List))
+	for i, comment := range group.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			return // Only line comments are supported.
+		}
+		lines[i] = comment.Text
+	}
+
+	formatted, err := FormatErrorDocs(strings.Join(lines, "\n"), cliArguments.sortCodes)
+	if err != nil {
+		return
+	}
+
+	// Formatting keeps the number of lines, so each line can be replaced on its own.
+	var edits []analysis.TextEdit
+	for i, line := range strings.Split(formatted, "\n") {
+		if line != lines[i] {
+			comment := group.List[i]
+			edits = append(edits, analysis.TextEdit{Pos: comment.Pos(), End: comment.End(), NewText: []byte(line)})
+		}
+	}
+	if len(edits) == 0 {
+		return
+	}
+
+	fix := analysis.SuggestedFix{Message: "Format 'Errors:' block", TextEdits: edits}
+	reportWithFix(pass, group, fix, MsgDocNotCanonical)
+}
+
+// findErrorDocsBlock returns the range of lines which contain the entries of the "Errors:" block.
+// If there is no such block, start is -1.
+func findErrorDocsBlock(lines []string) (start, end int) {
+	for i, raw := range lines {
+		if strings.TrimSpace(splitErrorDocLine(raw).content) != "Errors:" {
+			continue
+		}
+
+		// Skip the blank line following "Errors:", which is known to exist, as the doc comment was validated already.
+		start = i + 2
+		if start > len(lines) {
+			start = len(lines)
+		}
+		for end = start; end < len(lines); end++ {
+			if strings.TrimSpace(splitErrorDocLine(lines[end]).content) == "" {
+				break
+			}
+		}
+		return start, end
+	}
+	return -1, -1
+}
+
+// stripCommentMarkers removes the leading "//" from each line of the given doc comment.
+func stripCommentMarkers(doc string) string {
+	lines := strings.Split(doc, "\n")
+	for i, raw := range lines {
+		lines[i] = splitErrorDocLine(raw).content
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitErrorDocLine(raw string) errorDocLine {
+	content := strings.TrimLeft(raw, " \t")
+	indent := raw[:len(raw)-len(content)]
+	if strings.HasPrefix(content, "//") {
+		return errorDocLine{indent, "//", content[len("//"):]}
+	}
+	return errorDocLine{"", "", raw}
+}
+
+func (l errorDocLine) String() string {
+	return l.indent + l.marker + l.content
+}
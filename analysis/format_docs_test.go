@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestFormatErrorDocs(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       string
+		sortCodes bool
+		expected  string
+	}{
+		{
+			name:     "no block",
+			doc:      "// Some function.\n",
+			expected: "// Some function.\n",
+		},
+		{
+			name:     "errors none",
+			doc:      "// Some function.\n//\n// Errors: none -- never fails.\n",
+			expected: "// Some function.\n//\n// Errors: none -- never fails.\n",
+		},
+		{
+			name:     "canonical",
+			doc:      "// Errors:\n//\n//   - some-error    -- if something happens.\n//   - another-error --\n",
+			expected: "// Errors:\n//\n//   - some-error    -- if something happens.\n//   - another-error --\n",
+		},
+		{
+			name:     "legacy indentation",
+			doc:      "// Errors:\n//\n//    - some-error    -- if something happens.\n//    - another-error --\n",
+			expected: "// Errors:\n//\n//    - some-error    -- if something happens.\n//    - another-error --\n",
+		},
+		{
+			name:     "mixed indentation",
+			doc:      "// Errors:\n//\n//    - some-error    -- if something happens.\n//   - another-error --\n",
+			expected: "// Errors:\n//\n//   - some-error    -- if something happens.\n//   - another-error --\n",
+		},
+		{
+			name:     "indentation and alignment",
+			doc:      "// Errors:\n//\n// - some-error -- if something happens.\n//\t-   another-error  --\n\t//  - a --  a comment\n",
+			expected: "// Errors:\n//\n//   - some-error    -- if something happens.\n//   - another-error --\n\t//   - a             -- a comment\n",
+		},
+		{
+			name:     "without comment markers",
+			doc:      "Errors:\n\n- some-error -- if something happens.\n  - another-error --\n\nMore text.",
+			expected: "Errors:\n\n   - some-error    -- if something happens.\n   - another-error --\n\nMore text.",
+		},
+		{
+			name:      "sorted",
+			doc:       "// Errors:\n//\n//    - some-error -- if something happens.\n//      which is rare.\n//    - param:code -- the code.\n//    - another-error --\n",
+			sortCodes: true,
+			expected:  "// Errors:\n//\n//    - param: code   -- the code.\n//    - another-error --\n//    - some-error    -- if something happens.\n//      which is rare.\n",
+		},
+		{
+			name:      "negative claims",
+			doc:       "// Errors:\n//\n//    - ! canceled -- handled internally.\n//    - some-error --\n",
+			sortCodes: true,
+			expected:  "// Errors:\n//\n//    - some-error --\n//    - !canceled  -- handled internally.\n",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := FormatErrorDocs(test.doc, test.sortCodes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestFormatErrorDocsInvalid(t *testing.T) {
+	tests := []struct {
+		doc      string
+		expected string
+	}{
+		{"// Errors:\n//    - some-error --\n", FormatMessage(MsgDocNeedBlankLine)},
+		{"// Errors:\n//\n//    - some-error\n", FormatMessage(MsgDocMissingDashes)},
+		{"// Errors:\n//\n//    - some_error --\n", ""},
+		{"// Errors:\n//\n//    - some-error --\n//    - !some-error --\n", FormatMessage(MsgDocContradictingCode, "some-error")},
+	}
+
+	for _, test := range tests {
+		_, err := FormatErrorDocs(test.doc, false)
+		if err == nil {
+			t.Errorf("%q: expected an error", test.doc)
+			continue
+		}
+		if test.expected != "" && err.Error() != test.expected {
+			t.Errorf("%q: expected error %q got %q", test.doc, test.expected, err.Error())
+		}
+	}
+}
+
+func TestFormatDocsAnalyzer(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	Analyzer.Flags.Set("format", "true")
+	defer Analyzer.Flags.Set("format", "false")
+
+	dir := analysistest.TestData()
+	for _, testcase := range []struct {
+		pattern   string
+		sortCodes bool
+		expected  []string
+	}{
+		{
+			pattern: "format_docs",
+			expected: []string{
+				`format_docs/format_docs.go:14:1: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+				`format_docs/format_docs.go:29:1: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+				`format_docs/format_docs.go:42:2: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+			},
+		},
+		{
+			pattern:   "format_docs_sorted",
+			sortCodes: true,
+			expected: []string{
+				`format_docs_sorted/format_docs.go:3:1: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+				`format_docs_sorted/format_docs.go:14:1: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+				`format_docs_sorted/format_docs.go:29:1: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+				`format_docs_sorted/format_docs.go:42:2: unexpected diagnostic: 'Errors:' block is not in canonical form`,
+			},
+		},
+	} {
+		t.Run(testcase.pattern, func(t *testing.T) {
+			testcase := testcase
+			Analyzer.Flags.Set("sort-codes", fmt.Sprint(testcase.sortCodes))
+			defer Analyzer.Flags.Set("sort-codes", "false")
+
+			c := &collector{data: map[string]struct{}{}}
+			analysistest.RunWithSuggestedFixes(c, dir, Analyzer, testcase.pattern)
+			c.assert(t, testcase.expected...)
+		})
+	}
+}
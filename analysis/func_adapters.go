@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// ErrorFuncAdapter is a fact that is used to tag named function types with methods returning the result of calling the function itself.
+// Such types adapt ordinary functions to interfaces, like http.HandlerFunc does.
+//
+// For example "type HandlerFunc func() error" with the method "func (f HandlerFunc) Handle() error { return f() }"
+// gets an ErrorFuncAdapter{Methods: []string{"Handle"}} fact.
+//
+// The error codes of adapter methods are those of the adapted function, so they are checked where a function
+// converted to the adapter type is used as an interface, e.g. `var h Handler = HandlerFunc(handle)`.
+type ErrorFuncAdapter struct {
+	Methods []string // names of the methods returning the result of calling the function
+}
+
+func (*ErrorFuncAdapter) AFact() {}
+
+func (e *ErrorFuncAdapter) String() string {
+	return fmt.Sprintf("ErrorFuncAdapter: %v", strings.Join(e.Methods, " "))
+}
+
+// exportFuncAdapterFacts exports ErrorFuncAdapter facts for the named function types of the package, which have adapter methods.
+//
+// Function types declaring a contract are no adapters, as calling the function returns the error codes of the contract.
+func exportFuncAdapterFacts(pass *analysis.Pass, lookup *funcLookup) {
+	adapters := map[*types.TypeName][]string{}
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		if typeName, ok := findFuncAdapterType(pass, funcDecl); ok {
+			adapters[typeName] = append(adapters[typeName], funcDecl.Name.Name)
+		}
+	})
+
+	for typeName, methods := range adapters {
+		sort.Strings(methods)
+		pass.ExportObjectFact(typeName, &ErrorFuncAdapter{methods})
+	}
+}
+
+// findFuncAdapterType checks if the given method is an adapter method and returns the function type declaring it.
+//
+// Adapter methods do not declare error codes, return an error and only consist of a return statement calling the receiver,
+// e.g. `return f(w, r)`.
+func findFuncAdapterType(pass *analysis.Pass, funcDecl *ast.FuncDecl) (*types.TypeName, bool) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List[0].Names) != 1 || funcDecl.Body == nil || len(funcDecl.Body.List) != 1 ||
+		hasErrorDocs(pass, funcDecl) || !checkFunctionReturnsError(pass, funcDecl.Type) {
+		return nil, false
+	}
+
+	receiver := pass.TypesInfo.Defs[funcDecl.Recv.List[0].Names[0]]
+	if receiver == nil {
+		return nil, false
+	}
+	named, ok := receiver.Type().(*types.Named)
+	if !ok || pass.ImportObjectFact(named.Obj(), new(ErrorCodes)) {
+		return nil, false
+	}
+	if _, ok := named.Underlying().(*types.Signature); !ok {
+		return nil, false
+	}
+
+	returnStmt, ok := funcDecl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(returnStmt.Results) != 1 {
+		return nil, false
+	}
+	callExpr, ok := astutil.Unparen(returnStmt.Results[0]).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	fun, ok := astutil.Unparen(callExpr.Fun).(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[fun] != receiver {
+		return nil, false
+	}
+	return named.Obj(), true
+}
+
+// isFuncAdapterMethod checks if the given method is an adapter method of a function type with an ErrorFuncAdapter fact.
+func isFuncAdapterMethod(pass *analysis.Pass, method types.Object) bool {
+	fn, ok := method.(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	named := getNamedType(recv.Type())
+	if named == nil {
+		return false
+	}
+
+	var fact ErrorFuncAdapter
+	if !pass.ImportObjectFact(named.Obj(), &fact) {
+		return false
+	}
+	for _, name := range fact.Methods {
+		if name == fn.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfFuncAdapterIsValidForInterface checks the function converted to a function adapter type, which is used as the given interface,
+// e.g. `handle` in `var h Handler = HandlerFunc(handle)`.
+//
+// The error codes of the function have to be a subset of the error codes of all interface methods implemented by adapter methods.
+func checkIfFuncAdapterIsValidForInterface(c *context, errorInterface *ErrorInterface, interfaceType types.Type, exprType types.Type, expression ast.Expr) {
+	pass := c.pass
+
+	named, ok := exprType.(*types.Named)
+	if !ok {
+		return
+	}
+	var fact ErrorFuncAdapter
+	if !pass.ImportObjectFact(named.Obj(), &fact) {
+		return
+	}
+
+	var adaptedCodes CodeSet
+	adaptedCodesFound := false
+	for _, methodName := range fact.Methods {
+		interfaceCodes, ok := errorInterface.ErrorMethods[methodName]
+		if !ok {
+			continue
+		}
+
+		if !adaptedCodesFound {
+			if adaptedCodes, ok = findErrorCodesOfCallback(c, named.Obj().Name(), expression); !ok {
+				return
+			}
+			adaptedCodesFound = true
+		}
+
+		unexpectedCodes := findUncoveredCodes(adaptedCodes, interfaceCodes).Slice()
+		if len(unexpectedCodes) > 0 {
+			sort.Strings(unexpectedCodes)
+			report(pass, expression, MsgInterfaceCodesNotSubset, getNamedType(interfaceType).Obj().Name(), methodName, unexpectedCodes)
+		}
+	}
+}
@@ -0,0 +1,590 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// exportFuncTypeContractFacts finds all named function types that declare error codes in their docstring
+// and exports the declared codes as ErrorCodes fact of the type.
+//
+// The declared codes are a contract for all functions of that type,
+// e.g. `type WalkFunc func(path string) error` may declare the codes a callback is allowed to return.
+func exportFuncTypeContractFacts(pass *analysis.Pass) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// We only need to see type declarations.
+	nodeFilter := []ast.Node{
+		(*ast.GenDecl)(nil),
+	}
+
+	inspect.Nodes(nodeFilter, func(node ast.Node, _ bool) bool {
+		genDecl := node.(*ast.GenDecl)
+
+		for _, spec := range genDecl.Specs {
+			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				exportFuncVariableContractFacts(pass, genDecl, valueSpec)
+				continue
+			}
+
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				exportFuncFieldContractFacts(pass, structType)
+				exportErrorFieldFacts(pass, structType)
+				continue
+			}
+
+			funcType, ok := typeSpec.Type.(*ast.FuncType)
+			if !ok || !checkFunctionReturnsError(pass, funcType) {
+				continue
+			}
+
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+
+			codes, ok, err := findFuncContractDocs(pass, doc)
+			if err != nil {
+				reportError(pass, typeSpec.Name, err)
+			} else if ok {
+				pass.ExportObjectFact(pass.TypesInfo.Defs[typeSpec.Name], &ErrorCodes{codes})
+			}
+		}
+
+		// Never recurse deeper.
+		return false
+	})
+}
+
+// exportFuncFieldContractFacts exports the error codes declared in the docstring of function-typed fields of the given struct
+// as ErrorCodes fact of the fields, e.g. for `handler func() error` with a docstring declaring the codes the handler may return.
+func exportFuncFieldContractFacts(pass *analysis.Pass, structType *ast.StructType) {
+	for _, field := range structType.Fields.List {
+		// Hooks are often grouped in anonymous structs, so their fields are checked as well.
+		if nestedStruct, ok := field.Type.(*ast.StructType); ok {
+			exportFuncFieldContractFacts(pass, nestedStruct)
+			continue
+		}
+
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || !checkFunctionReturnsError(pass, funcType) {
+			continue
+		}
+
+		codes, ok, err := findFuncContractDocs(pass, field.Doc)
+		if err != nil {
+			reportError(pass, field, err)
+			continue
+		} else if !ok {
+			continue
+		}
+
+		for _, name := range field.Names {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[name], &ErrorCodes{codes})
+		}
+	}
+}
+
+// exportFuncVariableContractFacts exports the error codes declared in the docstring of package-level function variables
+// as ErrorCodes fact of the variables, e.g. for `var Hook func() error = defaultHook`.
+//
+// Calls of the variable return the declared codes, and every function assigned to it has to fulfill the contract.
+func exportFuncVariableContractFacts(pass *analysis.Pass, genDecl *ast.GenDecl, valueSpec *ast.ValueSpec) {
+	var variables []*types.Var
+	for _, name := range valueSpec.Names {
+		variable, ok := pass.TypesInfo.Defs[name].(*types.Var)
+		if ok && variable.Parent() == pass.Pkg.Scope() && isErrorReturningFuncType(variable.Type()) {
+			variables = append(variables, variable)
+		}
+	}
+	if len(variables) == 0 {
+		return
+	}
+
+	doc := valueSpec.Doc
+	if doc == nil && len(genDecl.Specs) == 1 {
+		doc = genDecl.Doc
+	}
+
+	codes, ok, err := findFuncContractDocs(pass, doc)
+	if err != nil {
+		reportError(pass, valueSpec.Names[0], err)
+		return
+	} else if !ok {
+		return
+	}
+
+	for _, variable := range variables {
+		pass.ExportObjectFact(variable, &ErrorCodes{codes})
+	}
+}
+
+// isErrorReturningFuncType checks if the given type is a function type with an error result.
+func isErrorReturningFuncType(typ types.Type) bool {
+	signature, ok := typ.Underlying().(*types.Signature)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < signature.Results().Len(); i++ {
+		if types.Implements(signature.Results().At(i).Type(), tError) {
+			return true
+		}
+	}
+	return false
+}
+
+// findFuncContractDocs finds the error codes declared as contract for a function type or function-typed parameter.
+//
+// The result is false if the given comments do not declare any error codes.
+func findFuncContractDocs(pass *analysis.Pass, comments *ast.CommentGroup) (CodeSet, bool, error) {
+	codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, comments)
+	if err != nil {
+		return nil, false, newMessageError(MsgContractOddDocstring, err)
+	}
+
+	// TODO: Implement support, then remove this check
+	if errorCodeParamName != "" {
+		return nil, false, newMessageError(MsgContractConstructor)
+	}
+
+	return codes, len(codes) > 0 || declaredNoCodesOk, nil
+}
+
+// findFuncParamContract finds the error codes declared as contract in the docstring of the given function-typed parameter.
+//
+// Parameter docs are placed in front of the parameter, which requires the parameter list to be split over multiple lines.
+func findFuncParamContract(c *context, field *ast.Field) (CodeSet, bool, error) {
+	if len(field.Names) == 0 {
+		return nil, false, nil
+	}
+
+	if _, ok := getUnderlyingType(c.pass.TypesInfo.TypeOf(field.Type)).(*types.Signature); !ok {
+		return nil, false, nil
+	}
+
+	for _, comments := range c.comments[field] {
+		if comments.End() > field.Pos() {
+			continue // Only comments in front of the parameter are docs.
+		}
+
+		codes, ok, err := findFuncContractDocs(c.pass, comments)
+		if err != nil || ok {
+			return codes, ok, err
+		}
+	}
+	return nil, false, nil
+}
+
+// checkFuncParamContracts reports odd docstrings of function-typed parameters of all functions in the current package.
+func checkFuncParamContracts(c *context) {
+	c.lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		for _, field := range funcDecl.Type.Params.List {
+			if _, _, err := findFuncParamContract(c, field); err != nil {
+				reportError(c.pass, field, err)
+			}
+		}
+	})
+}
+
+// findErrorCodesFromFuncContract finds the error codes declared as contract of the called function value,
+// either by its named function type or by the docstring of the function-typed parameter or package-level variable it refers to.
+func findErrorCodesFromFuncContract(c *context, calledFunction ast.Expr) (CodeSet, bool) {
+	pass := c.pass
+
+	if pass.TypesInfo.Types[calledFunction].IsType() {
+		return nil, false // Type conversions are no calls.
+	}
+
+	if _, codes, ok := importFuncVariableContract(pass, calledFunction); ok {
+		return codes, true
+	}
+
+	switch calledFunction := astutil.Unparen(calledFunction).(type) {
+	case *ast.Ident:
+		if calledFunction.Obj == nil {
+			break
+		}
+		if field, ok := calledFunction.Obj.Decl.(*ast.Field); ok {
+			if codes, ok, _ := findFuncParamContract(c, field); ok {
+				return codes, true
+			}
+		}
+	case *ast.SelectorExpr:
+		if codes, ok := importFuncFieldContract(pass, calledFunction); ok {
+			return codes, true
+		}
+	}
+
+	return importFuncTypeContract(pass, pass.TypesInfo.TypeOf(calledFunction))
+}
+
+// importFuncFieldContract imports the error codes declared as contract of the struct field selected by the given expression.
+func importFuncFieldContract(pass *analysis.Pass, selector *ast.SelectorExpr) (CodeSet, bool) {
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.FieldVal || !isErrorReturningFuncType(selection.Obj().Type()) {
+		return nil, false
+	}
+
+	var fact ErrorCodes
+	if !pass.ImportObjectFact(selection.Obj(), &fact) {
+		return nil, false
+	}
+	return fact.Codes, true
+}
+
+// importFuncVariableContract imports the error codes declared as contract of the package-level variable referenced by the given expression,
+// e.g. `Hook` or `pkg.Hook`, and returns the variable.
+func importFuncVariableContract(pass *analysis.Pass, expr ast.Expr) (*types.Var, CodeSet, bool) {
+	var ident *ast.Ident
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = expr
+	case *ast.SelectorExpr:
+		if _, ok := pass.TypesInfo.Selections[expr]; ok {
+			return nil, nil, false // Selection of a field or method, not a qualified identifier.
+		}
+		ident = expr.Sel
+	default:
+		return nil, nil, false
+	}
+
+	variable, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return nil, nil, false
+	}
+
+	var fact ErrorCodes
+	if !pass.ImportObjectFact(variable, &fact) {
+		return nil, nil, false
+	}
+	return variable, fact.Codes, true
+}
+
+// importFuncTypeContract imports the error codes declared as contract of the given named function type.
+func importFuncTypeContract(pass *analysis.Pass, typ types.Type) (CodeSet, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	var fact ErrorCodes
+	if !pass.ImportObjectFact(named.Obj(), &fact) {
+		return nil, false
+	}
+	return fact.Codes, true
+}
+
+// findCallbacksViolatingContracts checks all functions passed as arguments to function-typed parameters with a contract,
+// assigned to function-typed struct fields or package-level variables with a contract, or used in struct literals for such fields.
+//
+// The error codes of the passed function have to be a subset of the error codes declared by the contract.
+func findCallbacksViolatingContracts(c *context) {
+	pass := c.pass
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			findCallbacksViolatingParamContracts(c, node)
+		case *ast.AssignStmt:
+			findCallbacksViolatingFieldContractsInAssignStmt(c, node)
+		case *ast.CompositeLit:
+			findCallbacksViolatingFieldContractsInCompositeLit(c, node)
+		case *ast.ValueSpec:
+			findCallbacksViolatingVariableContractsInValueSpec(c, node)
+		}
+	})
+}
+
+// findCallbacksViolatingFieldContractsInAssignStmt checks functions assigned to struct fields or package-level variables with a contract.
+func findCallbacksViolatingFieldContractsInAssignStmt(c *context, statement *ast.AssignStmt) {
+	if len(statement.Lhs) != len(statement.Rhs) {
+		return
+	}
+
+	for i, lhsEntry := range statement.Lhs {
+		if variable, codes, ok := importFuncVariableContract(c.pass, lhsEntry); ok {
+			checkIfCallbackFulfillsContract(c, variable.Name(), codes, statement.Rhs[i])
+			continue
+		}
+
+		selector, ok := astutil.Unparen(lhsEntry).(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+
+		if codes, ok := importFuncFieldContract(c.pass, selector); ok {
+			checkIfCallbackFulfillsContract(c, selector.Sel.Name, codes, statement.Rhs[i])
+		}
+	}
+}
+
+// findCallbacksViolatingVariableContractsInValueSpec checks the initial values of package-level variables with a contract.
+func findCallbacksViolatingVariableContractsInValueSpec(c *context, spec *ast.ValueSpec) {
+	if len(spec.Names) != len(spec.Values) {
+		return
+	}
+
+	for i, name := range spec.Names {
+		var fact ErrorCodes
+		if variable, ok := c.pass.TypesInfo.Defs[name].(*types.Var); ok && c.pass.ImportObjectFact(variable, &fact) {
+			checkIfCallbackFulfillsContract(c, variable.Name(), fact.Codes, spec.Values[i])
+		}
+	}
+}
+
+// findCallbacksViolatingFieldContractsInCompositeLit checks functions used in struct literals for struct fields with a contract.
+func findCallbacksViolatingFieldContractsInCompositeLit(c *context, literal *ast.CompositeLit) {
+	pass := c.pass
+
+	structType, ok := getUnderlyingType(pass.TypesInfo.TypeOf(literal)).(*types.Struct)
+	if !ok {
+		return
+	}
+
+	for i, element := range literal.Elts {
+		var field *types.Var
+		if keyValue, ok := element.(*ast.KeyValueExpr); ok {
+			key, ok := keyValue.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			field, _ = pass.TypesInfo.ObjectOf(key).(*types.Var)
+			element = keyValue.Value
+		} else if i < structType.NumFields() {
+			field = structType.Field(i)
+		}
+
+		var fact ErrorCodes
+		if field != nil && isErrorReturningFuncType(field.Type()) && pass.ImportObjectFact(field, &fact) {
+			checkIfCallbackFulfillsContract(c, field.Name(), fact.Codes, element)
+		}
+	}
+}
+
+// findCallbacksViolatingParamContracts checks functions passed as arguments to function-typed parameters with a contract,
+// and functions converted to function types with a contract, e.g. `WalkFunc(walk)`.
+func findCallbacksViolatingParamContracts(c *context, callExpr *ast.CallExpr) {
+	pass := c.pass
+
+	if conversion := pass.TypesInfo.Types[callExpr.Fun]; conversion.IsType() {
+		if codes, ok := importFuncTypeContract(pass, conversion.Type); ok && len(callExpr.Args) == 1 {
+			checkIfCallbackFulfillsContract(c, getNamedType(conversion.Type).Obj().Name(), codes, callExpr.Args[0])
+		}
+		return
+	}
+
+	signature, ok := pass.TypesInfo.TypeOf(callExpr.Fun).(*types.Signature)
+	if !ok || signature.Variadic() || signature.Params().Len() != len(callExpr.Args) {
+		return // Skip type conversions, builtins, variadic calls and calls like f(g()).
+	}
+
+	var params []*ast.Field
+	if fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func); ok && fn.Pkg() == pass.Pkg {
+		if funcDecl := findFuncDeclForFunc(c, fn); funcDecl != nil {
+			params = funcDecl.Type.Params.List
+		}
+	}
+
+	// Method expressions take the receiver as first argument, which is not part of the declared parameters.
+	receiverArgs := 0
+	if isMethodExpression(pass, callExpr.Fun) {
+		receiverArgs = 1
+	}
+
+	for i, arg := range callExpr.Args {
+		param := signature.Params().At(i)
+
+		contractName := param.Name()
+		var codes CodeSet
+		ok := false
+		if i >= receiverArgs {
+			codes, ok = findParamContract(c, params, i-receiverArgs)
+		}
+		if !ok {
+			codes, ok = importFuncTypeContract(pass, param.Type())
+			if ok {
+				contractName = getNamedType(param.Type()).Obj().Name()
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		checkIfCallbackFulfillsContract(c, contractName, codes, arg)
+	}
+}
+
+// findParamContract finds the contract declared for the parameter at the given position in the given parameter list.
+func findParamContract(c *context, params []*ast.Field, position int) (CodeSet, bool) {
+	for _, field := range params {
+		if position < len(field.Names) {
+			codes, ok, _ := findFuncParamContract(c, field)
+			return codes, ok
+		}
+		position -= len(field.Names)
+	}
+	return nil, false
+}
+
+// checkIfCallbackFulfillsContract checks if the error codes of the given function expression are
+// a subset of the error codes declared in the contract.
+func checkIfCallbackFulfillsContract(c *context, contractName string, contractCodes CodeSet, callback ast.Expr) {
+	if foundCodes, ok := findErrorCodesOfCallback(c, contractName, callback); ok {
+		reportIfCallbackViolatesContract(c.pass, callback, contractName, contractCodes, foundCodes)
+	}
+}
+
+// findErrorCodesOfCallback finds the error codes of the given function expression, which is used as contractName.
+//
+// If the error codes can not be determined, a diagnostic is emitted and false is returned.
+func findErrorCodesOfCallback(c *context, contractName string, callback ast.Expr) (CodeSet, bool) {
+	pass, lookup := c.pass, c.lookup
+
+	// Nil values are always ok.
+	if basicType, ok := pass.TypesInfo.TypeOf(callback).(*types.Basic); ok && basicType.Kind() == types.UntypedNil {
+		return Set(), true
+	}
+
+	// Function values with a contract on their own, e.g. a parameter that is passed on.
+	if codes, ok := findErrorCodesFromFuncContract(c, callback); ok {
+		return codes, true
+	}
+
+	// Conversions between function types keep the converted function, e.g. `HandlerFunc(handle)`.
+	if callExpr, ok := astutil.Unparen(callback).(*ast.CallExpr); ok && len(callExpr.Args) == 1 && pass.TypesInfo.Types[callExpr.Fun].IsType() {
+		return findErrorCodesOfCallback(c, contractName, callExpr.Args[0])
+	}
+
+	var function *funcDefinition
+	switch callback := astutil.Unparen(callback).(type) {
+	case *ast.FuncLit:
+		function = &funcDefinition{nil, callback}
+	case *ast.Ident, *ast.SelectorExpr:
+		referenced := getReferencedFunc(pass, callback)
+		if _, ok := referenced.(*types.Var); ok {
+			if ident, ok := callback.(*ast.Ident); ok {
+				return findErrorCodesOfLocalFuncVariable(c, contractName, ident)
+			}
+		}
+
+		fn, ok := referenced.(*types.Func)
+		if !ok {
+			report(pass, callback, MsgContractCallbackUnsupported, contractName)
+			return nil, false
+		}
+
+		var fact ErrorCodes
+		if pass.ImportObjectFact(fn, &fact) {
+			return fact.Codes, true
+		}
+
+		funcDecl := findFuncDeclForFunc(c, fn)
+		if funcDecl == nil {
+			report(pass, callback, MsgContractCallbackUndeclared, contractName)
+			return nil, false
+		}
+		function = &funcDefinition{funcDecl, nil}
+	default:
+		report(pass, callback, MsgContractCallbackUnsupported, contractName)
+		return nil, false
+	}
+
+	foundCodes, ok := lookup.foundCodes[function.node()]
+	if !ok {
+		foundCodes = findErrorCodesInFunc(c, function)
+	}
+	return foundCodes, true
+}
+
+// findErrorCodesOfLocalFuncVariable finds the error codes of all functions assigned to the given local variable,
+// e.g. a method value `do := w.Do` that is passed on as a callback.
+//
+// If the error codes can not be determined, a diagnostic is emitted and false is returned.
+func findErrorCodesOfLocalFuncVariable(c *context, contractName string, ident *ast.Ident) (CodeSet, bool) {
+	pass := c.pass
+
+	function := findEnclosingFunction(pass, ident)
+	if function == nil {
+		report(pass, ident, MsgContractCallbackUnsupported, contractName)
+		return nil, false
+	}
+
+	taintResult := taintSpreadForIdentOfImmutableType(pass, map[*ast.Object]struct{}{}, ident, function)
+	if len(taintResult.identOutOfScope) > 0 || len(taintResult.destructAssignment) > 0 {
+		report(pass, ident, MsgContractCallbackUnsupported, contractName)
+		return nil, false
+	}
+
+	result := Set()
+	for _, expr := range taintResult.expressions {
+		codes, ok := findErrorCodesOfCallback(c, contractName, expr)
+		if !ok {
+			return nil, false
+		}
+		result = Union(result, codes)
+	}
+	return result, true
+}
+
+func reportIfCallbackViolatesContract(pass *analysis.Pass, callback ast.Expr, contractName string, contractCodes CodeSet, foundCodes CodeSet) {
+	unexpectedCodes := findUncoveredCodes(foundCodes, contractCodes)
+	if len(unexpectedCodes) > 0 {
+		codes := unexpectedCodes.Slice()
+		sort.Strings(codes)
+		report(pass, callback, MsgContractCodesNotSubset, contractName, codes)
+	}
+}
+
+// findFuncDeclForFunc finds the declaration of the given function or method in the current package.
+func findFuncDeclForFunc(c *context, fn *types.Func) *ast.FuncDecl {
+	signature := fn.Type().(*types.Signature)
+	if signature.Recv() == nil {
+		funcDecl, ok := c.lookup.functions[fn.Name()]
+		if ok && c.pass.TypesInfo.Defs[funcDecl.Name] == fn {
+			return funcDecl
+		}
+		return nil
+	}
+
+	for _, funcDecl := range c.lookup.methods[fn.Name()] {
+		if c.pass.TypesInfo.Defs[funcDecl.Name] == fn {
+			return funcDecl
+		}
+	}
+	return nil
+}
+
+// getReferencedFunc returns the object referenced by the given function name, e.g. `name`, `pkg.Name`, `value.Method` or `Name[T]`.
+func getReferencedFunc(pass *analysis.Pass, expr ast.Expr) types.Object {
+	switch expr := unwrapInstantiation(pass, expr).(type) {
+	case *ast.Ident:
+		return pass.TypesInfo.Uses[expr]
+	case *ast.SelectorExpr:
+		if selection, ok := pass.TypesInfo.Selections[expr]; ok {
+			return selection.Obj()
+		}
+		return pass.TypesInfo.Uses[expr.Sel]
+	}
+	return nil
+}
@@ -16,7 +16,8 @@ type funcLookup struct {
 	functions  map[string]*ast.FuncDecl   // Mapping Function Names to Declarations
 	methods    map[string][]*ast.FuncDecl // Mapping Method Names to Declarations (Multiple Possible per Name)
 	methodSet  typeutil.MethodSetCache
-	foundCodes map[funcDeclOrLit]CodeSet // Mapping Function Declarations and Function Literals to cached error codes
+	foundCodes map[funcDeclOrLit]CodeSet  // Mapping Function Declarations and Function Literals to cached error codes
+	trusted    map[funcDeclOrLit]struct{} // Functions whose cached error codes are taken without analysis, e.g. see skipOversizedComponents
 }
 
 func newFuncLookup() *funcLookup {
@@ -25,6 +26,7 @@ func newFuncLookup() *funcLookup {
 		map[string][]*ast.FuncDecl{},
 		typeutil.MethodSetCache{},
 		map[funcDeclOrLit]CodeSet{},
+		map[funcDeclOrLit]struct{}{},
 	}
 }
 
@@ -102,12 +104,40 @@ func (lookup *funcLookup) searchMethod(pass *analysis.Pass, receiver types.Type,
 	}
 
 	// Method we're looking for exists in the current package, we only need to find the right declaration
+	searchedMethod := getOriginMethod(searchedMethodType.Obj().(*types.Func))
 	for _, method := range methods {
 		methodObj := pass.TypesInfo.ObjectOf(method.Name)
-		if searchedMethodType.Obj() == methodObj {
+		if searchedMethod == methodObj {
 			return method
 		}
 	}
 
 	return nil
 }
+
+// getOriginMethod returns the declared method for a method of an instantiated generic type, like `Result[string].Err`.
+// For all other methods and functions the given object is returned.
+//
+// Facts and declarations only exist for the declared method.
+func getOriginMethod(method *types.Func) *types.Func {
+	recv := method.Type().(*types.Signature).Recv()
+	if recv == nil || method.Pkg() == nil {
+		return method
+	}
+
+	named := getNamedType(recv.Type())
+	if named == nil {
+		return method
+	}
+
+	typeName, ok := method.Pkg().Scope().Lookup(named.Obj().Name()).(*types.TypeName)
+	if !ok || typeName.Type() == named {
+		return method
+	}
+
+	object, _, _ := types.LookupFieldOrMethod(typeName.Type(), true, method.Pkg(), method.Name())
+	if origin, ok := object.(*types.Func); ok {
+		return origin
+	}
+	return method
+}
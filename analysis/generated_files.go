@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// generatedHeader matches the comment marking generated files, see https://golang.org/s/generatedcode.
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// skipGeneratedFileDiagnostics drops all diagnostics reported in generated files of the package of the given pass,
+// if the -skip-generated flag is set. Diagnostics are dropped by wrapping the report function of the pass.
+//
+// Generated files are still analysed, so the facts of functions declaring error codes in them are exported as usual.
+func skipGeneratedFileDiagnostics(pass *analysis.Pass) {
+	if !cliArguments.skipGenerated {
+		return
+	}
+
+	var generated []*token.File
+	for _, file := range pass.Files {
+		if isGeneratedFile(file) {
+			generated = append(generated, pass.Fset.File(file.Pos()))
+		}
+	}
+	if len(generated) == 0 {
+		return
+	}
+
+	report := pass.Report
+	pass.Report = func(diagnostic analysis.Diagnostic) {
+		for _, file := range generated {
+			if file != nil && file.Base() <= int(diagnostic.Pos) && int(diagnostic.Pos) <= file.Base()+file.Size() {
+				return
+			}
+		}
+		report(diagnostic)
+	}
+}
+
+// isGeneratedFile checks if the given file has a "Code generated ... DO NOT EDIT." comment before its package clause.
+func isGeneratedFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if generatedHeader.MatchString(strings.TrimRight(comment.Text, " \t\r")) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+//go:build go1.18
+// +build go1.18
+
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGenerics(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	analysistest.Run(t, dir, Analyzer, "generics/inner", "generics")
+}
+
+func TestGenericErrorTypes(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	analysistest.Run(t, dir, Analyzer, "generics/inner", "generic_errors")
+}
+
+func TestTypeParameterConstraints(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	analysistest.Run(t, dir, Analyzer, "constraints")
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// getPackageVariable returns the package-level variable of the current package the given ident refers to, if any.
+func getPackageVariable(c *context, ident *ast.Ident) (*types.Var, bool) {
+	pass := c.pass
+
+	variable, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || variable.Pkg() != pass.Pkg || variable.Parent() != pass.Pkg.Scope() {
+		return nil, false
+	}
+	return variable, true
+}
+
+// assignedValues holds all values assigned to a package-level variable or struct field.
+type assignedValues struct {
+	values []ast.Expr
+
+	// object marks the variable or field as visited in the visitedIdents of the analysis.
+	// Struct fields and variables declared in other files have no *ast.Object attached, so a separate one is used.
+	object *ast.Object
+}
+
+// findErrorCodesInAssignedValues finds the error codes of all values assigned to a package-level variable or struct field.
+func findErrorCodesInAssignedValues(c *context, visitedIdents map[*ast.Object]struct{}, assigned *assignedValues, function *funcDefinition) CodeSet {
+	// Mark variable as visited to avoid an endless loop for variables that are assigned to each other.
+	if _, ok := visitedIdents[assigned.object]; ok {
+		return nil
+	}
+	visitedIdents[assigned.object] = struct{}{}
+
+	result := Set()
+	for _, value := range assigned.values {
+		newCodes := findErrorCodesInExpression(c, visitedIdents, value, function)
+		result = Union(result, newCodes)
+	}
+	return result
+}
+
+// findErrorCodesInPackageVariable finds error codes of a package-level error variable,
+// e.g. an error that is initialised lazily using sync.Once.
+//
+// The result is the union of the error codes of all values assigned to the variable anywhere in the package.
+// This is only possible if all assignments are visible, so the variable may not be exported and its address may not be taken.
+func findErrorCodesInPackageVariable(c *context, visitedIdents map[*ast.Object]struct{}, ident *ast.Ident, variable *types.Var, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	if variable.Exported() {
+		report(pass, ident, MsgGlobalExported, variable.Name())
+		return nil
+	}
+
+	assigned, ok := c.assignedValues[variable]
+	if !ok {
+		assigned = findValuesAssignedToPackageVariable(c, variable)
+		c.assignedValues[variable] = assigned
+	}
+
+	return findErrorCodesInAssignedValues(c, visitedIdents, assigned, function)
+}
+
+// findValuesAssignedToPackageVariable finds all expressions that are assigned to the given package-level variable.
+//
+// For any assignment to the variable that can not be analysed, a diagnostic is emitted.
+func findValuesAssignedToPackageVariable(c *context, variable *types.Var) *assignedValues {
+	pass := c.pass
+
+	isVariable := func(expr ast.Expr) bool {
+		ident, ok := astutil.Unparen(expr).(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(ident) == variable
+	}
+
+	result := &assignedValues{object: ast.NewObj(ast.Var, variable.Name())}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if pass.TypesInfo.Defs[name] != variable {
+						continue
+					}
+					if len(node.Values) == len(node.Names) {
+						result.values = append(result.values, node.Values[i])
+					} else if len(node.Values) > 0 {
+						report(pass, node.Values[0], MsgAssignedCallResult, name.Name)
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhsEntry := range node.Lhs {
+					if !isVariable(lhsEntry) {
+						continue
+					}
+					if len(node.Lhs) == len(node.Rhs) {
+						result.values = append(result.values, node.Rhs[i])
+					} else {
+						report(pass, node.Rhs[0], MsgAssignedCallResult, variable.Name())
+					}
+				}
+			case *ast.UnaryExpr:
+				if node.Op == token.AND && isVariable(node.X) {
+					report(pass, node, MsgGlobalAddressTaken, variable.Name())
+				}
+			}
+			return true
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"go/types"
+	"sort"
+)
+
+// recordInterfaceUse records that the given type of the package is used as the given error interface,
+// so conflicting error codes of interfaces used for the same type can be reported at its declaration.
+func recordInterfaceUse(c *context, errorInterface *ErrorInterface, interfaceType, implType types.Type) {
+	implNamed, interfaceNamed := getNamedType(implType), getNamedType(interfaceType)
+	if implNamed == nil || interfaceNamed == nil || implNamed.Obj().Pkg() != c.pass.Pkg || types.IsInterface(implNamed) {
+		return
+	}
+
+	uses, ok := c.interfaceUses[implNamed.Obj()]
+	if !ok {
+		uses = map[*types.TypeName]*ErrorInterface{}
+		c.interfaceUses[implNamed.Obj()] = uses
+	}
+	uses[interfaceNamed.Obj()] = errorInterface
+}
+
+// checkConflictingInterfaceUses reports types of the package, which are used as several error interfaces,
+// whose methods of the same name declare different error codes.
+//
+// As the type may be used as either interface, each of its methods may only return the codes declared by all of them,
+// i.e. the intersection of the declared codes has to cover the codes of the method. Uses violating one of the interfaces
+// are reported where the type is converted, the conflict is reported once more at the declaration of the type,
+// referencing both interfaces, as the fix usually is to align the declarations of the interfaces.
+func checkConflictingInterfaceUses(c *context) {
+	implTypes := make([]*types.TypeName, 0, len(c.interfaceUses))
+	for implType := range c.interfaceUses {
+		implTypes = append(implTypes, implType)
+	}
+	sort.Slice(implTypes, func(i, j int) bool { return implTypes[i].Pos() < implTypes[j].Pos() })
+
+	for _, implType := range implTypes {
+		uses := c.interfaceUses[implType]
+		interfaces := make([]*types.TypeName, 0, len(uses))
+		for iface := range uses {
+			interfaces = append(interfaces, iface)
+		}
+		sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Name() < interfaces[j].Name() })
+
+		for i, first := range interfaces {
+			for _, second := range interfaces[i+1:] {
+				checkConflictingInterfaceMethods(c, implType, first, second, uses[first], uses[second])
+			}
+		}
+	}
+}
+
+// checkConflictingInterfaceMethods reports the methods of the given type, which are declared with different error codes
+// by the two given interfaces, and which return codes not declared by both.
+func checkConflictingInterfaceMethods(c *context, implType, first, second *types.TypeName, firstInterface, secondInterface *ErrorInterface) {
+	methodNames := make([]string, 0, len(firstInterface.ErrorMethods))
+	for methodName := range firstInterface.ErrorMethods {
+		methodNames = append(methodNames, methodName)
+	}
+	sort.Strings(methodNames)
+
+	for _, methodName := range methodNames {
+		firstCodes := firstInterface.ErrorMethods[methodName]
+		secondCodes, ok := secondInterface.ErrorMethods[methodName]
+		if !ok || (len(Difference(firstCodes, secondCodes)) == 0 && len(Difference(secondCodes, firstCodes)) == 0) {
+			continue
+		}
+
+		unexpectedCodes := Union(
+			SliceToSet(findCodesNotInInterfaceMethod(c, types.NewPointer(implType.Type()), methodName, firstCodes)),
+			SliceToSet(findCodesNotInInterfaceMethod(c, types.NewPointer(implType.Type()), methodName, secondCodes)),
+		).Slice()
+		sort.Strings(unexpectedCodes)
+		if len(unexpectedCodes) > 0 {
+			reportPos(c.pass, implType.Pos(), MsgInterfaceMethodConflict, implType.Name(), first.Name(), second.Name(), methodName, unexpectedCodes)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"go/types"
+)
+
+// getInterfaceOfMethod returns the interface type declaring the given method, or nil if it is not an interface method.
+func getInterfaceOfMethod(method types.Object) *types.Interface {
+	fn, ok := method.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+
+	result, _ := getUnderlyingType(recv.Type()).(*types.Interface)
+	return result
+}
+
+// findErrorCodesFromImplementations finds error codes that may be returned when calling the given interface method,
+// as the union of the error codes of all known implementations of the method.
+//
+// Known implementations are the types of the current package and of directly imported packages which implement the interface.
+// Implementations from imported packages are only known if their method declares error codes.
+// If there is no known implementation, false is returned.
+func findErrorCodesFromImplementations(c *context, startingFunc *funcDefinition, iface *types.Interface, methodName string) (CodeSet, bool) {
+	pass, lookup := c.pass, c.lookup
+
+	result := Set()
+	found := false
+	for _, pkg := range append([]*types.Package{pass.Pkg}, pass.Pkg.Imports()...) {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || types.IsInterface(typeName.Type()) {
+				continue
+			}
+
+			implementation := typeName.Type()
+			if !types.Implements(implementation, iface) {
+				implementation = types.NewPointer(implementation)
+				if !types.Implements(implementation, iface) {
+					continue
+				}
+			}
+
+			method, _, _ := types.LookupFieldOrMethod(implementation, true, pkg, methodName)
+			if method == nil {
+				continue
+			}
+
+			var fact ErrorCodes
+			if pass.ImportObjectFact(method, &fact) {
+				result = Union(result, fact.Codes)
+				found = true
+				continue
+			}
+
+			if pkg != pass.Pkg {
+				continue
+			}
+
+			methodDecl := lookup.searchMethod(pass, implementation, methodName)
+			if methodDecl == nil {
+				continue
+			}
+			newCodes := findErrorCodesInCalledFunc(c, startingFunc, &funcDefinition{methodDecl, nil})
+			result = Union(result, newCodes)
+			found = true
+		}
+	}
+
+	return result, found
+}
@@ -105,7 +105,7 @@ func checkIfErrorReturningInterface(pass *analysis.Pass, spec ast.Spec) *errorIn
 			// Figure out if method returns errors and try to get error code declarations.
 			errorMethod, err := checkIfInterfaceMethodDeclaresErrors(pass, interfaceType, element, elementType)
 			if err != nil {
-				pass.ReportRangef(element, "%v", err)
+				reportError(pass, element, err)
 			} else if errorMethod != nil {
 				result.errorMethods[errorMethod.ident.Name] = errorMethod
 			}
@@ -124,14 +124,14 @@ func checkIfInterfaceMethodDeclaresErrors(pass *analysis.Pass, interfaceType *as
 	}
 
 	methodIdent := method.Names[0]
-	codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(method.Doc)
+	codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, method.Doc)
 	if err != nil {
-		return nil, fmt.Errorf("interface method %q has odd docstring: %s", methodIdent.Name, err)
+		return nil, newMessageError(MsgInterfaceOddDocstring, methodIdent.Name, err)
 	}
 
 	// TODO: Implement support, then remove this check
 	if errorCodeParamName != "" {
-		return nil, fmt.Errorf("declaration of error constructors in interfaces is currently not supported")
+		return nil, newMessageError(MsgInterfaceConstructor)
 	}
 
 	errorCodeParam, ok := findErrorCodeParamIdent(pass, funcType, errorCodeParamName)
@@ -147,9 +147,9 @@ func checkIfInterfaceMethodDeclaresErrors(pass *analysis.Pass, interfaceType *as
 		}
 
 		// Warn directly about any methods if they return errors, but don't declare error codes in their docs.
-		return nil, fmt.Errorf("interface method %q does not declare any error codes", methodIdent.Name)
+		return nil, newMessageError(MsgInterfaceNoCodes, methodIdent.Name)
 	} else {
-		return &errorMethod{methodIdent, funcCodes{codes, errorCodeParam}}, nil
+		return &errorMethod{methodIdent, funcCodes{codes, errorCodeParam, nil}}, nil
 	}
 }
 
@@ -225,7 +225,7 @@ func addEmbeddedInterfaceErrorMethodsForFact(pass *analysis.Pass, embedding *err
 	for methodName, newErrorMethodCodes := range add.ErrorMethods {
 		oldErrorMethod, ok := embedding.errorMethods[methodName]
 		if !ok {
-			embedding.errorMethods[methodName] = &errorMethod{nil, funcCodes{newErrorMethodCodes, nil}}
+			embedding.errorMethods[methodName] = &errorMethod{nil, funcCodes{newErrorMethodCodes, nil, nil}}
 			continue
 		}
 
@@ -237,7 +237,7 @@ func addEmbeddedInterfaceErrorMethodsForFact(pass *analysis.Pass, embedding *err
 func checkEmbeddedInterfaceErrorMethodCodes(pass *analysis.Pass, oldCodes CodeSet, newCodes CodeSet, methodName string, reportPos analysis.Range) {
 	errorCodesMatch, errorMessage := checkIfErrorCodesMatch(oldCodes, newCodes)
 	if !errorCodesMatch {
-		pass.ReportRangef(reportPos, "embedded interface is not compatible: method %q has mismatches in declared error codes: %s", methodName, errorMessage)
+		report(pass, reportPos, MsgEmbeddedInterfaceMismatch, methodName, errorMessage)
 	}
 }
 
@@ -259,10 +259,63 @@ func exportInterfaceFacts(pass *analysis.Pass, interfaces []*errorInterfaceInter
 	}
 }
 
+// exportInterfaceLiteralFacts exports the declared codes of methods in interface literals as facts,
+// e.g. for the method of an anonymous interface used as type of a parameter or struct field.
+//
+// Unlike for methods of named interfaces, error codes are optional for methods of interface literals,
+// and types converted to interface literals are not checked.
+func exportInterfaceLiteralFacts(pass *analysis.Pass) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.TypeSpec)(nil),
+		(*ast.InterfaceType)(nil),
+	}
+
+	namedInterfaces := map[*ast.InterfaceType]struct{}{}
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.TypeSpec:
+			if interfaceType, ok := node.Type.(*ast.InterfaceType); ok {
+				namedInterfaces[interfaceType] = struct{}{}
+			}
+		case *ast.InterfaceType:
+			if _, ok := namedInterfaces[node]; !ok {
+				exportInterfaceLiteralMethodFacts(pass, node)
+			}
+		}
+	})
+}
+
+func exportInterfaceLiteralMethodFacts(pass *analysis.Pass, interfaceType *ast.InterfaceType) {
+	for _, method := range interfaceType.Methods.List {
+		funcType, ok := method.Type.(*ast.FuncType)
+		if !ok || method.Doc == nil || !checkFunctionReturnsError(pass, funcType) {
+			continue
+		}
+
+		methodIdent := method.Names[0]
+		codes, errorCodeParamName, declaredNoCodesOk, err := findErrorDocs(pass, method.Doc)
+		if err != nil {
+			report(pass, method, MsgInterfaceOddDocstring, methodIdent.Name, err)
+			continue
+		}
+
+		if errorCodeParamName != "" {
+			report(pass, method, MsgInterfaceConstructor)
+			continue
+		}
+
+		if len(codes) > 0 || declaredNoCodesOk {
+			exportErrorCodesFact(pass, methodIdent, codes)
+		}
+	}
+}
+
 func exportErrorInterfaceFact(pass *analysis.Pass, errorInterface *errorInterfaceInternal) {
 	interfaceType, ok := pass.TypesInfo.Defs[errorInterface.interfaceIdent]
 	if !ok {
-		logf("Could not find definition for interface %q!", errorInterface.interfaceIdent.Name)
+		reportInternalError(pass, errorInterface.interfaceIdent, "could not find definition for interface %q", errorInterface.interfaceIdent.Name)
 		return
 	}
 
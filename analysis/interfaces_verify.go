@@ -30,6 +30,7 @@ import (
 //     - Map Index
 //     - Range Statement
 //     - Channel Send
+//     - Instantiation of Generic Functions and Types
 func findConversionsToErrorReturningInterfaces(c *context) {
 	inspect := c.pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
@@ -76,6 +77,7 @@ func findConversionsToErrorReturningInterfaces(c *context) {
 			findConversionsInTypeSwitchStmt(c, node)
 		case *ast.IndexExpr:
 			findConversionsInIndexExpr(c, node)
+			findConversionsInInstantiation(c, node)
 		case *ast.CompositeLit:
 			findConversionsInCompositeLit(c, node)
 		case *ast.ReturnStmt:
@@ -91,11 +93,25 @@ func findConversionsToErrorReturningInterfaces(c *context) {
 			findConversionsInRangeStmtValue(c, node)
 		case *ast.SendStmt:
 			findConversionsInSendStmt(c, node)
+		case *ast.Ident:
+			findConversionsInInstantiation(c, node)
 		}
 
 		// Always recurse deeper.
 		return true
 	})
+
+	// Instantiations with multiple type arguments, e.g. `Pair[A, B]`, are not visited by the inspector.
+	for _, file := range c.pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if expr, ok := node.(ast.Expr); ok {
+				if _, ok := getIndexListExprX(expr); ok {
+					findConversionsInInstantiation(c, expr)
+				}
+			}
+			return true
+		})
+	}
 }
 
 func findConversionsInAssignStmt(c *context, statement *ast.AssignStmt) {
@@ -309,8 +325,6 @@ func findConversionsInCompositeLit(c *context, composite *ast.CompositeLit) {
 	case *types.Map:
 		findConversionsInCompositeValues(c, composite, exprType.Elem())
 		findConversionsInMapLitKeys(c, composite, exprType.Key())
-	default:
-		logf("composite lit type not handled in findConversionsInCompositeLit: %#v\n", exprType)
 	}
 }
 
@@ -416,8 +430,8 @@ func findConversionsInRangeStmtKey(c *context, statement *ast.RangeStmt) {
 	case *types.Chan:
 		exprType = rhsType.Elem()
 	default:
-		logf("unexpected type: %#v\n", rhsType)
-		panic("unexpected type in for-range statement")
+		reportInternalError(pass, statement.X, "unexpected type %v of range expression with error key", rhsType)
+		return
 	}
 
 	checkIfTypeIsValidSubtypeForInterface(c, errorInterface, keyType, exprType, statement.X)
@@ -474,6 +488,7 @@ func importErrorInterfaceFact(pass *analysis.Pass, interfaceType types.Type) *Er
 func checkIfExprHasValidSubtypeForInterface(c *context, errorInterface *ErrorInterface, interfaceType types.Type, expression ast.Expr) {
 	exprType := c.pass.TypesInfo.TypeOf(expression)
 	checkIfTypeIsValidSubtypeForInterface(c, errorInterface, interfaceType, exprType, expression)
+	checkIfFuncAdapterIsValidForInterface(c, errorInterface, interfaceType, exprType, expression)
 }
 
 // checkIfTypeIsValidSubtypeForInterface checks if the type (exprType) is a valid subtype of the interface type (interfaceType)
@@ -487,7 +502,7 @@ func checkIfTypeIsValidSubtypeForInterface(c *context, errorInterface *ErrorInte
 		return
 	}
 
-	pass, lookup := c.pass, c.lookup
+	pass := c.pass
 
 	// Nil values are always ok.
 	basicType, ok := exprType.(*types.Basic)
@@ -495,33 +510,70 @@ func checkIfTypeIsValidSubtypeForInterface(c *context, errorInterface *ErrorInte
 		return
 	}
 
+	recordInterfaceUse(c, errorInterface, interfaceType, exprType)
+
 	for methodName, interfaceCodes := range errorInterface.ErrorMethods {
-		methodType := lookup.searchMethodType(pass, exprType, methodName)
-		if methodType == nil {
-			panic("should be unreachable: the given expression was confirmed to implement the interface by the type checker.")
+		unexpectedCodes := findCodesNotInInterfaceMethod(c, exprType, methodName, interfaceCodes)
+		if len(unexpectedCodes) > 0 {
+			namedType := getNamedType(interfaceType)
+			report(pass, exprPos, MsgInterfaceCodesNotSubset, namedType.Obj().Name(), methodName, unexpectedCodes)
 		}
+	}
+}
 
-		var foundCodes CodeSet
-		var implementedCodes ErrorCodes
-		// Try to get error codes from fact.
-		if pass.ImportObjectFact(methodType.Obj(), &implementedCodes) {
-			foundCodes = implementedCodes.Codes
-		} else {
-			// Failed: Could be a non-exported function.
-			var ok bool
-			methodDecl := lookup.searchMethod(pass, exprType, methodName)
-			foundCodes, ok = lookup.foundCodes[methodDecl]
-			if !ok && methodDecl != nil {
-				foundCodes = findErrorCodesInFunc(c, &funcDefinition{methodDecl, nil})
-			}
+// findCodesNotInInterfaceMethod returns the sorted error codes of the given method of the implementing type,
+// which are not part of the error codes declared by the interface method.
+func findCodesNotInInterfaceMethod(c *context, implType types.Type, methodName string, interfaceCodes CodeSet) []string {
+	pass, lookup := c.pass, c.lookup
+
+	methodType := lookup.searchMethodType(pass, implType, methodName)
+	if methodType == nil {
+		panic("should be unreachable: the given type was confirmed to implement the interface by the type checker.")
+	}
+
+	// The codes of adapter methods depend on the adapted function, which is checked where it is converted to the adapter type.
+	if isFuncAdapterMethod(pass, methodType.Obj()) {
+		return nil
+	}
+
+	var foundCodes CodeSet
+	var implementedCodes ErrorCodes
+	// Try to get error codes from fact.
+	if pass.ImportObjectFact(methodType.Obj(), &implementedCodes) {
+		foundCodes = implementedCodes.Codes
+	} else {
+		// Failed: Could be a non-exported function.
+		var ok bool
+		methodDecl := lookup.searchMethod(pass, implType, methodName)
+		foundCodes, ok = lookup.foundCodes[methodDecl]
+		if !ok && methodDecl != nil {
+			foundCodes = findErrorCodesInFunc(c, &funcDefinition{methodDecl, nil})
 		}
+	}
 
-		unexpectedCodes := Difference(foundCodes, interfaceCodes)
-		if len(unexpectedCodes) > 0 {
-			namedType := getNamedType(interfaceType)
-			unexpectedCodes := unexpectedCodes.Slice()
-			sort.Strings(unexpectedCodes)
-			pass.ReportRangef(exprPos, "cannot use expression as %q value: method %q declares the following error codes which were not part of the interface: %v", namedType.Obj().Name(), methodName, unexpectedCodes)
+	unexpectedCodes := findUncoveredCodes(foundCodes, interfaceCodes).Slice()
+	sort.Strings(unexpectedCodes)
+	return unexpectedCodes
+}
+
+// findConversionsInInstantiation checks the type arguments of an instantiation of a generic function or type,
+// e.g. `FetchAll[*httpFetcher]` or the function of an inferred call like `FetchAll(&httpFetcher{})`.
+// Type arguments have to fulfill the error code contract of the constraint of the respective type parameter,
+// like values converted to the constraint interface.
+func findConversionsInInstantiation(c *context, expr ast.Expr) {
+	for _, typeArg := range getInstanceTypeArguments(c.pass, expr) {
+		errorInterface := importErrorInterfaceFact(c.pass, typeArg.constraint)
+		if errorInterface == nil || types.Identical(typeArg.arg, typeArg.constraint) {
+			continue
+		}
+
+		constraintName := getNamedType(typeArg.constraint).Obj().Name()
+		argName := types.TypeString(typeArg.arg, nil)
+		for methodName, interfaceCodes := range errorInterface.ErrorMethods {
+			unexpectedCodes := findCodesNotInInterfaceMethod(c, typeArg.arg, methodName, interfaceCodes)
+			if len(unexpectedCodes) > 0 {
+				report(c.pass, expr, MsgConstraintCodesNotSubset, argName, typeArg.param, methodName, constraintName, unexpectedCodes)
+			}
 		}
 	}
 }
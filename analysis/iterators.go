@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// getIteratorErrorPosition checks if the given type is an iterator yielding errors, and returns the position of the yielded error.
+//
+// Iterators are functions taking a yield function, which returns a bool, like `iter.Seq2[T, error]`,
+// which is `func(yield func(T, error) bool)`, or `iter.Seq[error]`. Exactly one of the yielded values has to be an error.
+// Functions returning an error iterator may declare the error codes of the yielded errors,
+// and the error variable of a range-over-func loop, e.g. `for v, err := range All()`, carries these error codes.
+func getIteratorErrorPosition(typ types.Type) (int, bool) {
+	yield, ok := getIteratorYieldSignature(typ)
+	if !ok {
+		return -1, false
+	}
+
+	position := -1
+	for i := 0; i < yield.Params().Len(); i++ {
+		if paramType := yield.Params().At(i).Type(); types.IsInterface(paramType) && types.Implements(paramType, tError) {
+			if position != -1 {
+				return -1, false
+			}
+			position = i
+		}
+	}
+	return position, position != -1
+}
+
+// isErrorIterator checks if the given type is an iterator yielding errors, see getIteratorErrorPosition.
+func isErrorIterator(typ types.Type) bool {
+	_, ok := getIteratorErrorPosition(typ)
+	return ok
+}
+
+// getIteratorYieldSignature returns the signature of the yield function of the given iterator type,
+// e.g. `func(T, error) bool` for `iter.Seq2[T, error]`.
+func getIteratorYieldSignature(typ types.Type) (*types.Signature, bool) {
+	if typ == nil {
+		return nil, false
+	}
+	iterator, ok := typ.Underlying().(*types.Signature)
+	if !ok || iterator.Params().Len() != 1 || iterator.Results().Len() != 0 || iterator.Variadic() {
+		return nil, false
+	}
+
+	yield, ok := iterator.Params().At(0).Type().Underlying().(*types.Signature)
+	if !ok || yield.Results().Len() != 1 || yield.Params().Len() == 0 || yield.Params().Len() > 2 || yield.Variadic() {
+		return nil, false
+	}
+	if result, ok := yield.Results().At(0).Type().Underlying().(*types.Basic); !ok || result.Kind() != types.Bool {
+		return nil, false
+	}
+	return yield, true
+}
+
+// findErrorCodesInErrorIterator finds the error codes of the errors yielded by the given error iterator expression.
+//
+// Iterators may be function literals, whose calls of yield are analysed, calls of functions returning iterators,
+// conversions like `iter.Seq2[T, error](fn)` and local variables.
+func findErrorCodesInErrorIterator(c *context, visitedIdents map[*ast.Object]struct{}, expr ast.Expr, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+
+	if pass.TypesInfo.Types[expr].IsNil() {
+		return Set()
+	}
+
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.FuncLit:
+		return findErrorCodesInYieldCalls(c, visitedIdents, expr, startingFunc)
+	case *ast.CallExpr:
+		if pass.TypesInfo.Types[expr.Fun].IsType() && len(expr.Args) == 1 {
+			return findErrorCodesInErrorIterator(c, visitedIdents, expr.Args[0], startingFunc)
+		}
+		return findErrorCodesInCallExpression(c, visitedIdents, expr, startingFunc)
+	case *ast.Ident:
+		return findErrorCodesFromIdentTaint(c, visitedIdents, expr, startingFunc)
+	}
+
+	report(pass, expr, MsgUnsupportedExpression)
+	return nil
+}
+
+// findErrorCodesInYieldCalls finds the error codes of the errors passed to the yield function in the given iterator literal,
+// e.g. `yield(v, err)` in `func(yield func(T, error) bool) { ... }`.
+//
+// The yield function may only be called, as the errors it gets passed to elsewhere can't be followed.
+// The errors are analysed in the scope of the given function, which contains the literal.
+func findErrorCodesInYieldCalls(c *context, visitedIdents map[*ast.Object]struct{}, literal *ast.FuncLit, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+	result := Set()
+
+	position, ok := getIteratorErrorPosition(pass.TypesInfo.TypeOf(literal))
+	params := literal.Type.Params.List
+	if !ok || len(params) != 1 || len(params[0].Names) != 1 {
+		return result
+	}
+	yield := pass.TypesInfo.Defs[params[0].Names[0]]
+	if yield == nil {
+		return result // the yield function is unnamed or "_", so nothing is yielded
+	}
+
+	calls := map[*ast.Ident]*ast.CallExpr{}
+	ast.Inspect(literal.Body, func(node ast.Node) bool {
+		if callExpr, ok := node.(*ast.CallExpr); ok {
+			if ident, ok := astutil.Unparen(callExpr.Fun).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == yield {
+				calls[ident] = callExpr
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(literal.Body, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[ident] != yield {
+			return true
+		}
+
+		callExpr, ok := calls[ident]
+		if !ok || len(callExpr.Args) <= position {
+			report(pass, ident, MsgYieldUnsupported, ident.Name)
+			return true
+		}
+
+		newCodes := findErrorCodesInExpression(c, visitedIdents, callExpr.Args[position], startingFunc)
+		result = Union(result, newCodes)
+		return true
+	})
+	return result
+}
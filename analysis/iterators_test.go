@@ -0,0 +1,16 @@
+//go:build go1.23
+// +build go1.23
+
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestIterators(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	analysistest.Run(t, dir, Analyzer, "iterators", "iterators/consumer")
+}
@@ -0,0 +1,371 @@
+package analysis
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lockfileHeader is written at the top of every generated lockfile.
+const lockfileHeader = `# Error codes of exported functions, generated by go-serum-analyzer -update-lockfile.
+# Changes to this file change the error contracts of the listed functions and should be reviewed as such.
+`
+
+type (
+	// lockfile records the declared error codes of all exported functions and methods,
+	// so changes to them are made explicitly, similar to API compatibility files.
+	//
+	// Every line contains a package path and a symbol, followed by the symbol's error codes.
+	// Empty lines and lines starting with '#' are ignored:
+	//
+	//	example.com/repo/payments  Pay              payments-declined payments-failed
+	//	example.com/repo/payments  Account.Balance  payments-account-not-found
+	//	example.com/repo/payments  Close
+	lockfile struct {
+		path     string
+		packages map[string]lockedSymbols // key: package path
+	}
+
+	// lockedSymbols maps the symbols of a package to their declared error codes.
+	lockedSymbols map[string]CodeSet
+)
+
+var lockfileCache = struct {
+	sync.Mutex
+	byPath map[string]*lockfile
+}{byPath: map[string]*lockfile{}}
+
+// checkLockfile compares the declared error codes of all exported functions of the package with the lockfile
+// and reports all differences. If the update flag is set, the entries of the package in the lockfile are replaced instead.
+//
+// Nothing happens if no lockfile is given.
+func checkLockfile(pass *analysis.Pass, funcClaims funcCodesMap) error {
+	if cliArguments.lockfile == "" {
+		return nil
+	}
+	if cliArguments.updateLockfile && runningAsVettool() {
+		// Every package is analysed in its own process, which would overwrite the updates of the other packages.
+		return fmt.Errorf("-update-lockfile is not supported with go vet, run go-serum-analyzer on the packages directly")
+	}
+
+	// The lockfile is shared by all packages analysed in this process, which may run concurrently.
+	lockfileCache.Lock()
+	defer lockfileCache.Unlock()
+
+	locked, err := loadLockfile(cliArguments.lockfile, cliArguments.updateLockfile)
+	if err != nil {
+		return err
+	}
+
+	current, funcDecls := findLockableSymbols(pass, funcClaims)
+	removedPackages := locked.findRemovedPackages(pass)
+
+	if cliArguments.updateLockfile {
+		if len(current) == 0 {
+			delete(locked.packages, pass.Pkg.Path())
+		} else {
+			locked.packages[pass.Pkg.Path()] = current
+		}
+		for _, pkgPath := range removedPackages {
+			delete(locked.packages, pkgPath)
+		}
+		return locked.write()
+	}
+
+	previous := locked.packages[pass.Pkg.Path()]
+	for symbol, codes := range current {
+		funcDecl := funcDecls[symbol]
+		previousCodes, ok := previous[symbol]
+		if !ok {
+			reportPosForCodes(pass, funcDecl.Pos(), codes.Slice(), MsgLockfileMissing, symbol)
+			continue
+		}
+
+		addedCodes := Difference(codes, previousCodes).Slice()
+		removedCodes := Difference(previousCodes, codes).Slice()
+		var changes []string
+		if len(addedCodes) > 0 {
+			sort.Strings(addedCodes)
+			changes = append(changes, FormatMessage(MsgLockfileAddedCodes, addedCodes))
+		}
+		if len(removedCodes) > 0 {
+			sort.Strings(removedCodes)
+			changes = append(changes, FormatMessage(MsgLockfileRemovedCodes, removedCodes))
+		}
+		if len(changes) > 0 {
+			reportPosForCodes(pass, funcDecl.Pos(), append(addedCodes, removedCodes...), MsgLockfileMismatch, symbol, strings.Join(changes, " "))
+		}
+	}
+
+	// Removed symbols can't be reported at their declaration, so they are reported at the package clause instead.
+	pos := lockfileReportPos(pass)
+	for _, symbol := range sortedSymbols(previous) {
+		if _, ok := current[symbol]; !ok {
+			reportPosForCodes(pass, pos, previous[symbol].Slice(), MsgLockfileStale, symbol)
+		}
+	}
+	for _, pkgPath := range removedPackages {
+		symbols := locked.packages[pkgPath]
+		for _, symbol := range sortedSymbols(symbols) {
+			reportPosForCodes(pass, pos, symbols[symbol].Slice(), MsgLockfilePackageRemoved, symbol, pkgPath)
+		}
+	}
+
+	return nil
+}
+
+// lockfileReportPos returns the position of the package clause of the non-test file of the package with the smallest name,
+// at which entries of the lockfile without declaration are reported. It does not depend on the order of the files.
+func lockfileReportPos(pass *analysis.Pass) token.Pos {
+	var result *ast.File
+	var resultName string
+	for _, file := range pass.Files {
+		name := filepath.Base(pass.Fset.File(file.Pos()).Name())
+		isTest, resultIsTest := strings.HasSuffix(name, "_test.go"), strings.HasSuffix(resultName, "_test.go")
+		if result == nil || (resultIsTest && !isTest) || (isTest == resultIsTest && name < resultName) {
+			result, resultName = file, name
+		}
+	}
+	return result.Package
+}
+
+// findRemovedPackages returns the sorted paths of the packages of the lockfile, which no longer exist
+// and whose entries are reported by the package of the given pass.
+//
+// Removed packages are never analysed, so their entries are reported by the package of the lockfile,
+// which still exists and has the longest common path with the removed package.
+// Only packages sharing a path prefix with the analysed package can be found, as their directories are derived from its directory.
+func (l *lockfile) findRemovedPackages(pass *analysis.Pass) []string {
+	if _, ok := l.packages[pass.Pkg.Path()]; !ok && !cliArguments.updateLockfile {
+		return nil
+	}
+	dir := filepath.Dir(pass.Fset.File(lockfileReportPos(pass)).Name())
+
+	exists := map[string]bool{pass.Pkg.Path(): true}
+	for pkgPath := range l.packages {
+		if _, ok := exists[pkgPath]; !ok {
+			exists[pkgPath] = !lockedPackageRemoved(pass.Pkg.Path(), dir, pkgPath)
+		}
+	}
+
+	var result []string
+	for pkgPath := range l.packages {
+		if exists[pkgPath] {
+			continue
+		}
+
+		reporter, reporterLength := "", 0
+		for other := range exists {
+			if !exists[other] {
+				continue
+			}
+			length := commonPathLength(other, pkgPath)
+			if length > reporterLength || (length == reporterLength && other < reporter) {
+				reporter, reporterLength = other, length
+			}
+		}
+		if reporter == pass.Pkg.Path() {
+			result = append(result, pkgPath)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// lockedPackageRemoved checks if the package with the given path has no Go files anymore.
+// Its directory is derived from the directory of the analysed package, so packages without common path are never removed.
+func lockedPackageRemoved(analysedPath, analysedDir, pkgPath string) bool {
+	length := commonPathLength(analysedPath, pkgPath)
+	if length == 0 {
+		return false
+	}
+
+	root := analysedDir
+	for i := strings.Count(analysedPath, "/") + 1; i > length; i-- {
+		root = filepath.Dir(root)
+	}
+	elements := strings.Split(pkgPath, "/")[length:]
+	entries, err := os.ReadDir(filepath.Join(append([]string{root}, elements...)...))
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			return false
+		}
+	}
+	return true
+}
+
+// commonPathLength returns the number of leading elements the given slash separated paths have in common.
+func commonPathLength(a, b string) int {
+	aElements, bElements := strings.Split(a, "/"), strings.Split(b, "/")
+	length := 0
+	for length < len(aElements) && length < len(bElements) && aElements[length] == bElements[length] {
+		length++
+	}
+	return length
+}
+
+// sortedSymbols returns the symbols of the given package of a lockfile in sorted order.
+func sortedSymbols(symbols lockedSymbols) []string {
+	result := make([]string, 0, len(symbols))
+	for symbol := range symbols {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// findLockableSymbols returns the declared error codes of all exported functions and methods of exported types,
+// which are not declared in test files. The symbols are mapped to their declarations as well.
+func findLockableSymbols(pass *analysis.Pass, funcClaims funcCodesMap) (lockedSymbols, map[string]*ast.FuncDecl) {
+	symbols := lockedSymbols{}
+	funcDecls := map[string]*ast.FuncDecl{}
+	for funcDecl, claims := range funcClaims {
+		if !funcDecl.Name.IsExported() || strings.HasSuffix(pass.Fset.File(funcDecl.Pos()).Name(), "_test.go") {
+			continue
+		}
+
+		symbol := funcDecl.Name.Name
+		if funcDecl.Recv != nil {
+			fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			named := getNamedType(fn.Type().(*types.Signature).Recv().Type())
+			if named == nil || !named.Obj().Exported() {
+				continue
+			}
+			symbol = named.Obj().Name() + "." + symbol
+		}
+
+		codes := claims.codes
+		if codes == nil {
+			codes = Set()
+		}
+		symbols[symbol] = codes
+		funcDecls[symbol] = funcDecl
+	}
+	return symbols, funcDecls
+}
+
+// loadLockfile reads the lockfile at the given path. Files are only read once.
+// If allowMissing is set, a missing file results in an empty lockfile.
+//
+// The caller has to hold the lock of the lockfileCache.
+func loadLockfile(path string, allowMissing bool) (*lockfile, error) {
+	if result, ok := lockfileCache.byPath[path]; ok {
+		return result, nil
+	}
+
+	result := &lockfile{path: path, packages: map[string]lockedSymbols{}}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && allowMissing {
+		lockfileCache.byPath[path] = result
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result.packages, err = parseLockfile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%v", path, err)
+	}
+
+	lockfileCache.byPath[path] = result
+	return result, nil
+}
+
+func parseLockfile(reader io.Reader) (map[string]lockedSymbols, error) {
+	result := map[string]lockedSymbols{}
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%d: expected a package path and a symbol, followed by error codes", lineNumber)
+		}
+
+		pkgPath, symbol, codes := fields[0], fields[1], fields[2:]
+		for _, code := range codes {
+			if !isDeclaredCodeValid(code) {
+				return nil, fmt.Errorf("%d: error code %q %s", lineNumber, code, FormatMessage(MsgInvalidCodeFormat))
+			}
+		}
+
+		symbols, ok := result[pkgPath]
+		if !ok {
+			symbols = lockedSymbols{}
+			result[pkgPath] = symbols
+		}
+		if _, ok := symbols[symbol]; ok {
+			return nil, fmt.Errorf("%d: repeated symbol %q of package %q", lineNumber, symbol, pkgPath)
+		}
+		symbols[symbol] = SliceToSet(codes)
+	}
+
+	return result, scanner.Err()
+}
+
+// write writes the lockfile to its path, with all entries sorted by package path and symbol.
+func (l *lockfile) write() error {
+	var buffer bytes.Buffer
+	l.format(&buffer)
+	return os.WriteFile(l.path, buffer.Bytes(), 0o644)
+}
+
+func (l *lockfile) format(writer io.Writer) {
+	io.WriteString(writer, lockfileHeader)
+
+	pkgPaths := make([]string, 0, len(l.packages))
+	pkgWidth, symbolWidth := 0, 0
+	for pkgPath, symbols := range l.packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+		if len(pkgPath) > pkgWidth {
+			pkgWidth = len(pkgPath)
+		}
+		for symbol := range symbols {
+			if len(symbol) > symbolWidth {
+				symbolWidth = len(symbol)
+			}
+		}
+	}
+	sort.Strings(pkgPaths)
+
+	// Columns are aligned to keep the lockfile readable in reviews.
+	for _, pkgPath := range pkgPaths {
+		symbols := l.packages[pkgPath]
+		names := make([]string, 0, len(symbols))
+		for symbol := range symbols {
+			names = append(names, symbol)
+		}
+		sort.Strings(names)
+
+		for _, symbol := range names {
+			codes := symbols[symbol].Slice()
+			sort.Strings(codes)
+			line := fmt.Sprintf("%-*s  %-*s  %s", pkgWidth, pkgPath, symbolWidth, symbol, strings.Join(codes, " "))
+			fmt.Fprintln(writer, strings.TrimRight(line, " "))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestLockfile(t *testing.T) {
+	dir := analysistest.TestData()
+	Analyzer.Flags.Set("lockfile", filepath.Join(dir, "lockfile.txt"))
+	defer Analyzer.Flags.Set("lockfile", "")
+
+	analysistest.Run(t, dir, Analyzer, "lockfile")
+}
+
+// updateLockfileInput is the lockfile, which the package lockfileupdate is checked against and updated in.
+// The package lockfileupdate/removed does not exist, the package other can't be found from lockfileupdate.
+const updateLockfileInput = `other                   Kept     some-error
+lockfileupdate          Changed  access-denied timeout
+lockfileupdate          Removed  not-found
+lockfileupdate/removed  Gone     gone
+`
+
+// runLockfile runs the analyzer with a temporary copy of updateLockfileInput on the package lockfileupdate.
+// It returns the diagnostics, formatted as "file:line: message", and the content of the lockfile afterwards.
+func runLockfile(t *testing.T, update bool) ([]string, string) {
+	path := filepath.Join(t.TempDir(), "lockfile.txt")
+	if err := os.WriteFile(path, []byte(updateLockfileInput), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	Analyzer.Flags.Set("lockfile", path)
+	Analyzer.Flags.Set("update-lockfile", strconv.FormatBool(update))
+	defer Analyzer.Flags.Set("lockfile", "")
+	defer Analyzer.Flags.Set("update-lockfile", "false")
+
+	var diagnostics []string
+	for _, result := range analysistest.Run(crashCollector{t}, analysistest.TestData(), Analyzer, "lockfileupdate") {
+		for _, diagnostic := range result.Diagnostics {
+			position := result.Pass.Fset.Position(diagnostic.Pos)
+			diagnostics = append(diagnostics, fmt.Sprintf("%s:%d: %s", filepath.Base(position.Filename), position.Line, diagnostic.Message))
+		}
+	}
+	sort.Strings(diagnostics)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return diagnostics, string(content)
+}
+
+func TestLockfileDiagnostics(t *testing.T) {
+	diagnostics, content := runLockfile(t, false)
+
+	// Entries without declaration are reported at the package clause of the first file, regardless of the order of the files.
+	want := []string{
+		`a.go:3: "Gone" of package "lockfileupdate/removed" is listed in the lockfile, but the package no longer exists`,
+		`a.go:3: "Removed" is listed in the lockfile, but is no longer exported or does not declare error codes`,
+		`b.go:17: "Added" declares error codes, but is missing in the lockfile`,
+		`b.go:7: error codes of "Changed" differ from the lockfile: added codes: [not-found] removed codes: [timeout]`,
+	}
+	if !reflect.DeepEqual(diagnostics, want) {
+		t.Errorf("diagnostics should be:\n%s\nbut were:\n%s", strings.Join(want, "\n"), strings.Join(diagnostics, "\n"))
+	}
+	if content != updateLockfileInput {
+		t.Errorf("lockfile should be unchanged, but was:\n%s", content)
+	}
+}
+
+func TestUpdateLockfile(t *testing.T) {
+	diagnostics, content := runLockfile(t, true)
+
+	if len(diagnostics) != 0 {
+		t.Errorf("expected no diagnostics when updating the lockfile, but got:\n%s", strings.Join(diagnostics, "\n"))
+	}
+	want := lockfileHeader + `lockfileupdate  Added    conflict
+lockfileupdate  Changed  access-denied not-found
+other           Kept     some-error
+`
+	if content != want {
+		t.Errorf("updated lockfile should be:\n%s\nbut was:\n%s", want, content)
+	}
+}
+
+func TestParseLockfile(t *testing.T) {
+	packages, err := parseLockfile(strings.NewReader(`
+# comment
+example.com/repo  Pay          payments-declined payments-failed
+example.com/repo  Account.Get  not-found
+example.com/repo  Close
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symbols := packages["example.com/repo"]
+	if len(symbols) != 3 {
+		t.Fatalf("expected 3 symbols but got %d", len(symbols))
+	}
+	if got := symbols["Pay"]; !reflect.DeepEqual(got, Set("payments-declined", "payments-failed")) {
+		t.Errorf("unexpected codes for Pay: %v", got)
+	}
+	if got := symbols["Close"]; len(got) != 0 {
+		t.Errorf("expected no codes for Close but got %v", got)
+	}
+
+	for _, invalid := range []string{
+		"example.com/repo",
+		"example.com/repo  Pay  not_valid",
+		"example.com/repo  Pay\nexample.com/repo  Pay",
+	} {
+		if _, err := parseLockfile(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected error for lockfile %q", invalid)
+		}
+	}
+}
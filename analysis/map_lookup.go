@@ -0,0 +1,195 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// findErrorCodesInMapIndexExpression finds error codes that originate from a lookup in a map of errors,
+// e.g. `errs["not-found"]` where `errs` is a map of pre-built errors.
+//
+// The map has to be a variable that is only ever assigned map literals.
+// Values stored into the map with index assignments (`errs[key] = value`) are considered as well.
+// If the key of the lookup is a constant, only values stored under the same constant key are analysed.
+// Values stored under a non-constant key are always considered, because they might match any lookup.
+//
+// This is only possible if all values stored in the map are visible, so the map may not be exported,
+// and may not be used other than by index expressions and assignments, e.g. passed to functions or copied to other variables.
+func findErrorCodesInMapIndexExpression(c *context, visitedIdents map[*ast.Object]struct{}, expr *ast.IndexExpr, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	if _, ok := getUnderlyingType(pass.TypesInfo.TypeOf(expr.X)).(*types.Map); !ok {
+		report(pass, expr, MsgUnsupportedExpression)
+		return nil
+	}
+
+	mapIdent, ok := astutil.Unparen(expr.X).(*ast.Ident)
+	if !ok {
+		report(pass, expr.X, MsgMapNotVariable)
+		return nil
+	}
+
+	mapVar, ok := pass.TypesInfo.ObjectOf(mapIdent).(*types.Var)
+	if !ok || mapVar.Pkg() != pass.Pkg {
+		report(pass, expr.X, MsgMapNotInPackage)
+		return nil
+	}
+	if mapVar.Exported() && mapVar.Parent() == pass.Pkg.Scope() {
+		report(pass, expr.X, MsgMapExported, mapVar.Name())
+		return nil
+	}
+
+	stored, ok := c.mapValues[mapVar]
+	if !ok {
+		stored = findValuesStoredInMap(c, mapVar)
+		c.mapValues[mapVar] = stored
+	}
+	if !stored.declared {
+		report(pass, expr.X, MsgMapOutOfScope, mapVar.Name())
+		return nil
+	}
+
+	key := pass.TypesInfo.Types[expr.Index].Value
+
+	result := Set()
+	for _, entry := range stored.entries {
+		if !mapKeyMightMatch(c, entry.key, key) {
+			continue
+		}
+		newCodes := findErrorCodesInExpression(c, visitedIdents, entry.value, function)
+		result = Union(result, newCodes)
+	}
+	return result
+}
+
+type (
+	// storedMapValues holds all values stored in a map of errors.
+	storedMapValues struct {
+		entries  []storedMapEntry
+		declared bool // whether the map is declared by a variable declaration or assignment, unlike parameters
+	}
+
+	// storedMapEntry is a value stored in a map of errors together with the expression of its key.
+	storedMapEntry struct {
+		key   ast.Expr
+		value ast.Expr
+	}
+)
+
+// findValuesStoredInMap finds all expressions that are stored in the given map variable
+// by map literals assigned to the variable or by index assignments.
+//
+// For any assignment to the map variable that can not be analysed, and for any use of the map variable
+// through which values might be stored without an index assignment, a diagnostic is emitted.
+func findValuesStoredInMap(c *context, mapVar *types.Var) *storedMapValues {
+	pass := c.pass
+
+	result := &storedMapValues{}
+	addLiteral := func(expr ast.Expr) {
+		literal, ok := astutil.Unparen(expr).(*ast.CompositeLit)
+		if !ok {
+			report(pass, expr, MsgMapNotLiteral)
+			return
+		}
+
+		for _, element := range literal.Elts {
+			element := element.(*ast.KeyValueExpr) // all elements have to be key-value, because it's a map
+			result.entries = append(result.entries, storedMapEntry{element.Key, element.Value})
+		}
+	}
+
+	isMapVar := func(expr ast.Expr) bool {
+		ident, ok := astutil.Unparen(expr).(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(ident) == mapVar
+	}
+
+	// Uses of the map variable, which can't store values in the map without being found, e.g. `errs` of `errs[key]`.
+	supportedUses := map[*ast.Ident]struct{}{}
+	markSupported := func(expr ast.Expr) {
+		if ident, ok := astutil.Unparen(expr).(*ast.Ident); ok {
+			supportedUses[ident] = struct{}{}
+		}
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if pass.TypesInfo.Defs[name] != mapVar {
+						continue
+					}
+					result.declared = true
+					if len(node.Values) == len(node.Names) {
+						addLiteral(node.Values[i])
+					} else if len(node.Values) > 0 {
+						addLiteral(node.Values[0])
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhsEntry := range node.Lhs {
+					var value ast.Expr
+					if len(node.Lhs) == len(node.Rhs) {
+						value = node.Rhs[i]
+					} else {
+						value = node.Rhs[0]
+					}
+
+					if isMapVar(lhsEntry) {
+						markSupported(lhsEntry)
+						if ident := astutil.Unparen(lhsEntry).(*ast.Ident); pass.TypesInfo.Defs[ident] == mapVar {
+							result.declared = true
+						}
+						addLiteral(value)
+						continue
+					}
+
+					indexExpr, ok := astutil.Unparen(lhsEntry).(*ast.IndexExpr)
+					if !ok || !isMapVar(indexExpr.X) {
+						continue
+					}
+					if len(node.Lhs) != len(node.Rhs) {
+						report(pass, value, MsgMapAssignedCallResult)
+						continue
+					}
+					result.entries = append(result.entries, storedMapEntry{indexExpr.Index, value})
+				}
+			case *ast.IndexExpr:
+				markSupported(node.X)
+			case *ast.RangeStmt:
+				markSupported(node.X)
+			case *ast.CallExpr:
+				if isBuiltinCall(pass, node, "len") || isBuiltinCall(pass, node, "delete") {
+					markSupported(node.Args[0])
+				}
+			case *ast.Ident:
+				if _, ok := supportedUses[node]; !ok && pass.TypesInfo.Uses[node] == mapVar {
+					report(pass, node, MsgMapLeaked, mapVar.Name())
+				}
+			}
+			return true
+		})
+	}
+
+	return result
+}
+
+// mapKeyMightMatch checks if a value stored under the given key expression might be returned by a lookup of the given key.
+//
+// If either key is not a constant, they might always match.
+func mapKeyMightMatch(c *context, keyExpr ast.Expr, key constant.Value) bool {
+	if key == nil {
+		return true
+	}
+
+	storedKey := c.pass.TypesInfo.Types[keyExpr].Value
+	if storedKey == nil || storedKey.Kind() != key.Kind() {
+		return true
+	}
+
+	return constant.Compare(storedKey, token.EQL, key)
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// messagesEnvVariable names the environment variable, which is used as path of the translated messages,
+// if the path is not given by the -messages flag.
+const messagesEnvVariable = "SERUM_MESSAGES"
+
+// messageTranslations are the translated entries of the message catalog, which are read from a JSON file
+// mapping message IDs to format strings:
+//
+//	{
+//		"codes-mismatch": "Die Fehlercodes von %q weichen von den deklarierten ab: %s",
+//		"missing-codes": "fehlende Codes: %v"
+//	}
+//
+// Messages without translation are taken from the message catalog.
+// Like for replaced catalog entries, translations receive the same arguments in the same order.
+var messageTranslations = struct {
+	sync.Mutex
+	path string
+	byID map[MessageID]string
+}{}
+
+// messagesPath returns the path of the translated messages, if any.
+func messagesPath() string {
+	if cliArguments.messagesFile != "" {
+		return cliArguments.messagesFile
+	}
+	return os.Getenv(messagesEnvVariable)
+}
+
+// loadMessageTranslations reads the translated messages from the given path, which are used by FormatMessage from then on.
+// No translations are used if no path is given. The file is only read again if the path changes.
+func loadMessageTranslations(path string) error {
+	messageTranslations.Lock()
+	defer messageTranslations.Unlock()
+
+	if path == messageTranslations.path {
+		return nil
+	}
+
+	byID := map[MessageID]string{}
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(content, &byID); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+
+		var unknown []string
+		for id := range byID {
+			if _, ok := Messages[id]; !ok {
+				unknown = append(unknown, string(id))
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return fmt.Errorf("%s: unknown message IDs: %s", path, strings.Join(unknown, ", "))
+		}
+	}
+
+	messageTranslations.path = path
+	messageTranslations.byID = byID
+	return nil
+}
+
+// translatedMessage returns the translated format string of the message with the given ID, if there is one.
+func translatedMessage(id MessageID) (string, bool) {
+	messageTranslations.Lock()
+	defer messageTranslations.Unlock()
+
+	format, ok := messageTranslations.byID[id]
+	return format, ok
+}
@@ -0,0 +1,407 @@
+package analysis
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// MessageID identifies a message of the analyzer.
+//
+// Every diagnostic reported by the analyzer carries the ID of its message as the diagnostic's category,
+// so tools wrapping the analyzer can recognise diagnostics without matching against the message text.
+type MessageID string
+
+// IDs of all messages in the message catalog.
+const (
+	// Decoration of diagnostics with the owners from the owners file.
+	MsgOwnedDiagnostic MessageID = "owned-diagnostic"
+
+	// Decoration of diagnostics about functions called multiple times.
+	MsgRepeatedCall     MessageID = "repeated-call"
+	MsgRepeatedCallSite MessageID = "repeated-call-site"
+
+	// Declaration of error codes in docstrings.
+	MsgOddDocstring          MessageID = "odd-docstring"
+	MsgDocNeedBlankLine      MessageID = "doc-need-blank-line"
+	MsgDocRepeatedBlock      MessageID = "doc-repeated-block"
+	MsgDocMissingDashes      MessageID = "doc-missing-dashes"
+	MsgDocWhitespaceCode     MessageID = "doc-whitespace-code"
+	MsgDocWhitespaceParam    MessageID = "doc-whitespace-param"
+	MsgDocMultipleParams     MessageID = "doc-multiple-params"
+	MsgDocEmptyPassthrough   MessageID = "doc-empty-passthrough"
+	MsgDocInvalidParamOption MessageID = "doc-invalid-param-option"
+	MsgDocInvalidParamPrefix MessageID = "doc-invalid-param-prefix"
+	MsgDocInvalidDetailKey   MessageID = "doc-invalid-detail-key"
+	MsgDocInvalidCode        MessageID = "doc-invalid-code"
+	MsgDocNotCanonical       MessageID = "doc-not-canonical"
+	MsgDocContradictingCode  MessageID = "doc-contradicting-code"
+	MsgInvalidCodeFormat     MessageID = "invalid-code-format"
+	MsgInvalidWildcardFormat MessageID = "invalid-wildcard-format"
+	MsgExportedWithoutCodes  MessageID = "exported-without-codes"
+	MsgCodeParamNotString    MessageID = "code-param-not-string"
+	MsgCodeParamNotFound     MessageID = "code-param-not-found"
+	MsgPassthroughNotError   MessageID = "passthrough-not-error"
+	MsgPassthroughNotFound   MessageID = "passthrough-not-found"
+	MsgCodesMismatch         MessageID = "codes-mismatch"
+	MsgExcludedCodeFound     MessageID = "excluded-code-found"
+	MsgMissingCodes          MessageID = "missing-codes"
+	MsgUnusedCodes           MessageID = "unused-codes"
+	MsgInterfaceOddDocstring MessageID = "interface-odd-docstring"
+	MsgInterfaceConstructor  MessageID = "interface-constructor"
+	MsgInterfaceNoCodes      MessageID = "interface-no-codes"
+	MsgContractOddDocstring  MessageID = "contract-odd-docstring"
+	MsgContractConstructor   MessageID = "contract-constructor"
+
+	// Annotations of return statements.
+	MsgAnnotationSyntax      MessageID = "annotation-syntax"
+	MsgAnnotationMultiple    MessageID = "annotation-multiple"
+	MsgAnnotationInvalidCode MessageID = "annotation-invalid-code"
+	MsgAnnotationMissingSign MessageID = "annotation-missing-sign"
+
+	// Error code origins.
+	MsgNotAnError               MessageID = "not-an-error"
+	MsgUnsupportedExpression    MessageID = "unsupported-expression"
+	MsgNoErrorCode              MessageID = "no-error-code"
+	MsgInvalidErrorValue        MessageID = "invalid-error-value"
+	MsgFieldInitNotFound        MessageID = "field-init-not-found"
+	MsgNonConstantCode          MessageID = "non-constant-code"
+	MsgCodeNotString            MessageID = "code-not-string"
+	MsgCodeInvalidFormat        MessageID = "code-invalid-format"
+	MsgReturnOutOfScope         MessageID = "return-out-of-scope"
+	MsgReturnOutOfScopeInLit    MessageID = "return-out-of-scope-in-literal"
+	MsgAssignedCallResult       MessageID = "assigned-call-result"
+	MsgCallErrorNotLast         MessageID = "call-error-not-last"
+	MsgGlobalExported           MessageID = "global-exported"
+	MsgGlobalAddressTaken       MessageID = "global-address-taken"
+	MsgFieldNotInPackage        MessageID = "field-not-in-package"
+	MsgFieldExported            MessageID = "field-exported"
+	MsgFieldAssignedCallResult  MessageID = "field-assigned-call-result"
+	MsgFieldAddressTaken        MessageID = "field-address-taken"
+	MsgFieldCodesNotDeclared    MessageID = "field-codes-not-declared"
+	MsgChannelNotVariable       MessageID = "channel-not-variable"
+	MsgChannelOutOfScope        MessageID = "channel-out-of-scope"
+	MsgChannelLeaked            MessageID = "channel-leaked"
+	MsgMapNotVariable           MessageID = "map-not-variable"
+	MsgMapNotInPackage          MessageID = "map-not-in-package"
+	MsgMapNotLiteral            MessageID = "map-not-literal"
+	MsgMapAssignedCallResult    MessageID = "map-assigned-call-result"
+	MsgMapExported              MessageID = "map-exported"
+	MsgMapOutOfScope            MessageID = "map-out-of-scope"
+	MsgMapLeaked                MessageID = "map-leaked"
+	MsgErrgroupNotVariable      MessageID = "errgroup-not-variable"
+	MsgErrgroupOutOfScope       MessageID = "errgroup-out-of-scope"
+	MsgErrgroupLeaked           MessageID = "errgroup-leaked"
+	MsgErrgroupTask             MessageID = "errgroup-task"
+	MsgErrorGroupSyntax         MessageID = "error-group-syntax"
+	MsgErrorGroupMethodMissing  MessageID = "error-group-method-missing"
+	MsgErrorGroupSubmit         MessageID = "error-group-submit"
+	MsgErrorGroupWait           MessageID = "error-group-wait"
+	MsgDotImportUndeclared      MessageID = "dot-import-undeclared"
+	MsgPackageFuncUndeclared    MessageID = "package-func-undeclared"
+	MsgCalleeUndeclared         MessageID = "callee-undeclared"
+	MsgUnnamedFunc              MessageID = "unnamed-func"
+	MsgLambdaOutOfScope         MessageID = "lambda-out-of-scope"
+	MsgLambdaOutOfScopeInLit    MessageID = "lambda-out-of-scope-in-literal"
+	MsgLambdaAssignment         MessageID = "lambda-assignment"
+	MsgConstructorUnsupported   MessageID = "constructor-unsupported"
+	MsgCodeParamRequired        MessageID = "code-param-required"
+	MsgCodeParamOutOfScope      MessageID = "code-param-out-of-scope"
+	MsgCodeParamAssignedCallRes MessageID = "code-param-assigned-call-result"
+	MsgCodeParamPrefixMismatch  MessageID = "code-param-prefix-mismatch"
+	MsgRecoverNotResult         MessageID = "recover-not-result"
+	MsgYieldUnsupported         MessageID = "yield-unsupported"
+
+	// Error types.
+	MsgInvalidErrorType      MessageID = "invalid-error-type"
+	MsgCodeMethodNotFound    MessageID = "code-method-not-found"
+	MsgErrorTypeWithoutCodes MessageID = "error-type-without-codes"
+	MsgCodeFieldInvalid      MessageID = "code-field-invalid"
+	MsgCodeFieldMultiple     MessageID = "code-field-multiple"
+	MsgCodeMethodReturn      MessageID = "code-method-return"
+	MsgCodeVarOutOfScope     MessageID = "code-variable-out-of-scope"
+
+	// Interfaces.
+	MsgEmbeddedInterfaceMismatch MessageID = "embedded-interface-mismatch"
+	MsgInterfaceCodesNotSubset   MessageID = "interface-codes-not-subset"
+	MsgConstraintCodesNotSubset  MessageID = "constraint-codes-not-subset"
+	MsgInterfaceMethodConflict   MessageID = "interface-method-conflict"
+
+	// Contracts of function types and function-typed parameters.
+	MsgContractCodesNotSubset      MessageID = "contract-codes-not-subset"
+	MsgContractCallbackUndeclared  MessageID = "contract-callback-undeclared"
+	MsgContractCallbackUnsupported MessageID = "contract-callback-unsupported"
+
+	// Error code lockfile.
+	MsgLockfileMismatch       MessageID = "lockfile-mismatch"
+	MsgLockfileAddedCodes     MessageID = "lockfile-added-codes"
+	MsgLockfileRemovedCodes   MessageID = "lockfile-removed-codes"
+	MsgLockfileMissing        MessageID = "lockfile-missing"
+	MsgLockfileStale          MessageID = "lockfile-stale"
+	MsgLockfilePackageRemoved MessageID = "lockfile-package-removed"
+
+	// Comparisons of error codes.
+	MsgImpossibleCodeComparison MessageID = "impossible-code-comparison"
+
+	// Details of errors.
+	MsgDetailsMismatch      MessageID = "details-mismatch"
+	MsgMissingDetailKeys    MessageID = "missing-detail-keys"
+	MsgUnexpectedDetailKeys MessageID = "unexpected-detail-keys"
+
+	// Error codes looking like sentences.
+	MsgSentenceCode           MessageID = "sentence-code"
+	MsgCodeTooLong            MessageID = "code-too-long"
+	MsgCodeTooManyWords       MessageID = "code-too-many-words"
+	MsgCodeFillerWord         MessageID = "code-filler-word"
+	MsgSentenceCodeSuggestion MessageID = "sentence-code-suggestion"
+
+	// Shadowed error variables.
+	MsgShadowedError MessageID = "shadowed-error"
+
+	// Limits of the analysis.
+	MsgComponentTooLarge  MessageID = "component-too-large"
+	MsgBodylessUndeclared MessageID = "bodyless-undeclared"
+	MsgInternalError      MessageID = "internal-error"
+)
+
+// Messages is the message catalog of the analyzer.
+// It maps every MessageID to the format string used to create the message text.
+//
+// Tools wrapping the analyzer may replace entries, e.g. to translate or augment messages.
+// Replacements receive the same arguments in the same order; explicit argument indexes (e.g. "%[2]q") can be used to reorder them.
+var Messages = map[MessageID]string{
+	MsgOwnedDiagnostic: "%s (owners: %s)",
+
+	MsgRepeatedCall:     "%s (called %d times)",
+	MsgRepeatedCallSite: "also called here",
+
+	MsgOddDocstring:          "function %q has odd docstring: %s",
+	MsgDocNeedBlankLine:      "need a blank line after the 'Errors:' block indicator",
+	MsgDocRepeatedBlock:      "repeated 'Errors:' block indicator",
+	MsgDocMissingDashes:      "mid block, a line leading with '- ' didnt contain a '--' to mark the end of the code name",
+	MsgDocWhitespaceCode:     "an error code can't be purely whitespace",
+	MsgDocWhitespaceParam:    "an error code parameter can't be purely whitespace",
+	MsgDocMultipleParams:     "cannot define more than one error code parameter (found multiple 'param:' inidicators)",
+	MsgDocEmptyPassthrough:   "a pass-through error parameter can't be purely whitespace",
+	MsgDocInvalidParamOption: `error code parameter has invalid option %s: should be (prefix "<prefix>")`,
+	MsgDocInvalidParamPrefix: "error code parameter has invalid prefix %q: codes starting with the prefix have to be valid error codes",
+	MsgDocInvalidDetailKey:   "declared details have invalid key %q: keys have to be separated by commas and can't contain whitespace",
+	MsgDocInvalidCode:        "declared error code has invalid format: %v",
+	MsgDocNotCanonical:       "'Errors:' block is not in canonical form",
+	MsgDocContradictingCode:  "error code %q is declared as returned and as never returned",
+	MsgInvalidCodeFormat:     "should match [a-zA-Z][a-zA-Z0-9\\-]*[a-zA-Z0-9]",
+	MsgInvalidWildcardFormat: "wildcards should match [a-zA-Z][a-zA-Z0-9\\-]*\\*",
+	MsgExportedWithoutCodes:  "function %q is exported, but does not declare any error codes",
+	MsgCodeParamNotString:    "error code parameter %q has to be of type string",
+	MsgCodeParamNotFound:     "declared error code parameter %q could not be found in parameter list",
+	MsgPassthroughNotError:   "pass-through parameter %q has to be of an error type",
+	MsgPassthroughNotFound:   "declared pass-through parameter %q could not be found in parameter list",
+	MsgCodesMismatch:         "function %q has a mismatch of declared and actual error codes: %s",
+	MsgExcludedCodeFound:     "function %q declares to never return %q, but may return the following error codes: %v",
+	MsgMissingCodes:          "missing codes: %v",
+	MsgUnusedCodes:           "unused codes: %v",
+	MsgInterfaceOddDocstring: "interface method %q has odd docstring: %s",
+	MsgInterfaceConstructor:  "declaration of error constructors in interfaces is currently not supported",
+	MsgInterfaceNoCodes:      "interface method %q does not declare any error codes",
+	MsgContractOddDocstring:  "error code contract has odd docstring: %s",
+	MsgContractConstructor:   "declaration of error constructors for function types is currently not supported",
+
+	MsgAnnotationSyntax:      "error in annotation: expected '=', '+=', '-=', '+code', or '-code' after '%s' indicator",
+	MsgAnnotationMultiple:    "found multiple annotations for the same return statement: only one is allowed",
+	MsgAnnotationInvalidCode: "invalid error code in annotation: %v",
+	MsgAnnotationMissingSign: "invalid error code in annotation: code has to start with '+' or '-'",
+
+	MsgNotAnError:               "expression does not implement valid error type",
+	MsgUnsupportedExpression:    "expression is not supported in error code analysis",
+	MsgNoErrorCode:              "expression does not define an error code",
+	MsgInvalidErrorValue:        "expression is not a valid error: error types must return constant error codes or a single field",
+	MsgFieldInitNotFound:        "could not find initialiser for error code field in contructor expression",
+	MsgNonConstantCode:          "error code has to be constant value or error code parameter",
+	MsgCodeNotString:            "error code has to be of type string",
+	MsgCodeInvalidFormat:        "error code has invalid format: %v",
+	MsgReturnOutOfScope:         "returned error may not be a parameter, receiver or global variable",
+	MsgReturnOutOfScopeInLit:    "returned error may not be a parameter, global variable or other variables declared outside of the function body",
+	MsgAssignedCallResult:       "unsupported: assigning result of function call to variable %q is not allowed",
+	MsgCallErrorNotLast:         "unsupported: tracking error codes for function call result that is not an error",
+	MsgGlobalExported:           "returned error may not be exported global variable %q, as it can be assigned outside of the package",
+	MsgGlobalAddressTaken:       "unsupported: address of global error variable %q may not be taken, as assignments through the pointer can not be tracked",
+	MsgFieldNotInPackage:        "unsupported: returned error field %q has to be declared in the current package",
+	MsgFieldExported:            "returned error may not be exported field %q, as it can be assigned outside of the package",
+	MsgFieldAssignedCallResult:  "unsupported: assigning result of function call to error field %q is not allowed",
+	MsgFieldAddressTaken:        "unsupported: address of error field %q may not be taken, as assignments through the pointer can not be tracked",
+	MsgFieldCodesNotDeclared:    "cannot store error in field %q: it may have the following error codes which are not declared for the field: %v",
+	MsgChannelNotVariable:       "unsupported: channel of errors has to be a local variable",
+	MsgChannelOutOfScope:        "channel of errors may not be a parameter, receiver or global variable",
+	MsgChannelLeaked:            "unsupported: channel of errors may not be passed to other functions",
+	MsgMapNotVariable:           "unsupported: map of errors has to be a variable",
+	MsgMapNotInPackage:          "unsupported: map of errors has to be a variable declared in the current package",
+	MsgMapNotLiteral:            "unsupported: map of errors may only be assigned map literals",
+	MsgMapAssignedCallResult:    "unsupported: assigning result of function call to map of errors is not allowed",
+	MsgMapExported:              "returned error may not be looked up in exported map %q, as values can be stored in it outside of the package",
+	MsgMapOutOfScope:            "map of errors %q may not be a parameter, receiver or result",
+	MsgMapLeaked:                "unsupported: map of errors %q may only be used in index expressions and assignments, as values stored through other references can not be tracked",
+	MsgErrgroupNotVariable:      "unsupported: error group has to be a local variable",
+	MsgErrgroupOutOfScope:       "error group may not be a parameter, receiver or global variable",
+	MsgErrgroupLeaked:           "unsupported: error group may not be passed to other functions",
+	MsgErrgroupTask:             "unsupported: function passed to %q has to be an identifier or function literal",
+	MsgErrorGroupSyntax:         "invalid annotation: expected %q followed by the submit methods, an arrow and the wait method, e.g. \"Submit -> Wait\"",
+	MsgErrorGroupMethodMissing:  "error group %q has no method %q",
+	MsgErrorGroupSubmit:         "submit method %q of an error group has to take a single function returning an error",
+	MsgErrorGroupWait:           "wait method %q of an error group has to return an error",
+	MsgDotImportUndeclared:      "function %q in dot-imported package does not declare error codes",
+	MsgPackageFuncUndeclared:    "function %q in package %q does not declare error codes",
+	MsgCalleeUndeclared:         "called function does not declare error codes",
+	MsgUnnamedFunc:              "invalid error source: definition of the unnamed function could not be found",
+	MsgLambdaOutOfScope:         "error returning function literal may not be a parameter, receiver or global variable",
+	MsgLambdaOutOfScopeInLit:    "error returning function literal may not be a parameter, global variable or other variables declared outside of the function body",
+	MsgLambdaAssignment:         "unsupported: assignment to variable %q can only be an identifier or function literal",
+	MsgConstructorUnsupported:   "unsupported use of error constructor %q",
+	MsgCodeParamRequired:        "require an error code parameter declaration to use %q as an error code",
+	MsgCodeParamOutOfScope:      "error code parameter may not be assigned an other parameter, receiver or global variable",
+	MsgCodeParamAssignedCallRes: "unsupported: assigning result of function call to error code parameter %q is not allowed",
+	MsgCodeParamPrefixMismatch:  "error code parameter %q is used with prefix %q, but is declared with prefix %q",
+	MsgRecoverNotResult:         "unsupported: error recovered by rerr.Recover has to be the address of a named error result, e.g. &err",
+	MsgYieldUnsupported:         "unsupported: yield function %q of an error iterator may only be called with the yielded values",
+
+	MsgInvalidErrorType:      "type is an invalid error type",
+	MsgCodeMethodNotFound:    `found no method "Code() string"`,
+	MsgErrorTypeWithoutCodes: "type %q is an invalid error type: could not find any error codes",
+	MsgCodeFieldInvalid:      "returned field %q is not a valid error code field (promoted fields are not supported currently, but might be added in the future)",
+	MsgCodeFieldMultiple:     "only single field allowed: cannot return field %q because field %q was returned previously",
+	MsgCodeMethodReturn:      "function %q should always return a string constant or a single field",
+	MsgCodeVarOutOfScope:     "error code variable may not be a parameter, receiver or global variable",
+
+	MsgEmbeddedInterfaceMismatch: "embedded interface is not compatible: method %q has mismatches in declared error codes: %s",
+	MsgInterfaceCodesNotSubset:   "cannot use expression as %q value: method %q declares the following error codes which were not part of the interface: %v",
+	MsgConstraintCodesNotSubset:  "cannot use %q as type argument for %q: method %q declares the following error codes which were not part of the constraint %q: %v",
+	MsgInterfaceMethodConflict:   "type %q is used as %q and %q, which declare different error codes for method %q: the method may only return codes declared by both, but also returns %v",
+
+	MsgContractCodesNotSubset:      "cannot use function as %q: it returns the following error codes which are not part of the contract: %v",
+	MsgContractCallbackUndeclared:  "cannot use function as %q: function does not declare error codes",
+	MsgContractCallbackUnsupported: "unsupported: function passed as %q has to be a function name, method value, function literal, local variable or a function value with a contract",
+
+	MsgLockfileMismatch:       "error codes of %q differ from the lockfile: %s",
+	MsgLockfileAddedCodes:     "added codes: %v",
+	MsgLockfileRemovedCodes:   "removed codes: %v",
+	MsgLockfileMissing:        "%q declares error codes, but is missing in the lockfile",
+	MsgLockfileStale:          "%q is listed in the lockfile, but is no longer exported or does not declare error codes",
+	MsgLockfilePackageRemoved: "%q of package %q is listed in the lockfile, but the package no longer exists",
+
+	MsgImpossibleCodeComparison: "error code %q is compared with an error which can never carry it, possible codes: %v",
+
+	MsgDetailsMismatch:      "details of error code %q do not match the declared keys: %s",
+	MsgMissingDetailKeys:    "missing keys: %v",
+	MsgUnexpectedDetailKeys: "unexpected keys: %v",
+
+	MsgSentenceCode:           "error code %q looks like a sentence: %s",
+	MsgCodeTooLong:            "it has %d characters, more than %d",
+	MsgCodeTooManyWords:       "it has %d words, more than %d",
+	MsgCodeFillerWord:         "it contains the filler word %q",
+	MsgSentenceCodeSuggestion: "prefer a short stable identifier like %q and describe the error in its message",
+
+	MsgShadowedError: "error variable %q shadows the error variable declared in line %d, codes of errors assigned to it do not reach the outer variable",
+
+	MsgComponentTooLarge:  "component too large to analyse: function %q is part of %d mutually recursive functions, more than %d, so their declared error codes are trusted without verification",
+	MsgBodylessUndeclared: "function %q has no Go body, e.g. it is implemented in assembly or linked with go:linkname, so it has to declare its error codes",
+	MsgInternalError:      "internal error of the analyser, results may be incomplete: %s",
+}
+
+// FormatMessage creates the text of the message with the given ID from the message catalog.
+//
+// Translated messages, given by the -messages flag or the SERUM_MESSAGES environment variable, take precedence.
+// If the catalog has no entry for the ID, the ID itself is used as format string.
+func FormatMessage(id MessageID, args ...interface{}) string {
+	format, ok := translatedMessage(id)
+	if !ok {
+		format, ok = Messages[id]
+	}
+	if !ok {
+		format = string(id)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// messageError is an error whose text is a message from the message catalog.
+//
+// It is used to pass messages up to the place where they get reported as diagnostics,
+// without losing the message ID.
+type messageError struct {
+	id   MessageID
+	args []interface{}
+}
+
+func newMessageError(id MessageID, args ...interface{}) error {
+	return &messageError{id, args}
+}
+
+func (e *messageError) Error() string {
+	return FormatMessage(e.id, e.args...)
+}
+
+// report emits a diagnostic for the given range, using the message with the given ID from the message catalog.
+func report(pass *analysis.Pass, rng analysis.Range, id MessageID, args ...interface{}) {
+	reportAt(pass, rng.Pos(), rng.End(), id, args...)
+}
+
+// reportPos emits a diagnostic at the given position, using the message with the given ID from the message catalog.
+func reportPos(pass *analysis.Pass, pos token.Pos, id MessageID, args ...interface{}) {
+	reportAt(pass, pos, token.NoPos, id, args...)
+}
+
+// reportError emits a diagnostic for the given range, using the message of the given error.
+//
+// The error is expected to be created by newMessageError.
+func reportError(pass *analysis.Pass, rng analysis.Range, err error) {
+	if err, ok := err.(*messageError); ok {
+		report(pass, rng, err.id, err.args...)
+		return
+	}
+	pass.ReportRangef(rng, "%v", err)
+}
+
+// reportInternalError emits a diagnostic about an inconsistency of the analyser itself for the given range,
+// so users know that the analysis is incomplete, instead of only seeing confusing diagnostics elsewhere.
+func reportInternalError(pass *analysis.Pass, rng analysis.Range, format string, args ...interface{}) {
+	report(pass, rng, MsgInternalError, fmt.Sprintf(format, args...))
+}
+
+// reportPosForCodes emits a diagnostic at the given position, which is about the given error codes.
+//
+// The codes are used to find the owners of the diagnostic, in addition to the current package.
+func reportPosForCodes(pass *analysis.Pass, pos token.Pos, codes []string, id MessageID, args ...interface{}) {
+	reportOwnedAt(pass, pos, token.NoPos, codes, id, args...)
+}
+
+func reportAt(pass *analysis.Pass, pos, end token.Pos, id MessageID, args ...interface{}) {
+	reportOwnedAt(pass, pos, end, nil, id, args...)
+}
+
+// reportWithFix emits a diagnostic for the given range, together with a fix for the problem.
+func reportWithFix(pass *analysis.Pass, rng analysis.Range, fix analysis.SuggestedFix, id MessageID, args ...interface{}) {
+	diagnostic := newDiagnostic(pass, rng.Pos(), rng.End(), nil, id, args...)
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+	pass.Report(diagnostic)
+}
+
+func reportOwnedAt(pass *analysis.Pass, pos, end token.Pos, codes []string, id MessageID, args ...interface{}) {
+	pass.Report(newDiagnostic(pass, pos, end, codes, id, args...))
+}
+
+func newDiagnostic(pass *analysis.Pass, pos, end token.Pos, codes []string, id MessageID, args ...interface{}) analysis.Diagnostic {
+	message := FormatMessage(id, args...)
+
+	// Errors loading the owners file are already returned by the analyzer run.
+	owners, _ := loadOwners(cliArguments.ownersFile)
+	if found := owners.lookup(pass.Pkg.Path(), codes...); len(found) > 0 {
+		message = FormatMessage(MsgOwnedDiagnostic, message, strings.Join(found, " "))
+	}
+
+	return analysis.Diagnostic{
+		Pos:      pos,
+		End:      end,
+		Category: string(id),
+		Message:  message,
+	}
+}
@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDiagnosticsUseMessageCatalog(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	for _, result := range analysistest.Run(t, dir, Analyzer, "001", "annotation", "docformat", "interfaces") {
+		for _, diagnostic := range result.Diagnostics {
+			id := MessageID(diagnostic.Category)
+			if _, ok := Messages[id]; !ok {
+				t.Errorf("diagnostic %q has category %q, which is not part of the message catalog", diagnostic.Message, id)
+			}
+		}
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	if got, want := FormatMessage(MsgCodeParamNotString, "code"), `error code parameter "code" has to be of type string`; got != want {
+		t.Errorf("FormatMessage should be %q but was %q", want, got)
+	}
+
+	original := Messages[MsgPackageFuncUndeclared]
+	defer func() { Messages[MsgPackageFuncUndeclared] = original }()
+	Messages[MsgPackageFuncUndeclared] = "package %[2]q: function %[1]q has no declared codes"
+	if got, want := FormatMessage(MsgPackageFuncUndeclared, "Errorf", "fmt"), `package "fmt": function "Errorf" has no declared codes`; got != want {
+		t.Errorf("FormatMessage with replaced catalog entry should be %q but was %q", want, got)
+	}
+
+	if got, want := FormatMessage("unknown-id"), "unknown-id"; got != want {
+		t.Errorf("FormatMessage for unknown ID should be %q but was %q", want, got)
+	}
+}
+
+func TestMessageTranslations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "de.json")
+	if err := os.WriteFile(path, []byte(`{"exported-without-codes": "Funktion %q ist exportiert, deklariert aber keine Fehlercodes"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	Analyzer.Flags.Set("strict", "true")
+	Analyzer.Flags.Set("messages", path)
+	defer func() {
+		Analyzer.Flags.Set("messages", "")
+		loadMessageTranslations("")
+	}()
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "translations")
+
+	if got, want := FormatMessage(MsgMissingCodes, []string{"some-error"}), "missing codes: [some-error]"; got != want {
+		t.Errorf("FormatMessage without translation should be %q but was %q", want, got)
+	}
+}
+
+func TestMessageTranslationsFromEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	if err := os.WriteFile(path, []byte(`{"missing-codes": "fehlende Codes: %v"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(messagesEnvVariable, path)
+	if err := loadMessageTranslations(messagesPath()); err != nil {
+		t.Fatal(err)
+	}
+	defer loadMessageTranslations("")
+
+	if got, want := FormatMessage(MsgMissingCodes, []string{"some-error"}), "fehlende Codes: [some-error]"; got != want {
+		t.Errorf("FormatMessage with translation should be %q but was %q", want, got)
+	}
+}
+
+func TestMessageTranslationsUnknownID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	if err := os.WriteFile(path, []byte(`{"no-such-message": "gibt es nicht"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := loadMessageTranslations(path)
+	if err == nil || !strings.Contains(err.Error(), "unknown message IDs: no-such-message") {
+		t.Errorf("loading translations with unknown message ID should fail, but got: %v", err)
+	}
+}
+
+func TestReportInternalError(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "internal.go", "package internal\n\nfunc Broken() error { return nil }\n", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diagnostics []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Fset:      fset,
+		Pkg:       types.NewPackage("internal", "internal"),
+		TypesInfo: &types.Info{Defs: map[*ast.Ident]types.Object{}},
+		Report:    func(diagnostic analysis.Diagnostic) { diagnostics = append(diagnostics, diagnostic) },
+	}
+
+	ident := file.Decls[0].(*ast.FuncDecl).Name
+	exportErrorCodesFact(pass, ident, Set("some-error"))
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic for a function without definition, got %v", diagnostics)
+	}
+	diagnostic := diagnostics[0]
+	if diagnostic.Category != string(MsgInternalError) || diagnostic.Pos != ident.Pos() {
+		t.Errorf("unexpected category or position of diagnostic: %q at %v", diagnostic.Category, fset.Position(diagnostic.Pos))
+	}
+	if want := `internal error of the analyser, results may be incomplete: could not find definition for function "Broken"`; diagnostic.Message != want {
+		t.Errorf("message should be %q but was %q", want, diagnostic.Message)
+	}
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// metricsUnknownCategory is used as category of diagnostics, which are not part of the message catalog.
+const metricsUnknownCategory = "unknown"
+
+// metrics are collected over all packages analysed in this process and written in the Prometheus textfile format,
+// so scheduled CI runs can feed dashboards without parsing the output of the analyser.
+var metrics = struct {
+	sync.Mutex
+	packages    int
+	duration    time.Duration
+	diagnostics map[string]int // key: category of the diagnostic
+}{diagnostics: map[string]int{}}
+
+// startMetrics starts collecting metrics for the package of the given pass, if a metrics file is given.
+// Diagnostics are counted by wrapping the report function of the pass.
+//
+// The returned function has to be called once the analysis of the package is done.
+// It writes the metrics of all packages analysed so far to the metrics file.
+func startMetrics(pass *analysis.Pass) func() error {
+	if cliArguments.metricsFile == "" {
+		return func() error { return nil }
+	}
+
+	start := time.Now()
+	report := pass.Report
+	pass.Report = func(diagnostic analysis.Diagnostic) {
+		category := diagnostic.Category
+		if category == "" {
+			category = metricsUnknownCategory
+		}
+
+		metrics.Lock()
+		metrics.diagnostics[category]++
+		metrics.Unlock()
+
+		report(diagnostic)
+	}
+
+	return func() error {
+		metrics.Lock()
+		defer metrics.Unlock()
+
+		metrics.packages++
+		metrics.duration += time.Since(start)
+		return writeMetrics(cliArguments.metricsFile)
+	}
+}
+
+// writeMetrics writes the collected metrics to the given path.
+// The file is replaced atomically, as textfile collectors may read it at any time.
+//
+// The caller has to hold the lock of the metrics.
+func writeMetrics(path string) error {
+	var buffer bytes.Buffer
+	formatMetrics(&buffer)
+	return writeFileAtomically(path, buffer.Bytes())
+}
+
+// writeFileAtomically replaces the file at the given path with the given content,
+// by writing to a temporary file first, which is then renamed.
+func writeFileAtomically(path string, content []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// formatMetrics writes the collected metrics in the Prometheus text format.
+//
+// The caller has to hold the lock of the metrics.
+func formatMetrics(writer io.Writer) {
+	fmt.Fprintln(writer, "# HELP serum_packages_analyzed_total Number of analysed packages.")
+	fmt.Fprintln(writer, "# TYPE serum_packages_analyzed_total counter")
+	fmt.Fprintf(writer, "serum_packages_analyzed_total %d\n", metrics.packages)
+
+	fmt.Fprintln(writer, "# HELP serum_analysis_duration_seconds Time spent analysing packages.")
+	fmt.Fprintln(writer, "# TYPE serum_analysis_duration_seconds summary")
+	fmt.Fprintf(writer, "serum_analysis_duration_seconds_sum %g\n", metrics.duration.Seconds())
+	fmt.Fprintf(writer, "serum_analysis_duration_seconds_count %d\n", metrics.packages)
+
+	categories := make([]string, 0, len(metrics.diagnostics))
+	for category := range metrics.diagnostics {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	fmt.Fprintln(writer, "# HELP serum_diagnostics_total Number of reported diagnostics by category.")
+	fmt.Fprintln(writer, "# TYPE serum_diagnostics_total counter")
+	for _, category := range categories {
+		fmt.Fprintf(writer, "serum_diagnostics_total{category=%q} %d\n", category, metrics.diagnostics[category])
+	}
+}
@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics.Lock()
+	metrics.packages, metrics.duration, metrics.diagnostics = 0, 0, map[string]int{}
+	metrics.Unlock()
+
+	dir := analysistest.TestData()
+	path := filepath.Join(t.TempDir(), "serum.prom")
+	Analyzer.Flags.Set("strict", "true")
+	Analyzer.Flags.Set("metrics-out", path)
+	defer Analyzer.Flags.Set("metrics-out", "")
+
+	// Packages of dependencies are analysed as well, here "wildcards/storage".
+	analysistest.Run(t, dir, Analyzer, "negative_claims")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	durationSum := regexp.MustCompile(`(?m)^serum_analysis_duration_seconds_sum [0-9.e+-]+$`)
+	if !durationSum.Match(content) {
+		t.Errorf("metrics should contain the sum of analysis durations, but were:\n%s", content)
+	}
+
+	want := `# HELP serum_packages_analyzed_total Number of analysed packages.
+# TYPE serum_packages_analyzed_total counter
+serum_packages_analyzed_total 2
+# HELP serum_analysis_duration_seconds Time spent analysing packages.
+# TYPE serum_analysis_duration_seconds summary
+serum_analysis_duration_seconds_sum 0
+serum_analysis_duration_seconds_count 2
+# HELP serum_diagnostics_total Number of reported diagnostics by category.
+# TYPE serum_diagnostics_total counter
+serum_diagnostics_total{category="codes-mismatch"} 1
+serum_diagnostics_total{category="excluded-code-found"} 3
+serum_diagnostics_total{category="odd-docstring"} 3
+`
+	if got := durationSum.ReplaceAllString(string(content), "serum_analysis_duration_seconds_sum 0"); got != want {
+		t.Errorf("metrics should be:\n%s\nbut were:\n%s", want, got)
+	}
+}
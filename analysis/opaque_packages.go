@@ -0,0 +1,32 @@
+package analysis
+
+import (
+	"go/types"
+	"strings"
+)
+
+// isOpaquePackage checks if the given package is listed in the -opaque-packages flag.
+//
+// Calls to functions of opaque packages are trusted to return no error codes, without reporting any diagnostics.
+// This allows to adopt the analyser step by step, e.g. while other packages of a company do not declare error codes yet.
+// Listed package paths also match their sub-packages.
+func isOpaquePackage(pkg *types.Package) bool {
+	return isPackageListed(pkg, cliArguments.opaquePackages)
+}
+
+// isPackageListed checks if the given package matches one of the package paths in the given comma separated list,
+// e.g. `example.com/billing,example.com/legacy/...`. Listed package paths also match their sub-packages.
+func isPackageListed(pkg *types.Package, list string) bool {
+	if pkg == nil || list == "" {
+		return false
+	}
+
+	path := pkg.Path()
+	for _, pattern := range strings.Split(list, ",") {
+		pattern = strings.TrimSuffix(strings.TrimSpace(pattern), "/...")
+		if pattern != "" && (path == pattern || strings.HasPrefix(path, pattern+"/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package analysis
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// OverlayDiagnostic is a diagnostic reported by AnalyzeOverlay, together with its resolved position.
+type OverlayDiagnostic struct {
+	Package  string         // path of the package the diagnostic was reported for
+	Position token.Position // resolved position of the diagnostic
+	analysis.Diagnostic
+}
+
+// AnalyzeOverlay loads the packages matching the given patterns and runs the analyzer on them,
+// returning the diagnostics of those packages sorted by position.
+//
+// The given config is used to load the packages, its Mode is set by AnalyzeOverlay.
+// Files listed in config.Overlay are analysed with their content from the overlay instead of the content on disk,
+// which allows editor integrations to analyse unsaved buffers.
+//
+// Dependencies are analysed as well, so their facts are available, except for packages of the standard library.
+// If a package can not be loaded or type checked, an error is returned.
+func AnalyzeOverlay(config *packages.Config, patterns ...string) ([]OverlayDiagnostic, error) {
+	diagnostics, _, _, err := analyzePackages(config, patterns...)
+	return diagnostics, err
+}
+
+// analyzePackages loads the packages matching the given patterns and runs the analyzer on them, like AnalyzeOverlay.
+// Besides the sorted diagnostics of those packages, it returns the loaded root packages and the driver holding the facts of all packages.
+func analyzePackages(config *packages.Config, patterns ...string) ([]OverlayDiagnostic, []*packages.Package, *overlayDriver, error) {
+	cfg := *config
+	cfg.Mode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+		packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule
+
+	roots, err := packages.Load(&cfg, patterns...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var loadErrors []string
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			loadErrors = append(loadErrors, err.Error())
+		}
+	})
+	if len(loadErrors) > 0 {
+		return nil, nil, nil, fmt.Errorf("failed to load packages: %s", strings.Join(loadErrors, "; "))
+	}
+
+	isRoot := make(map[*packages.Package]bool, len(roots))
+	for _, pkg := range roots {
+		isRoot[pkg] = true
+	}
+
+	// Dependencies are visited first, so their facts are exported before they are imported.
+	// Packages of the standard library are only analysed by the required analyzers exporting facts,
+	// e.g. the facts of ctrlflow about functions that never return, like os.Exit.
+	driver := &overlayDriver{facts: map[overlayFactKey]analysis.Fact{}}
+	allAnalyzers := requiredAnalyzers(Analyzer)
+	standardAnalyzers := requiredFactAnalyzers(Analyzer)
+	var diagnostics []OverlayDiagnostic
+	var runErr error
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		if runErr != nil {
+			return
+		}
+
+		analyzers := allAnalyzers
+		if !isRoot[pkg] && isStandardPackage(pkg) {
+			analyzers = standardAnalyzers
+		}
+		found, err := driver.run(pkg, analyzers)
+		if err != nil {
+			runErr = fmt.Errorf("%s: %v", pkg.PkgPath, err)
+			return
+		}
+		if isRoot[pkg] {
+			diagnostics = append(diagnostics, found...)
+		}
+	})
+	if runErr != nil {
+		return nil, nil, nil, runErr
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Position, diagnostics[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return diagnostics, roots, driver, nil
+}
+
+// isStandardPackage checks if the given package is part of the standard library, which does not need to be analysed,
+// apart from the facts of the required analyzers.
+func isStandardPackage(pkg *packages.Package) bool {
+	return pkg.Module == nil && !strings.Contains(strings.SplitN(pkg.PkgPath, "/", 2)[0], ".")
+}
+
+// overlayDriver runs the analyzer on loaded packages, keeping the facts of all packages in memory.
+type overlayDriver struct {
+	facts map[overlayFactKey]analysis.Fact
+}
+
+type overlayFactKey struct {
+	analyzer *analysis.Analyzer
+	obj      types.Object // nil for package facts
+	pkg      *types.Package
+	typ      reflect.Type
+}
+
+// run runs the given analyzers on the given package and returns the reported diagnostics.
+// Every analyzer has to come after the analyzers it requires, see requiredAnalyzers.
+func (d *overlayDriver) run(pkg *packages.Package, analyzers []*analysis.Analyzer) ([]OverlayDiagnostic, error) {
+	var diagnostics []OverlayDiagnostic
+	results := map[*analysis.Analyzer]interface{}{}
+
+	for _, analyzer := range analyzers {
+		analyzer := analyzer
+		pass := &analysis.Pass{
+			Analyzer:   analyzer,
+			Fset:       pkg.Fset,
+			Files:      pkg.Syntax,
+			OtherFiles: pkg.OtherFiles,
+			Pkg:        pkg.Types,
+			TypesInfo:  pkg.TypesInfo,
+			TypesSizes: pkg.TypesSizes,
+			ResultOf:   results,
+			Report: func(diagnostic analysis.Diagnostic) {
+				diagnostics = append(diagnostics, OverlayDiagnostic{pkg.PkgPath, pkg.Fset.Position(diagnostic.Pos), diagnostic})
+			},
+			ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+				return obj != nil && d.importFact(overlayFactKey{analyzer, obj, obj.Pkg(), reflect.TypeOf(fact)}, fact)
+			},
+			ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+				d.facts[overlayFactKey{analyzer, obj, pkg.Types, reflect.TypeOf(fact)}] = fact
+			},
+			ImportPackageFact: func(pkg *types.Package, fact analysis.Fact) bool {
+				return d.importFact(overlayFactKey{analyzer, nil, pkg, reflect.TypeOf(fact)}, fact)
+			},
+			ExportPackageFact: func(fact analysis.Fact) {
+				d.facts[overlayFactKey{analyzer, nil, pkg.Types, reflect.TypeOf(fact)}] = fact
+			},
+			AllObjectFacts:  func() []analysis.ObjectFact { return d.allObjectFacts(analyzer) },
+			AllPackageFacts: func() []analysis.PackageFact { return d.allPackageFacts(analyzer) },
+		}
+
+		result, err := analyzer.Run(pass)
+		if err != nil {
+			return nil, err
+		}
+		results[analyzer] = result
+	}
+
+	return diagnostics, nil
+}
+
+// requiredAnalyzers returns the given analyzer and all analyzers it requires, ordered such that
+// every analyzer comes after the analyzers it requires.
+func requiredAnalyzers(analyzer *analysis.Analyzer) []*analysis.Analyzer {
+	var result []*analysis.Analyzer
+	seen := map[*analysis.Analyzer]bool{}
+
+	var visit func(analyzer *analysis.Analyzer)
+	visit = func(analyzer *analysis.Analyzer) {
+		if seen[analyzer] {
+			return
+		}
+		seen[analyzer] = true
+		for _, required := range analyzer.Requires {
+			visit(required)
+		}
+		result = append(result, analyzer)
+	}
+	visit(analyzer)
+
+	return result
+}
+
+// requiredFactAnalyzers returns the analyzers required by the given analyzer, which export facts,
+// together with the analyzers they require, ordered like requiredAnalyzers. The given analyzer itself is not part of the result.
+func requiredFactAnalyzers(analyzer *analysis.Analyzer) []*analysis.Analyzer {
+	var result []*analysis.Analyzer
+	seen := map[*analysis.Analyzer]bool{}
+	for _, required := range requiredAnalyzers(analyzer) {
+		if required == analyzer || len(required.FactTypes) == 0 {
+			continue
+		}
+		for _, dependency := range requiredAnalyzers(required) {
+			if !seen[dependency] {
+				seen[dependency] = true
+				result = append(result, dependency)
+			}
+		}
+	}
+	return result
+}
+
+// importFact copies the stored fact for the given key into the given fact, which is a pointer to a fact type.
+func (d *overlayDriver) importFact(key overlayFactKey, fact analysis.Fact) bool {
+	stored, ok := d.facts[key]
+	if !ok {
+		return false
+	}
+	reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(stored).Elem())
+	return true
+}
+
+func (d *overlayDriver) allObjectFacts(analyzer *analysis.Analyzer) []analysis.ObjectFact {
+	var result []analysis.ObjectFact
+	for key, fact := range d.facts {
+		if key.analyzer == analyzer && key.obj != nil {
+			result = append(result, analysis.ObjectFact{Object: key.obj, Fact: fact})
+		}
+	}
+	return result
+}
+
+func (d *overlayDriver) allPackageFacts(analyzer *analysis.Analyzer) []analysis.PackageFact {
+	var result []analysis.PackageFact
+	for key, fact := range d.facts {
+		if key.analyzer == analyzer && key.obj == nil {
+			result = append(result, analysis.PackageFact{Package: key.pkg, Fact: fact})
+		}
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+)
+
+func overlayConfig(t *testing.T) *packages.Config {
+	t.Helper()
+
+	dir := analysistest.TestData()
+	return &packages.Config{
+		Dir: filepath.Join(dir, "src", "overlay"),
+		Env: append(os.Environ(), "GOPATH="+dir, "GO111MODULE=off", "GOPROXY=off"),
+	}
+}
+
+func TestAnalyzeOverlay(t *testing.T) {
+	t.Run("disk", func(t *testing.T) {
+		diagnostics, err := AnalyzeOverlay(overlayConfig(t), "overlay")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diagnostics) != 0 {
+			t.Errorf("expected no diagnostics for the content on disk, got %v", diagnostics)
+		}
+	})
+
+	t.Run("overlay", func(t *testing.T) {
+		config := overlayConfig(t)
+		filename := filepath.Join(config.Dir, "overlay.go")
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modified := strings.Replace(string(content), "return nil\n", "return &Error{\"overlay-error-unsaved\"}\n", 1)
+		config.Overlay = map[string][]byte{filename: []byte(modified)}
+
+		diagnostics, err := AnalyzeOverlay(config, "overlay")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diagnostics) != 1 {
+			t.Fatalf("expected one diagnostic for the overlay, got %v", diagnostics)
+		}
+
+		diagnostic := diagnostics[0]
+		if diagnostic.Package != "overlay" || filepath.Base(diagnostic.Position.Filename) != "overlay.go" {
+			t.Errorf("unexpected position of diagnostic: %s %v", diagnostic.Package, diagnostic.Position)
+		}
+		if !strings.Contains(diagnostic.Message, "overlay-error-unsaved") {
+			t.Errorf("expected diagnostic about the unsaved error code, got %q", diagnostic.Message)
+		}
+	})
+
+	t.Run("no return", func(t *testing.T) {
+		config := overlayConfig(t)
+		filename := filepath.Join(config.Dir, "overlay.go")
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The facts about functions that never return are exported for packages of the standard library as well.
+		modified := strings.Replace(string(content), "package overlay\n", "package overlay\n\nimport \"os\"\n", 1)
+		modified = strings.Replace(modified, "return nil\n", "os.Exit(1)\n\treturn &Error{\"overlay-error-unreachable\"}\n", 1)
+		config.Overlay = map[string][]byte{filename: []byte(modified)}
+
+		diagnostics, err := AnalyzeOverlay(config, "overlay")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diagnostics) != 0 {
+			t.Errorf("expected no diagnostics for returns after os.Exit, got %v", diagnostics)
+		}
+	})
+
+	t.Run("type error", func(t *testing.T) {
+		config := overlayConfig(t)
+		filename := filepath.Join(config.Dir, "overlay.go")
+		config.Overlay = map[string][]byte{filename: []byte("package overlay\n\nfunc Broken() error { return 1 }\n")}
+
+		if _, err := AnalyzeOverlay(config, "overlay"); err == nil {
+			t.Error("expected an error for a package with type errors")
+		}
+	})
+}
+
+// TestExternalTestPackageFacts checks that the package under test and its test variant, which is imported by the external test package,
+// export the same ErrorCodes facts, so tests are checked against the codes of the package.
+func TestExternalTestPackageFacts(t *testing.T) {
+	dir := analysistest.TestData()
+	config := &packages.Config{
+		Dir:   filepath.Join(dir, "src", "external_tests"),
+		Env:   append(os.Environ(), "GOPATH="+dir, "GO111MODULE=off", "GOPROXY=off"),
+		Tests: true,
+	}
+
+	_, _, driver, err := analyzePackages(config, "external_tests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Codes of each object per variant of the package, which is either the package itself or its test variant.
+	codes := map[string]map[*types.Package]string{}
+	for key, fact := range driver.facts {
+		if _, ok := fact.(*ErrorCodes); !ok || key.analyzer != Analyzer || key.obj == nil || key.pkg.Path() != "external_tests" {
+			continue
+		}
+		name := objectName(key.obj)
+		if codes[name] == nil {
+			codes[name] = map[*types.Package]string{}
+		}
+		codes[name][key.pkg] = fact.(*ErrorCodes).String()
+	}
+
+	for _, name := range []string{"Find", "lookup"} {
+		variants := codes[name]
+		if len(variants) != 2 {
+			t.Errorf("expected ErrorCodes facts for %s in the package and its test variant, got %v", name, variants)
+			continue
+		}
+		var first string
+		for _, fact := range variants {
+			if first == "" {
+				first = fact
+			} else if fact != first {
+				t.Errorf("ErrorCodes facts for %s differ between the package and its test variant: %v", name, variants)
+			}
+		}
+	}
+	if len(codes["Lookup"]) != 1 {
+		t.Errorf("expected an ErrorCodes fact for Lookup only in the test variant, got %v", codes["Lookup"])
+	}
+}
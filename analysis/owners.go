@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ownerCodePrefix marks a rule of an owners file to match error codes instead of package paths.
+const ownerCodePrefix = "code:"
+
+type (
+	// owners maps package paths and error code prefixes to the teams owning them.
+	//
+	// It is read from an owners file, which is similar to a CODEOWNERS file.
+	// Every line contains a pattern followed by one or more owners.
+	// Empty lines and lines starting with '#' are ignored:
+	//
+	//	# Packages are matched by path, including all sub-packages.
+	//	example.com/monorepo/payments  @payments-team
+	//	# Error codes are matched by prefix.
+	//	code:payments-                 @payments-team @oncall
+	//	*                              @platform-team
+	//
+	// Like in CODEOWNERS files, the last matching rule wins.
+	owners struct {
+		rules []ownerRule
+	}
+
+	ownerRule struct {
+		pattern string
+		isCode  bool
+		owners  []string
+	}
+)
+
+var ownersCache = struct {
+	sync.Mutex
+	byPath map[string]*owners
+}{byPath: map[string]*owners{}}
+
+// loadOwners reads the owners file at the given path.
+// The result is nil if no path is given. Files are only read once.
+func loadOwners(path string) (*owners, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	ownersCache.Lock()
+	defer ownersCache.Unlock()
+
+	if result, ok := ownersCache.byPath[path]; ok {
+		return result, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result, err := parseOwners(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%v", path, err)
+	}
+
+	ownersCache.byPath[path] = result
+	return result, nil
+}
+
+func parseOwners(reader io.Reader) (*owners, error) {
+	result := &owners{}
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%d: expected a pattern followed by at least one owner", lineNumber)
+		}
+
+		rule := ownerRule{pattern: fields[0], owners: fields[1:]}
+		if strings.HasPrefix(rule.pattern, ownerCodePrefix) {
+			rule.pattern = strings.TrimPrefix(rule.pattern, ownerCodePrefix)
+			rule.isCode = true
+		}
+		result.rules = append(result.rules, rule)
+	}
+
+	return result, scanner.Err()
+}
+
+// lookup finds the owners of the given package and error codes.
+//
+// The result contains the owners of the package and the owners of each of the given codes, sorted and without duplicates.
+func (o *owners) lookup(pkgPath string, codes ...string) []string {
+	if o == nil {
+		return nil
+	}
+
+	found := Set(o.lookupRule(false, pkgPath)...)
+	for _, code := range codes {
+		found = Union(found, Set(o.lookupRule(true, code)...))
+	}
+
+	result := found.Slice()
+	sort.Strings(result)
+	return result
+}
+
+// lookupRule returns the owners of the last rule matching the given package path or error code.
+func (o *owners) lookupRule(isCode bool, value string) []string {
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		rule := o.rules[i]
+		if rule.isCode != isCode {
+			continue
+		}
+
+		if rule.matches(value) {
+			return rule.owners
+		}
+	}
+	return nil
+}
+
+func (r *ownerRule) matches(value string) bool {
+	if r.pattern == "*" {
+		return true
+	}
+
+	if r.isCode {
+		return strings.HasPrefix(value, r.pattern)
+	}
+
+	pattern := strings.TrimSuffix(r.pattern, "/...")
+	return value == pattern || strings.HasPrefix(value, pattern+"/")
+}
@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestOwners(t *testing.T) {
+	dir := analysistest.TestData()
+	Analyzer.Flags.Set("owners", filepath.Join(dir, "owners.txt"))
+	defer Analyzer.Flags.Set("owners", "")
+
+	analysistest.Run(t, dir, Analyzer, "owners")
+}
+
+func TestOwnersLookup(t *testing.T) {
+	owners, err := parseOwners(strings.NewReader(`
+# comment
+*                         @platform
+example.com/repo/payments @payments
+code:payments-            @payments @oncall
+code:payments-internal-   @payments-internal
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		pkgPath string
+		codes   []string
+		want    []string
+	}{
+		{"example.com/repo/other", nil, []string{"@platform"}},
+		{"example.com/repo/payments", nil, []string{"@payments"}},
+		{"example.com/repo/payments/inner", nil, []string{"@payments"}},
+		{"example.com/repo/paymentsx", nil, []string{"@platform"}},
+		{"example.com/repo/other", []string{"payments-declined"}, []string{"@oncall", "@payments", "@platform"}},
+		{"example.com/repo/other", []string{"payments-internal-error"}, []string{"@payments-internal", "@platform"}},
+	} {
+		if got := owners.lookup(test.pkgPath, test.codes...); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("owners of %q with codes %v should be %v but were %v", test.pkgPath, test.codes, test.want, got)
+		}
+	}
+
+	if _, err := parseOwners(strings.NewReader("pattern-without-owner")); err == nil {
+		t.Errorf("expected error for rule without owner")
+	}
+}
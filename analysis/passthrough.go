@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// findPassthroughParamPositions finds the positions of the error parameters, which the given function declares
+// with "param-passthrough:" in its docs, e.g. `err` for "- param-passthrough: err --".
+//
+// The result is false, if a declared parameter could not be found or is not an error, which is reported.
+func findPassthroughParamPositions(pass *analysis.Pass, funcDecl *ast.FuncDecl) ([]int, bool) {
+	var result []int
+	for _, name := range findErrorPassthroughParams(pass, funcDecl.Doc) {
+		position, ok := findPassthroughParamPosition(pass, funcDecl.Type, name)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, position)
+	}
+	return result, true
+}
+
+// findPassthroughParamPosition finds the position of the pass-through parameter with the given name in the parameter list.
+func findPassthroughParamPosition(pass *analysis.Pass, funcType *ast.FuncType, name string) (int, bool) {
+	position := 0
+	for _, param := range funcType.Params.List { // Params is never nil
+		for _, paramIdent := range param.Names {
+			if paramIdent.Name != name {
+				position++
+				continue
+			}
+
+			paramType := pass.TypesInfo.TypeOf(paramIdent)
+			if paramType == nil || !types.Implements(paramType, tError) {
+				report(pass, paramIdent, MsgPassthroughNotError, name)
+				return -1, false
+			}
+			return position, true
+		}
+	}
+
+	reportPos(pass, funcType.Pos(), MsgPassthroughNotFound, name)
+	return -1, false
+}
+
+// exportErrorPassthroughFacts exports the pass-through parameters of each function in the given map as ErrorPassthrough facts.
+func exportErrorPassthroughFacts(pass *analysis.Pass, codes funcCodesMap) {
+	for funcDecl, funcCodes := range codes {
+		if len(funcCodes.passthrough) == 0 {
+			continue
+		}
+		if fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+			pass.ExportObjectFact(fn, &ErrorPassthrough{funcCodes.passthrough})
+		}
+	}
+}
+
+// isPassthroughParam checks if the given ident refers to an error parameter of the given function,
+// which was declared with "param-passthrough:" in the function's docs.
+func isPassthroughParam(pass *analysis.Pass, function *funcDefinition, ident *ast.Ident) bool {
+	if function == nil || function.funcDecl == nil {
+		return false
+	}
+
+	var fact ErrorPassthrough
+	if !pass.ImportObjectFact(pass.TypesInfo.ObjectOf(function.funcDecl.Name), &fact) {
+		return false
+	}
+
+	position := getParamPosition(function.Type(), ident)
+	for _, paramPosition := range fact.ParamPositions {
+		if position == paramPosition {
+			return true
+		}
+	}
+	return false
+}
+
+// findErrorCodesFromPassthroughCall finds the error codes of the arguments passed to the pass-through parameters
+// of the called function, if the function declares to return them.
+//
+// For example, calling `annotate(err error, message string) error` as `annotate(Lookup(), "lookup failed")`
+// returns the error codes of Lookup(), in addition to the declared error codes of annotate.
+func findErrorCodesFromPassthroughCall(c *context, visitedIdents map[*ast.Object]struct{}, callExpr *ast.CallExpr, callee types.Object, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+	result := Set()
+
+	fn, ok := callee.(*types.Func)
+	if !ok {
+		return result
+	}
+
+	var fact ErrorPassthrough
+	if !pass.ImportObjectFact(getOriginMethod(fn), &fact) {
+		return result
+	}
+
+	offset := 0
+	if isMethodExpression(pass, callExpr.Fun) {
+		offset = 1 // The receiver is passed as first argument, e.g. `(*T).Annotate(t, err)`.
+	}
+
+	for _, position := range fact.ParamPositions {
+		if position+offset < len(callExpr.Args) {
+			newCodes := findErrorCodesInExpression(c, visitedIdents, callExpr.Args[position+offset], startingFunc)
+			result = Union(result, newCodes)
+		}
+	}
+	return result
+}
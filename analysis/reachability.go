@@ -0,0 +1,350 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/cfg"
+)
+
+// getControlFlowGraph returns the control flow graph of the given function, or nil if there is none.
+func getControlFlowGraph(pass *analysis.Pass, function *funcDefinition) *cfg.CFG {
+	cfgs, ok := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	if !ok || function == nil {
+		return nil
+	}
+
+	if function.funcDecl != nil {
+		if function.funcDecl.Body == nil {
+			return nil
+		}
+		return cfgs.FuncDecl(function.funcDecl)
+	}
+	return cfgs.FuncLit(function.funcLit)
+}
+
+// findBlock finds the block of the given control flow graph, which contains the given node.
+// Returns nil if the node is not part of any block, e.g. because it is the signature of the function.
+func findBlock(graph *cfg.CFG, node ast.Node) *cfg.Block {
+	for _, block := range graph.Blocks {
+		for _, blockNode := range block.Nodes {
+			if blockNode.Pos() <= node.Pos() && node.End() <= blockNode.End() {
+				return block
+			}
+		}
+	}
+	return nil
+}
+
+// isUnreachable checks if the given statement of the given function can never be executed,
+// e.g. because it follows an unconditional panic or return.
+func isUnreachable(pass *analysis.Pass, function *funcDefinition, stmt ast.Stmt) bool {
+	graph := getControlFlowGraph(pass, function)
+	if graph == nil {
+		return false
+	}
+
+	block := findBlock(graph, stmt)
+	return block != nil && !block.Live
+}
+
+// isUnreachablePos checks if the statement at the given position of the given function can never be executed.
+// It is used for positions without a syntax node, e.g. of SSA instructions.
+func isUnreachablePos(pass *analysis.Pass, function *funcDefinition, pos token.Pos) bool {
+	graph := getControlFlowGraph(pass, function)
+	if graph == nil || !pos.IsValid() {
+		return false
+	}
+
+	for _, block := range graph.Blocks {
+		for _, node := range block.Nodes {
+			if node.Pos() <= pos && pos < node.End() {
+				return !block.Live
+			}
+		}
+	}
+	return false
+}
+
+// canReachUse checks if the value of the given source expression, which is assigned to the given variable,
+// might reach any use of the variable, following the control flow of the function containing the source.
+// Assignments to the variable are not uses and overwrite the value, so a value that is always overwritten
+// before it is used, or that is assigned after the last use, does not reach any use.
+//
+// Uses copying the value to another local variable, e.g. `errB = errA` or the swap `errA, errB = errB, errA`,
+// only count, if the copied value reaches a use of that variable in turn.
+//
+// If a use can not be located in the same function as the source, e.g. because it is inside of
+// a function literal which might be called anywhere, it is assumed that the source reaches the use.
+func canReachUse(c *context, source ast.Node, variable *ast.Ident) bool {
+	return canReachUseVisiting(c, source, variable, map[*ast.Ident]struct{}{})
+}
+
+func canReachUseVisiting(c *context, source ast.Node, variable *ast.Ident, visitedCopies map[*ast.Ident]struct{}) bool {
+	pass := c.pass
+	function := findEnclosingFunction(pass, source)
+	obj := pass.TypesInfo.ObjectOf(variable)
+	if function == nil || obj == nil {
+		return true
+	}
+
+	// Variables of enclosing functions might be used after a function literal is called,
+	// and named results are used by return statements without results.
+	if !isNodeInsideFunction(function, variable) || obj.Pos() < function.body().Pos() {
+		return true
+	}
+
+	flow := getFunctionFlow(c, function)
+	if flow == nil {
+		return true
+	}
+	from := flow.blocks[source]
+	if from == nil {
+		return true
+	}
+	if !from.Live {
+		return false
+	}
+
+	valueFlow := &variableFlow{pass, obj, source, findAssignedPos(from, source), false}
+	valueFlow.direct = valueFlow.isAssignedDirectly(from)
+	for _, use := range flow.uses[obj] {
+		if _, ok := flow.nestedUses[use]; ok {
+			return true
+		}
+
+		to := flow.blocks[use]
+		if to != nil && !valueFlow.reaches(from, to, use) {
+			continue
+		}
+
+		variableCopy, ok := flow.copies[use]
+		if !ok {
+			return true
+		}
+		if _, ok := visitedCopies[use]; ok {
+			continue
+		}
+		visitedCopies[use] = struct{}{}
+		if canReachUseVisiting(c, variableCopy.assignment, variableCopy.target, visitedCopies) {
+			return true
+		}
+	}
+	return false
+}
+
+// functionFlow indexes the control flow graph and the variables of a function, as needed by canReachUse.
+// It is built once per function and cached in the context, as canReachUse is called for every value assigned to an error variable.
+type functionFlow struct {
+	blocks     map[ast.Node]*cfg.Block       // the block of the control flow graph containing each node of the function body
+	uses       map[types.Object][]*ast.Ident // the uses of variables, which do not assign them, in source order
+	nestedUses map[*ast.Ident]struct{}       // uses inside of function literals in the function
+	copies     map[*ast.Ident]*variableCopy  // uses copying the value of a variable to another local variable
+}
+
+// getFunctionFlow returns the cached functionFlow of the given function, or nil if it has no control flow graph.
+func getFunctionFlow(c *context, function *funcDefinition) *functionFlow {
+	if flow, ok := c.functionFlows[function.node()]; ok {
+		return flow
+	}
+
+	var flow *functionFlow
+	if graph := getControlFlowGraph(c.pass, function); graph != nil {
+		flow = newFunctionFlow(c.pass, function, graph)
+	}
+	c.functionFlows[function.node()] = flow
+	return flow
+}
+
+func newFunctionFlow(pass *analysis.Pass, function *funcDefinition, graph *cfg.CFG) *functionFlow {
+	flow := &functionFlow{
+		blocks:     map[ast.Node]*cfg.Block{},
+		uses:       map[types.Object][]*ast.Ident{},
+		nestedUses: map[*ast.Ident]struct{}{},
+		copies:     map[*ast.Ident]*variableCopy{},
+	}
+
+	// Like for findBlock, a node belongs to the first block with a node containing it.
+	for _, block := range graph.Blocks {
+		for _, blockNode := range block.Nodes {
+			ast.Inspect(blockNode, func(node ast.Node) bool {
+				if _, ok := flow.blocks[node]; !ok && node != nil {
+					flow.blocks[node] = block
+				}
+				return true
+			})
+		}
+	}
+
+	assigned := map[*ast.Ident]bool{}
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			ast.Inspect(node.Body, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok {
+					flow.nestedUses[ident] = struct{}{}
+				}
+				return true
+			})
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if ident, ok := astutil.Unparen(lhs).(*ast.Ident); ok {
+					assigned[ident] = true
+				}
+			}
+			findVariableCopies(pass, function, node, node.Lhs, node.Rhs, flow.copies)
+		case *ast.ValueSpec:
+			targets := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				targets[i] = name
+			}
+			findVariableCopies(pass, function, node, targets, node.Values, flow.copies)
+		case *ast.Ident:
+			if obj := pass.TypesInfo.Uses[node]; obj != nil && !assigned[node] {
+				flow.uses[obj] = append(flow.uses[obj], node)
+			}
+		}
+		return true
+	})
+	return flow
+}
+
+// variableCopy is an assignment copying the value of one local variable to another one, e.g. `errB = errA`.
+type variableCopy struct {
+	assignment ast.Node   // *ast.AssignStmt or *ast.ValueSpec
+	target     *ast.Ident // the variable the value is copied to
+}
+
+// findVariableCopies adds the uses of variables in the given right-hand side, which are copied to local variables
+// of the given function, to the given copies.
+func findVariableCopies(pass *analysis.Pass, function *funcDefinition, assignment ast.Node, lhs, rhs []ast.Expr, copies map[*ast.Ident]*variableCopy) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+
+	for i := range rhs {
+		use, ok := unwrapInterfaceConversions(pass, rhs[i]).(*ast.Ident)
+		if !ok {
+			continue
+		}
+		target, ok := astutil.Unparen(lhs[i]).(*ast.Ident)
+		if !ok {
+			continue
+		}
+		variable, ok := pass.TypesInfo.ObjectOf(target).(*types.Var)
+		if !ok || variable.Pos() < function.body().Pos() || function.body().End() <= variable.Pos() {
+			continue // Blank identifiers, package variables and variables of enclosing functions are not followed.
+		}
+		copies[use] = &variableCopy{assignment, target}
+	}
+}
+
+// findAssignedPos returns the end of the node of the given block containing the source,
+// which is the position the value of the source is assigned at.
+// All expressions of an assignment are evaluated before any variable is assigned, e.g. in `errA, errB = errB, errA`.
+func findAssignedPos(block *cfg.Block, source ast.Node) token.Pos {
+	for _, node := range block.Nodes {
+		if node.Pos() <= source.Pos() && source.End() <= node.End() {
+			return node.End()
+		}
+	}
+	return source.End()
+}
+
+// variableFlow follows the value of a source expression assigned to a variable through the control flow graph.
+//
+// Only values assigned directly to the variable are overwritten by other assignments to the variable.
+// Values reaching the variable through other variables, e.g. `b := a`, are only checked to be evaluated before the use.
+type variableFlow struct {
+	pass     *analysis.Pass
+	obj      types.Object
+	source   ast.Node
+	assigned token.Pos // the position after which the variable holds the value of the source
+	direct   bool      // the source is assigned to the variable itself
+}
+
+// isAssignedDirectly checks if the statement of the given block, which contains the source, assigns it to the variable.
+func (f *variableFlow) isAssignedDirectly(block *cfg.Block) bool {
+	for _, node := range block.Nodes {
+		if node.Pos() > f.source.Pos() || f.source.End() > node.End() {
+			continue
+		}
+
+		var targets []ast.Expr
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			targets = node.Lhs
+		case *ast.DeclStmt:
+			if genDecl, ok := node.Decl.(*ast.GenDecl); ok {
+				for _, spec := range genDecl.Specs {
+					if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+						for _, name := range valueSpec.Names {
+							targets = append(targets, name)
+						}
+					}
+				}
+			}
+		}
+
+		for _, target := range targets {
+			if ident, ok := astutil.Unparen(target).(*ast.Ident); ok && f.pass.TypesInfo.ObjectOf(ident) == f.obj {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reaches checks if the value of the source in the block from reaches the given use in the block to,
+// without being overwritten by another assignment to the variable on the way.
+func (f *variableFlow) reaches(from, to *cfg.Block, use ast.Node) bool {
+	if from == to && f.assigned <= use.Pos() {
+		return !f.isOverwritten(from, f.assigned, use.Pos())
+	}
+	if f.isOverwritten(from, f.assigned, token.NoPos) {
+		return false
+	}
+
+	// Search the successors of the source block, which also finds the block itself inside of loops.
+	visited := map[*cfg.Block]bool{}
+	queue := append([]*cfg.Block{}, from.Succs...)
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		if block == to && !f.isOverwritten(block, token.NoPos, use.Pos()) {
+			return true
+		}
+		if visited[block] || f.isOverwritten(block, token.NoPos, token.NoPos) {
+			continue
+		}
+		visited[block] = true
+		queue = append(queue, block.Succs...)
+	}
+	return false
+}
+
+// isOverwritten checks if the given block assigns the variable after the position after and before the position before.
+// A position of token.NoPos does not limit the range.
+func (f *variableFlow) isOverwritten(block *cfg.Block, after, before token.Pos) bool {
+	if !f.direct {
+		return false
+	}
+
+	for _, node := range block.Nodes {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || (after.IsValid() && assign.Pos() <= after) || (before.IsValid() && assign.End() > before) {
+			continue
+		}
+
+		for _, lhs := range assign.Lhs {
+			if ident, ok := astutil.Unparen(lhs).(*ast.Ident); ok && f.pass.TypesInfo.ObjectOf(ident) == f.obj {
+				return true
+			}
+		}
+	}
+	return false
+}
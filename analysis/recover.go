@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// findErrorCodesFromDeferredRecover finds the error codes of the errors created by deferred calls of rerr.Recover in the given function,
+// e.g. `defer rerr.Recover(&err, "internal-error")`, which converts a panic into an error with the given code
+// and stores it in a named error result of the function.
+//
+// The code has to be constant or an error code parameter, like the code of error constructors.
+// Deferred calls in nested function literals belong to the literals.
+func findErrorCodesFromDeferredRecover(pass *analysis.Pass, function *funcDefinition) CodeSet {
+	result := Set()
+	for _, callExpr := range findDeferredRecoverCalls(pass, function) {
+		if len(callExpr.Args) != 2 {
+			continue
+		}
+
+		if !isNamedErrorResultAddress(pass, function, callExpr.Args[0]) {
+			report(pass, callExpr.Args[0], MsgRecoverNotResult)
+			continue
+		}
+
+		if code, ok := extractErrorCodeFromStringExpression(pass, function, callExpr.Args[1]); ok {
+			result.Add(code)
+		}
+	}
+	return result
+}
+
+// findErrorCodesFromDeferredRecoverLiterals finds the error codes of the errors, which deferred function literals calling recover()
+// in the given function assign to its named error results, e.g.:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = &Error{"panic-error"}
+//		}
+//	}()
+//
+// The assigned errors are returned whenever the function panics, so their codes are added to the codes of the function.
+// The assigned expressions are analysed in the scope of the function, like function literals called where they are defined.
+func findErrorCodesFromDeferredRecoverLiterals(c *context, visitedIdents map[*ast.Object]struct{}, function *funcDefinition) CodeSet {
+	pass := c.pass
+	result := Set()
+
+	var literals []*ast.FuncLit
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			if literal, ok := astutil.Unparen(node.Call.Fun).(*ast.FuncLit); ok && callsRecover(pass, literal) {
+				literals = append(literals, literal)
+			}
+		}
+		return true
+	})
+
+	for _, literal := range literals {
+		ast.Inspect(literal.Body, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt)
+			if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
+				return true
+			}
+			for i, lhs := range assign.Lhs {
+				if isNamedErrorResult(pass, function, lhs) {
+					result = Union(result, findErrorCodesInExpression(c, visitedIdents, assign.Rhs[i], function))
+				}
+			}
+			return true
+		})
+	}
+	return result
+}
+
+// callsRecover checks if the builtin recover is called in the body of the given function literal.
+func callsRecover(pass *analysis.Pass, literal *ast.FuncLit) bool {
+	found := false
+	ast.Inspect(literal.Body, func(node ast.Node) bool {
+		if callExpr, ok := node.(*ast.CallExpr); ok && isBuiltinCall(pass, callExpr, "recover") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// findDeferredRecoverCalls finds the deferred calls of rerr.Recover in the body of the given function.
+func findDeferredRecoverCalls(pass *analysis.Pass, function *funcDefinition) []*ast.CallExpr {
+	var result []*ast.CallExpr
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			if isRerrCall(pass, node.Call, "Recover") {
+				result = append(result, node.Call)
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// isNamedErrorResultAddress checks if the given expression takes the address of a named error result of the given function, e.g. `&err`.
+func isNamedErrorResultAddress(pass *analysis.Pass, function *funcDefinition, expr ast.Expr) bool {
+	unary, ok := astutil.Unparen(expr).(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return false
+	}
+	return isNamedErrorResult(pass, function, unary.X)
+}
+
+// isNamedErrorResult checks if the given expression is a named error result of the given function, e.g. `err`.
+func isNamedErrorResult(pass *analysis.Pass, function *funcDefinition, expr ast.Expr) bool {
+	ident, ok := astutil.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	variable, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	for _, errorResult := range findErrorResults(pass, function.Type()) {
+		if errorResult.name != nil && pass.TypesInfo.Defs[errorResult.name] == variable {
+			return true
+		}
+	}
+	return false
+}
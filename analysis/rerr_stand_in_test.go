@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateRerrStandIn = flag.Bool("update-rerr", false, "regenerate the stand-in of the rerr package in the testdata")
+
+// rerrStandIn is the stand-in of the rerr package in the testdata, as the testdata can not import the module.
+const rerrStandIn = "testdata/src/github.com/serum-errors/go-serum-analyzer/rerr/rerr.go"
+
+// rerrStandInDecls are the declarations of the rerr package copied into its stand-in, including the methods of types.
+// The registry is left out, as it imports net/http, whose analysis would slow down the tests.
+var rerrStandInDecls = map[string]bool{
+	"HasCode":     true,
+	"CodeOf":      true,
+	"CodeOfOr":    true,
+	"findCoded":   true,
+	"Recover":     true,
+	"PanicError":  true,
+	"ErrorStruct": true,
+}
+
+// TestRerrStandIn checks that the stand-in of the rerr package in the testdata matches the rerr package.
+// Run with -update-rerr to regenerate it after changing the rerr package.
+func TestRerrStandIn(t *testing.T) {
+	expected, err := generateRerrStandIn("../rerr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *updateRerrStandIn {
+		if err := os.WriteFile(rerrStandIn, expected, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actual, err := os.ReadFile(rerrStandIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("%s does not match the rerr package, run the test with -update-rerr to regenerate it", rerrStandIn)
+	}
+}
+
+// generateRerrStandIn generates the source of the stand-in from the non-test files of the rerr package in the given directory.
+func generateRerrStandIn(dir string) ([]byte, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var decls bytes.Buffer
+	imports := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		used := false
+		for _, decl := range file.Decls {
+			if !isRerrStandInDecl(decl) {
+				continue
+			}
+			used = true
+			start, end := fset.Position(decl.Pos()).Offset, fset.Position(decl.End()).Offset
+			decls.WriteString("\n")
+			decls.Write(src[start:end])
+			decls.WriteString("\n")
+		}
+		if used {
+			for _, spec := range file.Imports {
+				imports[spec.Path.Value] = true
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by TestRerrStandIn from the rerr package. DO NOT EDIT.\n\n")
+	buffer.WriteString("// Package rerr is a stand-in for github.com/serum-errors/go-serum-analyzer/rerr, used by the testdata.\n")
+	buffer.WriteString("package rerr\n\nimport (\n")
+	sortedImports := make([]string, 0, len(imports))
+	for path := range imports {
+		sortedImports = append(sortedImports, path)
+	}
+	sort.Strings(sortedImports)
+	for _, path := range sortedImports {
+		buffer.WriteString(path + "\n")
+	}
+	buffer.WriteString(")\n")
+	buffer.Write(decls.Bytes())
+	return format.Source(buffer.Bytes())
+}
+
+// isRerrStandInDecl checks if the given declaration of the rerr package is copied into the stand-in.
+func isRerrStandInDecl(decl ast.Decl) bool {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			return rerrStandInDecls[decl.Name.Name]
+		}
+		recv := decl.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		ident, ok := recv.(*ast.Ident)
+		return ok && rerrStandInDecls[ident.Name]
+	case *ast.GenDecl:
+		if decl.Tok != token.TYPE {
+			return false
+		}
+		for _, spec := range decl.Specs {
+			if rerrStandInDecls[spec.(*ast.TypeSpec).Name.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis/scc"
+)
+
+// Result is the result of the serum analyzer for one package.
+// It is available to other analyzers that list Analyzer in their Requires:
+//
+//	result := pass.ResultOf[serum.Analyzer].(*serum.Result)
+//	codes := result.CodesOf(expr)
+type Result struct {
+	pass     *analysis.Pass
+	lookup   *funcLookup
+	comments ast.CommentMap
+}
+
+// CodesOf returns the sorted list of error codes that the given error expression might carry.
+//
+// The expression has to be located inside of a function of the analysed package.
+// For expressions outside of functions and expressions that are not errors nil is returned.
+// Problems found while analysing the expression are not reported as diagnostics,
+// because those are already reported by the serum analyzer itself where they matter.
+func (r *Result) CodesOf(expr ast.Expr) []string {
+	typ := r.pass.TypesInfo.TypeOf(expr)
+	if typ == nil || !types.Implements(typ, tError) {
+		return nil
+	}
+
+	function := findEnclosingFunction(r.pass, expr)
+	if function == nil {
+		return nil
+	}
+
+	// Use a copy of the pass that drops all diagnostics, as the analyzer is already done reporting.
+	pass := *r.pass
+	pass.Report = func(analysis.Diagnostic) {}
+
+	scc := scc.StartSCC()
+	c := newContext(&pass, r.lookup, scc, r.comments)
+
+	scc.Visit(function.node())
+	codes := findErrorCodesInExpression(c, map[*ast.Object]struct{}{}, expr, function)
+	scc.EndVisit(function.node())
+
+	result := codes.Slice()
+	sort.Strings(result)
+	return result
+}
+
+// Facts returns the facts exported by the serum analyzer for objects of the analysed package,
+// sorted by the position of the objects.
+//
+// The facts are useful to debug why a caller does not see the error codes of a function,
+// e.g. because the function does not declare error codes and therefore has no ErrorCodes fact.
+func (r *Result) Facts() []analysis.ObjectFact {
+	var result []analysis.ObjectFact
+	for _, fact := range r.pass.AllObjectFacts() {
+		if fact.Object.Pkg() == r.pass.Pkg {
+			result = append(result, fact)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Object.Pos() < result[j].Object.Pos()
+	})
+	return result
+}
+
+// Declaration is an error code declared in the "Errors:" block of a function of the analysed package.
+type Declaration struct {
+	Function    *types.Func
+	Code        string
+	Description string   // comment of the entry, with continuation lines joined by spaces
+	Details     []string // declared keys of the details of the error, e.g. by "details: id, kind", or nil
+}
+
+// Declarations returns the error codes declared by the functions of the analysed package,
+// sorted by the position of the functions and by the order of the entries in their "Errors:" blocks.
+//
+// Only functions with an ErrorCodes fact are included, so the declarations are the ones callers see.
+// Wildcards, negative claims and error code parameters are left out, as they do not name a single error code.
+func (r *Result) Declarations() []Declaration {
+	var result []Declaration
+	for _, file := range r.pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Doc == nil {
+				continue
+			}
+			function, ok := r.pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok || !r.pass.ImportObjectFact(function, new(ErrorCodes)) {
+				continue
+			}
+
+			lines := strings.Split(funcDecl.Doc.Text(), "\n")
+			start, end := findErrorDocsBlock(lines)
+			if start == -1 {
+				continue
+			}
+
+			_, entries := parseErrorDocEntries(lines[start:end])
+			for _, entry := range entries {
+				if entry.isParam || entry.isExcluded || isWildcardCode(entry.code) {
+					continue
+				}
+
+				details, comment, _ := parseDetailKeys(entry.comment)
+				description := []string{}
+				if comment != "" {
+					description = append(description, comment)
+				}
+				for _, line := range entry.rest {
+					if content := strings.TrimSpace(line.content); content != "" {
+						description = append(description, content)
+					}
+				}
+				result = append(result, Declaration{function, entry.code, strings.Join(description, " "), details})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Function.Pos() < result[j].Function.Pos()
+	})
+	return result
+}
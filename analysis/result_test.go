@@ -0,0 +1,78 @@
+package analysis
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// codesOfAnalyzer reports the error codes of every argument passed to a function called log,
+// using the result of the serum analyzer.
+var codesOfAnalyzer = &analysis.Analyzer{
+	Name:     "codesof",
+	Doc:      "Test analyzer using the Result of the serum analyzer.",
+	Requires: []*analysis.Analyzer{Analyzer},
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		result := pass.ResultOf[Analyzer].(*Result)
+		for _, file := range pass.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok || len(call.Args) != 1 {
+					return true
+				}
+				if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "log" {
+					pass.Reportf(call.Args[0].Pos(), "codes: %s", strings.Join(result.CodesOf(call.Args[0]), " "))
+				}
+				return true
+			})
+		}
+		return nil, nil
+	},
+}
+
+func TestResultCodesOf(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), codesOfAnalyzer, "result")
+}
+
+// factsAnalyzer reports all facts of the analysed package at the respective objects, using the result of the serum analyzer.
+var factsAnalyzer = &analysis.Analyzer{
+	Name:     "facts",
+	Doc:      "Test analyzer listing the facts of the serum analyzer.",
+	Requires: []*analysis.Analyzer{Analyzer},
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		result := pass.ResultOf[Analyzer].(*Result)
+		for _, fact := range result.Facts() {
+			pass.Reportf(fact.Object.Pos(), "%s: %v", fact.Object.Name(), fact.Fact)
+		}
+		return nil, nil
+	},
+}
+
+func TestResultFacts(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), factsAnalyzer, "result_facts")
+}
+
+// declarationsAnalyzer reports all declared error codes of the analysed package at the declaring functions.
+var declarationsAnalyzer = &analysis.Analyzer{
+	Name:     "declarations",
+	Doc:      "Test analyzer listing the declarations of the serum analyzer.",
+	Requires: []*analysis.Analyzer{Analyzer},
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		result := pass.ResultOf[Analyzer].(*Result)
+		for _, declaration := range result.Declarations() {
+			if declaration.Details != nil {
+				pass.Reportf(declaration.Function.Pos(), "%s: %s: details %v: %s", declaration.Function.Name(), declaration.Code, declaration.Details, declaration.Description)
+				continue
+			}
+			pass.Reportf(declaration.Function.Pos(), "%s: %s: %s", declaration.Function.Name(), declaration.Code, declaration.Description)
+		}
+		return nil, nil
+	},
+}
+
+func TestResultDeclarations(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), declarationsAnalyzer, "result_declarations")
+}
@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fillerWords are words which are part of sentences, but carry no meaning in an identifier.
+// Codes containing them usually describe the error instead of naming it, e.g. "storage-the-file-could-not-be-found".
+var fillerWords = map[string]struct{}{
+	"a": {}, "an": {}, "the": {},
+	"is": {}, "are": {}, "was": {}, "were": {}, "be": {}, "been": {},
+	"could": {}, "would": {}, "should": {}, "can": {}, "cannot": {},
+	"has": {}, "have": {}, "had": {}, "does": {}, "did": {},
+	"to": {}, "of": {}, "for": {}, "because": {}, "when": {}, "while": {}, "please": {},
+}
+
+// isSentenceCodeCheckEnabled checks if one of the limits of the check for codes looking like sentences is set.
+func isSentenceCodeCheckEnabled() bool {
+	return cliArguments.maxCodeLength > 0 || cliArguments.maxCodeWords > 0
+}
+
+// findSentenceCodes reports the error codes declared in the "Errors:" blocks of the package, which look like sentences:
+// codes longer than the -max-code-length limit, codes with more dash separated words than the -max-code-words limit,
+// and codes containing filler words like "the" or "could".
+//
+// Codes should be short stable identifiers, the description of the error belongs in its message.
+// The diagnostics are reported at the declaring entries and suggest a shorter code, if one can be derived.
+// Doc comments with invalid "Errors:" blocks are skipped, they are reported where the docs are used.
+func findSentenceCodes(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			checkSentenceCodes(pass, group)
+		}
+	}
+}
+
+func checkSentenceCodes(pass *analysis.Pass, group *ast.CommentGroup) {
+	lines := make([]string, len(group.List))
+	for i, comment := range group.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			return // Only line comments are supported.
+		}
+		lines[i] = comment.Text
+	}
+
+	if _, _, _, err := (&findErrorDocsSM{}).run(stripCommentMarkers(strings.Join(lines, "\n"))); err != nil {
+		return
+	}
+	start, end := findErrorDocsBlock(lines)
+	if start == -1 {
+		return
+	}
+
+	for i := start; i < end; i++ {
+		_, entries := parseErrorDocEntries(lines[i : i+1])
+		if len(entries) == 0 || entries[0].isParam {
+			continue
+		}
+		code := strings.TrimPrefix(entries[0].code, negativeClaimPrefix)
+		if isWildcardCode(code) {
+			continue
+		}
+
+		reasons := findSentenceCodeReasons(code)
+		if len(reasons) == 0 {
+			continue
+		}
+		message := strings.Join(reasons, ", ")
+		if suggestion := suggestShortCode(code); suggestion != "" {
+			message += "; " + FormatMessage(MsgSentenceCodeSuggestion, suggestion)
+		}
+		reportPosForCodes(pass, group.List[i].Pos(), []string{code}, MsgSentenceCode, code, message)
+	}
+}
+
+// findSentenceCodeReasons returns the reasons why the given error code looks like a sentence, or nil if it does not.
+func findSentenceCodeReasons(code string) []string {
+	var reasons []string
+	words := strings.Split(code, "-")
+	if limit := cliArguments.maxCodeLength; limit > 0 && len(code) > limit {
+		reasons = append(reasons, FormatMessage(MsgCodeTooLong, len(code), limit))
+	}
+	if limit := cliArguments.maxCodeWords; limit > 0 && len(words) > limit {
+		reasons = append(reasons, FormatMessage(MsgCodeTooManyWords, len(words), limit))
+	}
+	for _, word := range words {
+		if _, ok := fillerWords[strings.ToLower(word)]; ok {
+			reasons = append(reasons, FormatMessage(MsgCodeFillerWord, word))
+			break
+		}
+	}
+	return reasons
+}
+
+// suggestShortCode derives a shorter error code from the given one, which does not look like a sentence.
+// Filler words are dropped, and trailing words are dropped until the code is within the limits.
+// The first two words are always kept, as they usually name the package and the kind of error.
+//
+// If no shorter valid code can be derived, the empty string is returned.
+func suggestShortCode(code string) string {
+	var words []string
+	for _, word := range strings.Split(code, "-") {
+		if _, ok := fillerWords[strings.ToLower(word)]; !ok && word != "" {
+			words = append(words, word)
+		}
+	}
+
+	for len(words) > 2 {
+		tooLong := cliArguments.maxCodeLength > 0 && len(strings.Join(words, "-")) > cliArguments.maxCodeLength
+		tooManyWords := cliArguments.maxCodeWords > 0 && len(words) > cliArguments.maxCodeWords
+		if !tooLong && !tooManyWords {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+
+	suggestion := strings.Join(words, "-")
+	if suggestion == code || !isErrorCodeValid(suggestion) || len(findSentenceCodeReasons(suggestion)) > 0 {
+		return ""
+	}
+	return suggestion
+}
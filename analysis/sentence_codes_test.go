@@ -0,0 +1,25 @@
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSentenceCodes(t *testing.T) {
+	Analyzer.Flags.Set("strict", "true")
+	Analyzer.Flags.Set("max-code-length", "24")
+	Analyzer.Flags.Set("max-code-words", "4")
+	defer Analyzer.Flags.Set("max-code-length", "0")
+	defer Analyzer.Flags.Set("max-code-words", "0")
+
+	// The diagnostics are reported in doc comments, where no expectations can be declared.
+	c := &collector{data: map[string]struct{}{}}
+	analysistest.Run(c, analysistest.TestData(), Analyzer, "sentence_codes")
+	c.assert(t,
+		`sentence_codes/sentence_codes.go:23:1: unexpected diagnostic: error code "storage-the-file-could-not-be-found" looks like a sentence: it has 35 characters, more than 24, it has 7 words, more than 4, it contains the filler word "the"; prefer a short stable identifier like "storage-file-not-found" and describe the error in its message`,
+		`sentence_codes/sentence_codes.go:30:1: unexpected diagnostic: error code "storage-is-full" looks like a sentence: it contains the filler word "is"; prefer a short stable identifier like "storage-full" and describe the error in its message`,
+		`sentence_codes/sentence_codes.go:31:1: unexpected diagnostic: error code "storage-quota-exceeded-for-user-account" looks like a sentence: it has 39 characters, more than 24, it has 6 words, more than 4, it contains the filler word "for"; prefer a short stable identifier like "storage-quota-exceeded" and describe the error in its message`,
+		`sentence_codes/sentence_codes.go:39:1: unexpected diagnostic: error code "storage-unknown-error-while-reading-index-file" looks like a sentence: it has 46 characters, more than 24, it has 7 words, more than 4, it contains the filler word "while"; prefer a short stable identifier like "storage-unknown-error" and describe the error in its message`,
+	)
+}
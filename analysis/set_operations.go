@@ -1,7 +1,9 @@
 package analysis
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -11,6 +13,13 @@ func (set CodeSet) String() string {
 	return fmt.Sprintf("set[%s]", strings.Join(set.Slice(), " "))
 }
 
+// MarshalJSON encodes the set as sorted list of its values.
+func (set CodeSet) MarshalJSON() ([]byte, error) {
+	slice := set.Slice()
+	sort.Strings(slice)
+	return json.Marshal(slice)
+}
+
 // Set creates a Set using the provided values.
 func Set(values ...string) CodeSet {
 	return SliceToSet(values)
@@ -70,3 +79,15 @@ func Difference(set, subtrahend CodeSet) CodeSet {
 	}
 	return diff
 }
+
+// Intersection creates a new set containing the elements that appear in both input sets.
+// The input sets are not modified.
+func Intersection(set, other CodeSet) CodeSet {
+	result := make(CodeSet)
+	for value := range set {
+		if _, ok := other[value]; ok {
+			result[value] = struct{}{}
+		}
+	}
+	return result
+}
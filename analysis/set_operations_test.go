@@ -65,15 +65,15 @@ func TestSetAdd(t *testing.T) {
 }
 func TestUnionAndDifference(t *testing.T) {
 	tests := []struct {
-		a, b, union, difference CodeSet
+		a, b, union, difference, intersection CodeSet
 	}{
-		{Set("one"), Set("two"), Set("one", "two"), Set("one")},
-		{Set(), Set("one"), Set("one"), Set()},
-		{Set("one"), Set("one"), Set("one"), Set()},
-		{Set("one", "two"), Set("one", "two"), Set("one", "two"), Set()},
-		{Set("three", "one", "two"), Set("two", "one"), Set("one", "two", "three"), Set("three")},
-		{Set(), Set(), Set(), Set()},
-		{Set(), Set("one"), Set("one"), Set()},
+		{Set("one"), Set("two"), Set("one", "two"), Set("one"), Set()},
+		{Set(), Set("one"), Set("one"), Set(), Set()},
+		{Set("one"), Set("one"), Set("one"), Set(), Set("one")},
+		{Set("one", "two"), Set("one", "two"), Set("one", "two"), Set(), Set("one", "two")},
+		{Set("three", "one", "two"), Set("two", "one"), Set("one", "two", "three"), Set("three"), Set("one", "two")},
+		{Set(), Set(), Set(), Set(), Set()},
+		{Set(), Set("one"), Set("one"), Set(), Set()},
 	}
 
 	for _, test := range tests {
@@ -86,5 +86,9 @@ func TestUnionAndDifference(t *testing.T) {
 		if result := Union(test.a, test.b); !reflect.DeepEqual(test.union, result) {
 			t.Errorf("union(%s) should be %v but was %v", params, test.union, result)
 		}
+
+		if result := Intersection(test.a, test.b); !reflect.DeepEqual(test.intersection, result) {
+			t.Errorf("intersection(%s) should be %v but was %v", params, test.intersection, result)
+		}
 	}
 }
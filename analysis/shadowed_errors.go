@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// findShadowedErrors reports error variables declared in functions declaring error codes, which shadow another
+// error variable of the same function, e.g. the inner err in:
+//
+//	err := foo()
+//	if x {
+//		err := bar()
+//		_ = err
+//	}
+//	return err
+//
+// The codes of errors assigned to the inner variable do not reach the outer one, which is correct,
+// but often not intended. Inner variables, which are returned themselves, e.g. in `if err := bar(); err != nil { return err }`,
+// are not reported, as their codes are not lost.
+func findShadowedErrors(pass *analysis.Pass, funcClaims funcCodesMap) {
+	for funcDecl := range funcClaims {
+		if funcDecl.Body == nil {
+			continue
+		}
+
+		returned := findReturnedVariables(pass, funcDecl.Body)
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			ident, ok := node.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			inner, ok := pass.TypesInfo.Defs[ident].(*types.Var)
+			if !ok || !isErrorVariable(inner) || inner.Parent() == nil || inner.Parent().Parent() == nil {
+				return true
+			}
+			if _, ok := returned[inner]; ok {
+				return true
+			}
+
+			_, outerObject := inner.Parent().Parent().LookupParent(inner.Name(), ident.Pos())
+			outer, ok := outerObject.(*types.Var)
+			if !ok || !isErrorVariable(outer) || outer.Pos() < funcDecl.Pos() || funcDecl.End() <= outer.Pos() {
+				return true
+			}
+			report(pass, ident, MsgShadowedError, inner.Name(), pass.Fset.Position(outer.Pos()).Line)
+			return true
+		})
+	}
+}
+
+// isErrorVariable checks if the given variable is of an interface type implementing error.
+func isErrorVariable(variable *types.Var) bool {
+	return types.IsInterface(variable.Type()) && types.Implements(variable.Type(), tError)
+}
+
+// findReturnedVariables finds all variables used in the results of return statements in the given body.
+func findReturnedVariables(pass *analysis.Pass, body *ast.BlockStmt) map[*types.Var]struct{} {
+	result := map[*types.Var]struct{}{}
+	ast.Inspect(body, func(node ast.Node) bool {
+		returnStmt, ok := node.(*ast.ReturnStmt)
+		if !ok {
+			return true
+		}
+		for _, expr := range returnStmt.Results {
+			ast.Inspect(expr, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok {
+					if variable, ok := pass.TypesInfo.Uses[ident].(*types.Var); ok {
+						result[variable] = struct{}{}
+					}
+				}
+				return true
+			})
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,114 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isPackageWithoutErrors checks if the package of the given pass can be skipped by the analysis,
+// because none of its declarations and expressions can carry error codes.
+//
+// This is a cheap pre-pass for packages like plain utilities or generated data types,
+// which make up a large part of huge repositories:
+// a package is only skipped if no comment declares error codes ("Errors:") or annotates them ("Error Codes:"),
+// and no type used in the package is an error or refers to errors,
+// e.g. functions returning errors, error types, error containers or interfaces with methods returning errors.
+// Such packages neither export facts nor report diagnostics, so the result is the same as for a full analysis.
+func isPackageWithoutErrors(pass *analysis.Pass) bool {
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			text := group.Text()
+			if strings.Contains(text, "Errors") || strings.Contains(text, annotationIndicatorReturnStmt) {
+				return false
+			}
+		}
+	}
+
+	seen := map[types.Type]bool{}
+	for _, obj := range pass.TypesInfo.Defs {
+		if obj != nil && mentionsErrors(seen, obj.Type()) {
+			return false
+		}
+	}
+	for _, obj := range pass.TypesInfo.Uses {
+		if mentionsErrors(seen, obj.Type()) {
+			return false
+		}
+	}
+	for expr, tv := range pass.TypesInfo.Types {
+		if _, ok := expr.(*ast.Ident); ok {
+			continue // already covered by Defs and Uses
+		}
+		if mentionsErrors(seen, tv.Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mentionsErrors checks if the given type is an error or refers to errors in its structure, its signature or its methods.
+// The given map caches the results of visited types and also protects against recursive types.
+func mentionsErrors(seen map[types.Type]bool, typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if result, ok := seen[typ]; ok {
+		return result
+	}
+	seen[typ] = false
+
+	result := func() bool {
+		if types.Implements(typ, tError) || types.Implements(types.NewPointer(typ), tError) || isErrorContainer(typ) {
+			return true
+		}
+
+		// Methods may return errors, e.g. methods of interfaces declaring error codes.
+		for _, methodSet := range []*types.MethodSet{types.NewMethodSet(typ), types.NewMethodSet(types.NewPointer(typ))} {
+			for i := 0; i < methodSet.Len(); i++ {
+				if mentionsErrors(seen, methodSet.At(i).Type()) {
+					return true
+				}
+			}
+		}
+
+		switch typ := typ.Underlying().(type) {
+		case *types.Signature:
+			return mentionsErrorsInTuple(seen, typ.Params()) || mentionsErrorsInTuple(seen, typ.Results())
+		case *types.Pointer:
+			return mentionsErrors(seen, typ.Elem())
+		case *types.Slice:
+			return mentionsErrors(seen, typ.Elem())
+		case *types.Array:
+			return mentionsErrors(seen, typ.Elem())
+		case *types.Chan:
+			return mentionsErrors(seen, typ.Elem())
+		case *types.Map:
+			return mentionsErrors(seen, typ.Key()) || mentionsErrors(seen, typ.Elem())
+		case *types.Struct:
+			for i := 0; i < typ.NumFields(); i++ {
+				if mentionsErrors(seen, typ.Field(i).Type()) {
+					return true
+				}
+			}
+		case *types.Tuple:
+			return mentionsErrorsInTuple(seen, typ)
+		}
+		return false
+	}()
+
+	seen[typ] = result
+	return result
+}
+
+func mentionsErrorsInTuple(seen map[types.Type]bool, tuple *types.Tuple) bool {
+	for i := 0; i < tuple.Len(); i++ {
+		if mentionsErrors(seen, tuple.At(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
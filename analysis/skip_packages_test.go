@@ -0,0 +1,25 @@
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// skipPackagesAnalyzer reports at the package clause, if the package can be skipped by the serum analyzer.
+var skipPackagesAnalyzer = &analysis.Analyzer{
+	Name: "skippackages",
+	Doc:  "Test analyzer reporting packages skipped by the serum analyzer.",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		if isPackageWithoutErrors(pass) {
+			pass.Reportf(pass.Files[0].Name.Pos(), "package without errors")
+		}
+		return nil, nil
+	},
+}
+
+func TestSkipPackages(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), skipPackagesAnalyzer,
+		"skip_packages/plain", "skip_packages/functions", "skip_packages/callbacks", "skip_packages/docs")
+}
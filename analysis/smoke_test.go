@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+)
+
+var stdlibSmoke = flag.Bool("stdlib", false, "run the analyzer on the standard library, only checking for crashes")
+
+// crashCollector forwards failures of the analysis itself (loading errors, analyzer errors),
+// while dropping all complaints about diagnostics and facts, which are expected on foreign code.
+type crashCollector struct {
+	t *testing.T
+}
+
+func (c crashCollector) Errorf(format string, args ...interface{}) {
+	if strings.HasPrefix(format, "loading ") || strings.HasPrefix(format, "error analyzing ") {
+		c.t.Errorf(format, args...)
+	}
+}
+
+// TestStdlibSmoke runs the analyzer on every package of the standard library.
+// Diagnostics are ignored: the test fails only if the analyzer panics, errors, or runs into the test timeout.
+//
+// Packages are analysed one at a time, because loading the whole standard library with syntax at once
+// requires more memory than usually available on CI machines.
+//
+// The test is skipped unless the -stdlib flag is given:
+//
+//    go test ./analysis -run TestStdlibSmoke -stdlib -timeout 30m
+//
+// Run it with Go 1.17, as the pinned golang.org/x/tools can not load newer standard libraries.
+func TestStdlibSmoke(t *testing.T) {
+	if !*stdlibSmoke {
+		t.Skip("skipping standard library smoke test: enable with -stdlib")
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, "std")
+	if err != nil {
+		t.Fatalf("listing standard library packages: %v", err)
+	}
+
+	Analyzer.Flags.Set("strict", "true")
+	dir := analysistest.TestData()
+	for _, pkg := range pkgs {
+		if strings.HasPrefix(pkg.PkgPath, "vendor/") {
+			continue // Vendored packages can not be loaded by their import path.
+		}
+
+		t.Run(pkg.PkgPath, func(t *testing.T) {
+			analysistest.Run(crashCollector{t}, dir, Analyzer, pkg.PkgPath)
+		})
+	}
+}
@@ -0,0 +1,585 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	engineAST = "ast"
+	engineSSA = "ssa"
+)
+
+// checkEngine checks the value of the -engine flag.
+func checkEngine() error {
+	switch cliArguments.engine {
+	case engineAST, engineSSA:
+		return nil
+	}
+	return fmt.Errorf("-engine: unknown engine %q, should be %q or %q", cliArguments.engine, engineAST, engineSSA)
+}
+
+// ssaEngine finds the error codes of functions on the SSA form of the package, which is built when -engine=ssa is set.
+//
+// On SSA form every value has a single definition, so branches are joined by phi nodes instead of reassignments,
+// function literals are called through closures that bind the captured variables, and stores to fields
+// are tied to the allocation of their struct. This makes the flow of errors precise,
+// e.g. a field of one local struct does not get the errors stored into the same field of another struct,
+// and errors assigned to named results by deferred function literals are found.
+//
+// The engine only covers a subset of the language. It does not report diagnostics itself:
+// whenever a function contains anything the engine does not support, e.g. errors from parameters,
+// annotations, error containers or calls of functions without known error codes, the function is analysed by the AST engine instead,
+// which reports the same diagnostics as without the flag.
+type ssaEngine struct {
+	pass    *analysis.Pass
+	program *ssa.Program
+	claims  map[types.Object]funcCodes    // claims of the analysed functions of the package
+	calls   map[token.Pos]*ast.CallExpr   // calls of the package by the position of their opening parenthesis
+	funcs   map[token.Pos]*funcDefinition // definitions of the functions of the package by their position, see funcDefinition
+
+	cache  map[*ssa.Function]CodeSet
+	stack  map[*ssa.Function]int // functions currently analysed with their depth, to stop at recursive calls
+	lowest int                   // lowest depth of a function on the stack, which has been called recursively
+}
+
+// newSSAEngine builds the SSA form of the package of the given pass.
+// Imported packages are only created from their types, as their functions are covered by facts.
+//
+// Returns nil if there are no claims to verify, or if the SSA form can not be built,
+// e.g. for packages with cgo, in which case all functions are analysed by the AST engine.
+func newSSAEngine(pass *analysis.Pass, funcClaims funcCodesMap) (engine *ssaEngine) {
+	if len(funcClaims) == 0 {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			engine = nil
+		}
+	}()
+
+	program := ssa.NewProgram(pass.Fset, 0)
+
+	created := map[*types.Package]bool{}
+	var createAll func(packages []*types.Package)
+	createAll = func(packages []*types.Package) {
+		for _, pkg := range packages {
+			if !created[pkg] {
+				created[pkg] = true
+				program.CreatePackage(pkg, nil, nil, true)
+				createAll(pkg.Imports())
+			}
+		}
+	}
+	createAll(pass.Pkg.Imports())
+
+	program.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false).Build()
+
+	claims := map[types.Object]funcCodes{}
+	for funcDecl, codes := range funcClaims {
+		if obj := pass.TypesInfo.Defs[funcDecl.Name]; obj != nil {
+			claims[obj] = codes
+		}
+	}
+
+	calls := map[token.Pos]*ast.CallExpr{}
+	funcs := map[token.Pos]*funcDefinition{}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				calls[node.Lparen] = node
+			case *ast.FuncDecl:
+				funcs[node.Name.Pos()] = &funcDefinition{node, nil}
+			case *ast.FuncLit:
+				funcs[node.Type.Func] = &funcDefinition{nil, node}
+			}
+			return true
+		})
+	}
+
+	return &ssaEngine{
+		pass:    pass,
+		program: program,
+		claims:  claims,
+		calls:   calls,
+		funcs:   funcs,
+		cache:   map[*ssa.Function]CodeSet{},
+		stack:   map[*ssa.Function]int{},
+	}
+}
+
+// codesOf finds the error codes returned by the given function.
+// The second result is false, if the function is not supported by the engine and has to be analysed by the AST engine.
+func (e *ssaEngine) codesOf(funcDecl *ast.FuncDecl) (CodeSet, bool) {
+	// Error codes from constructor, union and pass-through parameters are only known at the call site.
+	if claims, ok := e.claims[e.pass.TypesInfo.Defs[funcDecl.Name]]; !ok || claims.param != nil || len(claims.passthrough) > 0 {
+		return nil, false
+	}
+
+	obj, ok := e.pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	if !ok || isGenericSignature(obj.Type().(*types.Signature)) {
+		return nil, false
+	}
+
+	fn := e.program.FuncValue(obj)
+	if fn == nil {
+		return nil, false
+	}
+	return e.codesOfFunction(fn)
+}
+
+// codesOfFunction finds the error codes returned by the error results of the given function.
+func (e *ssaEngine) codesOfFunction(fn *ssa.Function) (CodeSet, bool) {
+	if codes, ok := e.cache[fn]; ok {
+		return codes, true
+	}
+	if depth, ok := e.stack[fn]; ok {
+		// The codes of a recursive call are the codes found in the rest of the function.
+		if depth < e.lowest {
+			e.lowest = depth
+		}
+		return Set(), true
+	}
+
+	function := e.funcDefinition(fn)
+	if fn.Blocks == nil || function == nil || hasReturnStmtAnnotations(e.pass, function) || len(findDeferredRecoverCalls(e.pass, function)) > 0 {
+		return nil, false
+	}
+
+	var errorResults []int
+	results := fn.Signature.Results()
+	for i := 0; i < results.Len(); i++ {
+		typ := results.At(i).Type()
+		if types.Implements(typ, tError) {
+			errorResults = append(errorResults, i)
+		} else if isErrorContainer(typ) || isErrorIterator(typ) {
+			return nil, false
+		}
+	}
+
+	depth := len(e.stack)
+	e.stack[fn] = depth
+	outer := e.lowest
+	e.lowest = depth
+
+	result, ok := e.codesOfReturns(fn, function, errorResults)
+
+	delete(e.stack, fn)
+	if ok && e.lowest >= depth {
+		e.cache[fn] = result
+	}
+	if outer < e.lowest {
+		e.lowest = outer
+	}
+	return result, ok
+}
+
+func (e *ssaEngine) codesOfReturns(fn *ssa.Function, function *funcDefinition, errorResults []int) (CodeSet, bool) {
+	result := Set()
+	visited := map[ssa.Value]bool{}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			ret, ok := instr.(*ssa.Return)
+			if !ok || isUnreachablePos(e.pass, function, ret.Pos()) {
+				continue
+			}
+
+			for _, i := range errorResults {
+				codes, ok := e.codesOfValue(ret.Results[i], visited)
+				if !ok {
+					return nil, false
+				}
+				result = Union(result, codes)
+			}
+		}
+	}
+	return result, true
+}
+
+// codesOfValue finds the error codes of the given error value.
+// Values are only visited once, which ends the search at loops of phi nodes.
+func (e *ssaEngine) codesOfValue(value ssa.Value, visited map[ssa.Value]bool) (CodeSet, bool) {
+	if visited[value] {
+		return Set(), true
+	}
+	visited[value] = true
+
+	switch value := value.(type) {
+	case *ssa.Const:
+		return e.codesOfConst(value)
+	case *ssa.MakeInterface:
+		return e.codesOfValue(value.X, visited)
+	case *ssa.ChangeInterface:
+		return e.codesOfValue(value.X, visited)
+	case *ssa.ChangeType:
+		return e.codesOfValue(value.X, visited)
+	case *ssa.Phi:
+		result := Set()
+		for _, edge := range value.Edges {
+			codes, ok := e.codesOfValue(edge, visited)
+			if !ok {
+				return nil, false
+			}
+			result = Union(result, codes)
+		}
+		return result, true
+	case *ssa.Call:
+		return e.codesOfCall(value.Common())
+	case *ssa.Extract:
+		if call, ok := value.Tuple.(*ssa.Call); ok {
+			return e.codesOfCall(call.Common())
+		}
+	case *ssa.Alloc:
+		return e.codesOfErrorAlloc(value)
+	case *ssa.UnOp:
+		if value.Op == token.MUL {
+			return e.codesOfLoad(value.X, visited)
+		}
+	case *ssa.Field:
+		return e.declaredFieldCodes(value.X.Type(), value.Field)
+	}
+	return nil, false
+}
+
+// codesOfConst finds the error codes of a constant, which is either nil or of an error type based on a string.
+// A nil pointer of an error type has the error codes of its type.
+func (e *ssaEngine) codesOfConst(value *ssa.Const) (CodeSet, bool) {
+	errorType := e.errorTypeOf(value.Type())
+	if value.IsNil() {
+		if errorType == nil {
+			return Set(), true
+		}
+		return SliceToSet(errorType.Codes), true
+	}
+
+	if errorType == nil || errorType.Field != nil {
+		return nil, false
+	}
+
+	result := SliceToSet(errorType.Codes)
+	if errorType.Underlying {
+		code, ok := getSSAErrorCode(value)
+		if !ok {
+			return nil, false
+		}
+		result.Add(code)
+	}
+	return result, true
+}
+
+// codesOfErrorAlloc finds the error codes of an allocated error,
+// which are the codes of its error type and the constant codes stored into its error code field.
+func (e *ssaEngine) codesOfErrorAlloc(alloc *ssa.Alloc) (CodeSet, bool) {
+	errorType := e.errorTypeOf(alloc.Type())
+	if errorType == nil {
+		return nil, false
+	}
+
+	result := SliceToSet(errorType.Codes)
+	stored := false
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.FieldAddr:
+			if errorType.Field == nil || ref.Field != errorType.Field.Position {
+				continue
+			}
+			for _, fieldRef := range *ref.Referrers() {
+				switch fieldRef := fieldRef.(type) {
+				case *ssa.Store:
+					code, ok := getSSAErrorCode(fieldRef.Val)
+					if !ok {
+						return nil, false
+					}
+					result.Add(code)
+					stored = true
+				case *ssa.UnOp, *ssa.DebugRef:
+				default:
+					return nil, false
+				}
+			}
+		case *ssa.Store:
+			if ref.Addr == alloc {
+				return nil, false // the whole error is overwritten
+			}
+		case *ssa.UnOp, *ssa.MakeInterface, *ssa.ChangeType, *ssa.Phi, *ssa.Return, *ssa.DebugRef:
+		default:
+			// e.g. passed to a call, which might set the error code
+			return nil, false
+		}
+	}
+
+	// Errors without any code are reported by the AST engine.
+	if errorType.Field != nil && len(errorType.Codes) == 0 && !stored {
+		return nil, false
+	}
+	return result, true
+}
+
+// codesOfLoad finds the error codes of the value loaded from the given address,
+// which is either a local variable or a field of a local struct.
+func (e *ssaEngine) codesOfLoad(addr ssa.Value, visited map[ssa.Value]bool) (CodeSet, bool) {
+	switch addr := addr.(type) {
+	case *ssa.Alloc:
+		if e.errorTypeOf(addr.Type()) != nil {
+			return e.codesOfErrorAlloc(addr)
+		}
+		return e.codesOfStores(addr, visited)
+	case *ssa.FieldAddr:
+		if codes, ok := e.declaredFieldCodes(addr.X.Type(), addr.Field); ok {
+			return codes, true
+		}
+		if alloc, ok := addr.X.(*ssa.Alloc); ok {
+			return e.codesOfFieldStores(alloc, addr.Field, visited)
+		}
+	}
+	return nil, false
+}
+
+// declaredFieldCodes returns the error codes declared in the docstring of the field at the given index
+// of the given struct type or pointer to a struct type.
+func (e *ssaEngine) declaredFieldCodes(typ types.Type, index int) (CodeSet, bool) {
+	if pointer, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok || index >= structType.NumFields() {
+		return nil, false
+	}
+	return importErrorFieldCodes(e.pass, structType.Field(index))
+}
+
+// codesOfStores finds the error codes of all values stored into the given variable,
+// including the stores of function literals which capture the variable.
+func (e *ssaEngine) codesOfStores(addr ssa.Value, visited map[ssa.Value]bool) (CodeSet, bool) {
+	result := Set()
+	for _, ref := range *addr.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.Store:
+			if ref.Addr != addr {
+				return nil, false // the address of the variable is stored somewhere else
+			}
+			codes, ok := e.codesOfValue(ref.Val, visited)
+			if !ok {
+				return nil, false
+			}
+			result = Union(result, codes)
+		case *ssa.MakeClosure:
+			fn, ok := ref.Fn.(*ssa.Function)
+			if !ok {
+				return nil, false
+			}
+			for i, binding := range ref.Bindings {
+				if binding != addr {
+					continue
+				}
+				codes, ok := e.codesOfStores(fn.FreeVars[i], visited)
+				if !ok {
+					return nil, false
+				}
+				result = Union(result, codes)
+			}
+		case *ssa.UnOp, *ssa.DebugRef:
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// codesOfFieldStores finds the error codes of all values stored into the field with the given index of the given struct.
+func (e *ssaEngine) codesOfFieldStores(alloc *ssa.Alloc, field int, visited map[ssa.Value]bool) (CodeSet, bool) {
+	result := Set()
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.FieldAddr:
+			if ref.Field != field {
+				continue
+			}
+			for _, fieldRef := range *ref.Referrers() {
+				switch fieldRef := fieldRef.(type) {
+				case *ssa.Store:
+					codes, ok := e.codesOfValue(fieldRef.Val, visited)
+					if !ok {
+						return nil, false
+					}
+					result = Union(result, codes)
+				case *ssa.UnOp, *ssa.DebugRef:
+				default:
+					return nil, false
+				}
+			}
+		case *ssa.Store:
+			if _, ok := ref.Val.(*ssa.Const); ref.Addr != alloc || !ok {
+				return nil, false // the struct is stored somewhere else, or overwritten with a value which is not the zero value
+			}
+		case *ssa.UnOp, *ssa.DebugRef:
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// codesOfCall finds the error codes returned by the given call.
+func (e *ssaEngine) codesOfCall(call *ssa.CallCommon) (CodeSet, bool) {
+	if call.IsInvoke() {
+		fact := new(ErrorCodes)
+		if e.pass.ImportObjectFact(call.Method, fact) {
+			return fact.Codes, true
+		}
+		return nil, false
+	}
+
+	callee := call.StaticCallee()
+	if callee == nil {
+		return nil, false
+	}
+
+	obj, ok := callee.Object().(*types.Func)
+	if !ok {
+		if callee.Parent() == nil {
+			return nil, false // e.g. a wrapper for a method value
+		}
+		return e.codesOfFunction(callee)
+	}
+	if isGenericSignature(obj.Type().(*types.Signature)) {
+		return nil, false
+	}
+
+	if e.pass.ImportObjectFact(obj, new(ErrorUnion)) || e.pass.ImportObjectFact(obj, new(ErrorPassthrough)) {
+		return nil, false
+	}
+	if isErrorGroupWait(e.pass, obj) {
+		return nil, false // The codes of the submitted functions are left to the AST engine.
+	}
+
+	if callExpr := e.calls[call.Pos()]; callExpr != nil && hasCodeOptionArgs(e.pass, callExpr) {
+		return nil, false // The codes set by functional options are left to the AST engine.
+	}
+
+	declared, ok := e.declaredCodes(obj)
+	if !ok {
+		if obj.Pkg() == e.pass.Pkg {
+			return e.codesOfFunction(callee)
+		}
+		if isOpaquePackage(obj.Pkg()) {
+			return Set(), true
+		}
+		return nil, false
+	}
+
+	constructor := new(ErrorConstructor)
+	if e.pass.ImportObjectFact(obj, constructor) {
+		code, ok := e.constructorCode(call, constructor)
+		if !ok {
+			return nil, false
+		}
+		return Union(declared, Set(code)), true
+	}
+	return declared, true
+}
+
+// declaredCodes returns the error codes declared by the given function.
+// Error codes of functions of the package are only exported as facts after the analysis, so they are taken from the claims.
+func (e *ssaEngine) declaredCodes(obj *types.Func) (CodeSet, bool) {
+	if obj.Pkg() == e.pass.Pkg {
+		claims, ok := e.claims[obj]
+		return claims.codes, ok
+	}
+
+	fact := new(ErrorCodes)
+	if e.pass.ImportObjectFact(obj, fact) {
+		return fact.Codes, true
+	}
+	return nil, false
+}
+
+// constructorCode returns the error code passed to the error constructor of the given call.
+//
+// The code is taken from the syntax of the call, so only constant expressions are accepted.
+// Calls passing a local variable are left to the AST engine, which follows the assignments of the variable.
+func (e *ssaEngine) constructorCode(call *ssa.CallCommon, constructor *ErrorConstructor) (string, bool) {
+	callExpr := e.calls[call.Pos()]
+	if callExpr == nil {
+		return "", false
+	}
+
+	position := constructor.CodeParamPosition
+	if isMethodExpression(e.pass, callExpr.Fun) {
+		position++ // The receiver is passed as first argument, e.g. `(*T).New(t, "code")`.
+	}
+	if position >= len(callExpr.Args) {
+		return "", false
+	}
+
+	value := getConstantValue(e.pass, callExpr.Args[position])
+	if value == nil || value.Kind() != constant.String || constant.StringVal(value) == "" {
+		return "", false
+	}
+	code, err := getErrorCodeFromConstant(constant.MakeString(constructor.Prefix + constant.StringVal(value)))
+	return code, err == nil
+}
+
+// errorTypeOf returns the ErrorType fact of the given type, or nil if it is not an error type.
+func (e *ssaEngine) errorTypeOf(typ types.Type) *ErrorType {
+	if getNamedType(typ) == nil {
+		return nil
+	}
+
+	errorType, err := getErrorTypeForError(e.pass, typ)
+	if err != nil {
+		return nil
+	}
+	return errorType
+}
+
+// getSSAErrorCode returns the error code of the given value, if it is a constant with a valid error code.
+// Invalid codes are left to the AST engine, which reports them.
+func getSSAErrorCode(value ssa.Value) (string, bool) {
+	c, ok := value.(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	code, err := getErrorCodeFromConstant(c.Value)
+	return code, err == nil && code != ""
+}
+
+// funcDefinition returns the definition of the given function, or nil if it has no syntax, e.g. because it is synthetic.
+//
+// Functions are found by their position, which is the name of declared functions and the "func" keyword of literals,
+// as the SSA form only keeps the syntax of functions after they are built in debug mode.
+func (e *ssaEngine) funcDefinition(fn *ssa.Function) *funcDefinition {
+	if fn.Synthetic != "" {
+		return nil // e.g. wrappers of methods, which have the position of the wrapped method
+	}
+	return e.funcs[fn.Pos()]
+}
+
+// hasReturnStmtAnnotations checks if the body of the given function contains "Error Codes" annotations.
+func hasReturnStmtAnnotations(pass *analysis.Pass, function *funcDefinition) bool {
+	body := function.body()
+	if body == nil {
+		return false
+	}
+
+	for _, file := range pass.Files {
+		if file.Pos() > body.Pos() || body.End() > file.End() {
+			continue
+		}
+		for _, group := range file.Comments {
+			if body.Pos() <= group.Pos() && group.End() <= body.End() && strings.Contains(group.Text(), annotationIndicatorReturnStmt) {
+				return true
+			}
+		}
+	}
+	return false
+}
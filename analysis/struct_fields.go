@@ -0,0 +1,253 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// findErrorCodesInFieldSelection finds error codes of an error stored in a struct field,
+// e.g. `return s.err` in a method of a singleton that guards the field with a mutex.
+//
+// Fields declaring error codes in their docstring result in the declared codes, see exportErrorFieldFacts.
+// Otherwise, the result is the union of the error codes of all values written to the field anywhere in the package.
+// This is only possible if all writes are visible, so the field has to be an unexported field of a type in the current package,
+// and its address may not be taken.
+func findErrorCodesInFieldSelection(c *context, visitedIdents map[*ast.Object]struct{}, expr *ast.SelectorExpr, function *funcDefinition) CodeSet {
+	pass := c.pass
+
+	selection, ok := pass.TypesInfo.Selections[expr]
+	if !ok || selection.Kind() != types.FieldVal {
+		report(pass, expr, MsgUnsupportedExpression)
+		return nil
+	}
+
+	// Fields of result structs carry the error codes of the result struct, e.g. `Lookup(key).Err` or `result.Err`.
+	if cliArguments.resultStructs && isResultStruct(pass.TypesInfo.TypeOf(expr.X)) {
+		switch x := astutil.Unparen(expr.X).(type) {
+		case *ast.CallExpr, *ast.Ident:
+			return findErrorCodesInErrorContainer(c, visitedIdents, x, function)
+		}
+	}
+
+	field := selection.Obj().(*types.Var)
+	if codes, ok := importErrorFieldCodes(pass, field); ok {
+		return codes
+	}
+
+	if field.Pkg() != pass.Pkg {
+		report(pass, expr, MsgFieldNotInPackage, field.Name())
+		return nil
+	}
+
+	if field.Exported() {
+		report(pass, expr, MsgFieldExported, field.Name())
+		return nil
+	}
+
+	assigned, ok := c.assignedValues[field]
+	if !ok {
+		assigned = findValuesAssignedToField(c, field)
+		c.assignedValues[field] = assigned
+	}
+
+	return findErrorCodesInAssignedValues(c, visitedIdents, assigned, function)
+}
+
+// findValuesAssignedToField finds all expressions that are written to the given struct field,
+// either by assignments to the field or by struct literals.
+//
+// For any write to the field that can not be analysed, a diagnostic is emitted.
+func findValuesAssignedToField(c *context, field *types.Var) *assignedValues {
+	pass := c.pass
+
+	isField := func(expr ast.Expr) bool {
+		selector, ok := astutil.Unparen(expr).(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		selection, ok := pass.TypesInfo.Selections[selector]
+		return ok && selection.Obj() == field
+	}
+
+	result := &assignedValues{object: ast.NewObj(ast.Var, field.Name())}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				for i, lhsEntry := range node.Lhs {
+					if !isField(lhsEntry) {
+						continue
+					}
+					if len(node.Lhs) == len(node.Rhs) {
+						result.values = append(result.values, node.Rhs[i])
+					} else {
+						report(pass, node.Rhs[0], MsgFieldAssignedCallResult, field.Name())
+					}
+				}
+			case *ast.CompositeLit:
+				if value := findFieldValueInCompositeLit(c, node, field); value != nil {
+					result.values = append(result.values, value)
+				}
+			case *ast.UnaryExpr:
+				if node.Op == token.AND && isField(node.X) {
+					report(pass, node, MsgFieldAddressTaken, field.Name())
+				}
+			}
+			return true
+		})
+	}
+
+	return result
+}
+
+// findFieldValueInCompositeLit returns the value for the given field in a struct literal, or nil if no value is set for the field.
+func findFieldValueInCompositeLit(c *context, literal *ast.CompositeLit, field *types.Var) ast.Expr {
+	pass := c.pass
+
+	structType, ok := getUnderlyingType(pass.TypesInfo.TypeOf(literal)).(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	for i, element := range literal.Elts {
+		if keyValue, ok := element.(*ast.KeyValueExpr); ok {
+			key, ok := keyValue.Key.(*ast.Ident)
+			if ok && pass.TypesInfo.ObjectOf(key) == field {
+				return keyValue.Value
+			}
+		} else if i < structType.NumFields() && structType.Field(i) == field {
+			return element
+		}
+	}
+	return nil
+}
+
+// exportErrorFieldFacts exports the error codes declared in the docstring of error fields of the given struct
+// as ErrorCodes fact of the fields, e.g. for `lastErr error` with a docstring declaring the codes that may be stored in it.
+//
+// Reading such a field results in the declared codes, so it may also be exported and read in other packages.
+// Instead, the errors written to the field are checked against the declared codes, see findWritesViolatingErrorFieldDocs.
+func exportErrorFieldFacts(pass *analysis.Pass, structType *ast.StructType) {
+	for _, field := range structType.Fields.List {
+		if nestedStruct, ok := field.Type.(*ast.StructType); ok {
+			exportErrorFieldFacts(pass, nestedStruct)
+			continue
+		}
+
+		fieldType := pass.TypesInfo.TypeOf(field.Type)
+		if fieldType == nil || !types.Implements(fieldType, tError) {
+			continue
+		}
+
+		codes, ok, err := findFuncContractDocs(pass, field.Doc)
+		if err != nil {
+			reportError(pass, field, err)
+			continue
+		} else if !ok {
+			continue
+		}
+
+		for _, name := range field.Names {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[name], &ErrorCodes{codes})
+		}
+	}
+}
+
+// importErrorFieldCodes imports the error codes declared in the docstring of the given error field.
+// The returned set is a copy, which may be modified by the caller.
+func importErrorFieldCodes(pass *analysis.Pass, field *types.Var) (CodeSet, bool) {
+	if !field.IsField() || !types.Implements(field.Type(), tError) {
+		return nil, false
+	}
+
+	var fact ErrorCodes
+	if !pass.ImportObjectFact(field, &fact) {
+		return nil, false
+	}
+	return Union(fact.Codes, nil), true
+}
+
+// findWritesViolatingErrorFieldDocs checks all errors written to error fields with declared error codes in functions of the package,
+// either by assignments to the field or by struct literals. Their error codes have to be covered by the declared codes.
+//
+// Writes in the initial values of package-level variables are not checked, as they are not analysed within a function.
+func findWritesViolatingErrorFieldDocs(c *context) {
+	pass := c.pass
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			function := &funcDefinition{funcDecl, nil}
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				switch node := node.(type) {
+				case *ast.AssignStmt:
+					for i, lhsEntry := range node.Lhs {
+						field, codes, ok := findSelectedErrorField(pass, lhsEntry)
+						if !ok {
+							continue
+						}
+						if len(node.Lhs) != len(node.Rhs) {
+							report(pass, node.Rhs[0], MsgFieldAssignedCallResult, field.Name())
+							continue
+						}
+						checkErrorFieldWrite(c, function, field, codes, node.Rhs[i])
+					}
+				case *ast.CompositeLit:
+					structType, ok := getUnderlyingType(pass.TypesInfo.TypeOf(node)).(*types.Struct)
+					if !ok {
+						return true
+					}
+					for i := 0; i < structType.NumFields(); i++ {
+						field := structType.Field(i)
+						if codes, ok := importErrorFieldCodes(pass, field); ok {
+							if value := findFieldValueInCompositeLit(c, node, field); value != nil {
+								checkErrorFieldWrite(c, function, field, codes, value)
+							}
+						}
+					}
+				case *ast.UnaryExpr:
+					if field, _, ok := findSelectedErrorField(pass, node.X); ok && node.Op == token.AND {
+						report(pass, node, MsgFieldAddressTaken, field.Name())
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// findSelectedErrorField returns the error field with declared error codes, which is selected by the given expression, e.g. `c.lastErr`.
+func findSelectedErrorField(pass *analysis.Pass, expr ast.Expr) (*types.Var, CodeSet, bool) {
+	selector, ok := astutil.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil, nil, false
+	}
+
+	field := selection.Obj().(*types.Var)
+	codes, ok := importErrorFieldCodes(pass, field)
+	return field, codes, ok
+}
+
+// checkErrorFieldWrite checks if the error codes of the given value, written to the given field in the given function,
+// are covered by the error codes declared for the field.
+func checkErrorFieldWrite(c *context, function *funcDefinition, field *types.Var, declaredCodes CodeSet, value ast.Expr) {
+	foundCodes := findErrorCodesInExpression(c, map[*ast.Object]struct{}{}, value, function)
+	if undeclared := findUncoveredCodes(foundCodes, declaredCodes); len(undeclared) > 0 {
+		codes := undeclared.Slice()
+		sort.Strings(codes)
+		report(c.pass, value, MsgFieldCodesNotDeclared, field.Name(), codes)
+	}
+}
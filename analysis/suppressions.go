@@ -0,0 +1,173 @@
+package analysis
+
+import (
+	"encoding/json"
+	"go/ast"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Kinds of suppressions listed in the suppressions report.
+const (
+	suppressionOverwrite      = "overwrite"       // annotation "Error Codes = ..."
+	suppressionModify         = "modify"          // annotations "Error Codes += ...", "Error Codes -= ..." and "Error Codes +code -code"
+	suppressionOpaquePackage  = "opaque-package"  // package listed in the -opaque-packages flag
+	suppressionTrustedPackage = "trusted-package" // package listed in the -trusted-packages flag
+)
+
+// suppression is an entry of the suppressions report: a place where the result of the analysis is overruled.
+type suppression struct {
+	Kind     string   `json:"kind"`
+	Package  string   `json:"package"`
+	Function string   `json:"function,omitempty"`
+	Position string   `json:"position,omitempty"`
+	Codes    []string `json:"codes,omitempty"`   // codes of an overwrite annotation
+	Added    []string `json:"added,omitempty"`   // codes added by an annotation
+	Removed  []string `json:"removed,omitempty"` // codes removed by an annotation
+	Reason   string   `json:"reason,omitempty"`  // further lines of the comment containing the annotation
+}
+
+// suppressions are collected over all packages analysed in this process, like the metrics,
+// so governance tooling can track and expire them without parsing the source code.
+var suppressions = struct {
+	sync.Mutex
+	packages map[string][]suppression // key: path of the analysed package
+}{packages: map[string][]suppression{}}
+
+// reportSuppressions writes all suppressions of the analysed packages to the suppressions file, if one is given.
+// The file is replaced after every package and contains the suppressions of all packages analysed so far.
+func reportSuppressions(c *context) error {
+	if cliArguments.suppressionsFile == "" {
+		return nil
+	}
+
+	found := findSuppressions(c)
+
+	suppressions.Lock()
+	defer suppressions.Unlock()
+
+	suppressions.packages[c.pass.Pkg.Path()] = found
+
+	content, err := formatSuppressions()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(cliArguments.suppressionsFile, content)
+}
+
+// findSuppressions finds all annotations of return statements in the analysed package.
+// Invalid annotations are skipped, they are reported where the annotated functions are analysed.
+func findSuppressions(c *context) []suppression {
+	// Use a copy of the pass that drops all diagnostics, as the annotations are reported by the analysis itself.
+	pass := *c.pass
+	pass.Report = func(analysis.Diagnostic) {}
+	silent := *c
+	silent.pass = &pass
+
+	var result []suppression
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				stmt, ok := node.(*ast.ReturnStmt)
+				if !ok {
+					return true
+				}
+
+				annotations := getReturnStmtAnnotations(&silent, stmt)
+				if annotations == nil {
+					return true
+				}
+
+				entry := suppression{
+					Kind:     suppressionModify,
+					Package:  pass.Pkg.Path(),
+					Function: funcDecl.Name.Name,
+					Position: pass.Fset.Position(stmt.Pos()).String(),
+					Added:    sortedCodes(annotations.addCodes),
+					Removed:  sortedCodes(annotations.subCodes),
+					Reason:   findSuppressionReason(c.comments[stmt]),
+				}
+				if annotations.shouldOverwrite {
+					entry.Kind = suppressionOverwrite
+					entry.Codes = sortedCodes(annotations.overwrite)
+				}
+				result = append(result, entry)
+				return true
+			})
+		}
+	}
+
+	return result
+}
+
+// findSuppressionReason joins all lines of the comment containing the annotation, which are not the annotation itself.
+func findSuppressionReason(groups []*ast.CommentGroup) string {
+	for _, group := range groups {
+		var reason []string
+		annotated := false
+		for _, line := range strings.Split(group.Text(), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, annotationIndicatorReturnStmt) {
+				annotated = true
+			} else if line != "" {
+				reason = append(reason, line)
+			}
+		}
+		if annotated {
+			return strings.Join(reason, " ")
+		}
+	}
+	return ""
+}
+
+// formatSuppressions encodes the collected suppressions as JSON, sorted by package and position,
+// followed by the packages listed in the -opaque-packages and -trusted-packages flags.
+//
+// The caller has to hold the lock of the suppressions.
+func formatSuppressions() ([]byte, error) {
+	packages := make([]string, 0, len(suppressions.packages))
+	for pkg := range suppressions.packages {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	all := []suppression{}
+	for _, pkg := range packages {
+		all = append(all, suppressions.packages[pkg]...)
+	}
+
+	all = appendPackageSuppressions(all, suppressionOpaquePackage, cliArguments.opaquePackages)
+	all = appendPackageSuppressions(all, suppressionTrustedPackage, cliArguments.trustedPackages)
+
+	content, err := json.MarshalIndent(struct {
+		Suppressions []suppression `json:"suppressions"`
+	}{all}, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// appendPackageSuppressions appends a suppression of the given kind for each package path in the given comma separated list.
+func appendPackageSuppressions(all []suppression, kind string, list string) []suppression {
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			all = append(all, suppression{Kind: kind, Package: pattern})
+		}
+	}
+	return all
+}
+
+func sortedCodes(codes CodeSet) []string {
+	result := codes.Slice()
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSuppressions(t *testing.T) {
+	suppressions.Lock()
+	suppressions.packages = map[string][]suppression{}
+	suppressions.Unlock()
+
+	dir := analysistest.TestData()
+	path := filepath.Join(t.TempDir(), "suppressions.json")
+	Analyzer.Flags.Set("strict", "true")
+	Analyzer.Flags.Set("suppressions-out", path)
+	Analyzer.Flags.Set("opaque-packages", "example.com/legacy")
+	Analyzer.Flags.Set("trusted-packages", "example.com/vendored")
+	defer Analyzer.Flags.Set("suppressions-out", "")
+	defer Analyzer.Flags.Set("opaque-packages", "")
+	defer Analyzer.Flags.Set("trusted-packages", "")
+
+	analysistest.Run(t, dir, Analyzer, "annotation")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var report struct {
+		Suppressions []suppression
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	byFunction := map[string]suppression{}
+	for _, entry := range report.Suppressions {
+		if entry.Function != "" {
+			entry.Position = filepath.Base(entry.Position)
+			byFunction[entry.Function] = entry
+		}
+	}
+
+	for _, want := range []suppression{
+		{Kind: "overwrite", Package: "annotation", Function: "OverwriteReturn1", Position: "annotation.go:8:2", Codes: []string{"overwritten-error"}},
+		{Kind: "modify", Package: "annotation", Function: "AddReturn1", Position: "annotation.go:46:2", Added: []string{"overwritten-error"}},
+		{Kind: "modify", Package: "annotation", Function: "SubReturn1", Position: "annotation.go:77:2", Removed: []string{"some-error"}},
+		{Kind: "modify", Package: "annotation", Function: "SubReturnWithReason", Position: "annotation.go:150:2", Removed: []string{"some-error"}, Reason: "The error is handled by all callers."},
+	} {
+		if got := byFunction[want.Function]; !reflect.DeepEqual(got, want) {
+			t.Errorf("suppression of %q should be %+v, but was %+v", want.Function, want, got)
+		}
+	}
+
+	last := report.Suppressions[len(report.Suppressions)-2:]
+	if want := []suppression{
+		{Kind: "opaque-package", Package: "example.com/legacy"},
+		{Kind: "trusted-package", Package: "example.com/vendored"},
+	}; !reflect.DeepEqual(last, want) {
+		t.Errorf("last suppressions should be %+v, but were %+v", want, last)
+	}
+
+	if !strings.HasSuffix(string(content), "\n") {
+		t.Errorf("suppressions file should end with a newline")
+	}
+}
@@ -15,10 +15,12 @@ import (
 
 // ErrorType is a fact about a ree.Error type,
 // declaring which error codes Code() might return,
-// and/or what field gets returned by a call to Code().
+// and/or what field gets returned by a call to Code(),
+// and/or if Code() returns the value of the error itself, e.g. `string(e)` for a type based on string.
 type ErrorType struct {
-	Codes []string        // error codes, or nil
-	Field *ErrorCodeField // field information, or nil
+	Codes      []string        // error codes, or nil
+	Field      *ErrorCodeField // field information, or nil
+	Underlying bool            // Code() returns the value of the error converted to string
 }
 
 // ErrorCodeField is part of ErrorType,
@@ -32,6 +34,9 @@ func (*ErrorType) AFact() {}
 
 func (e *ErrorType) String() string {
 	sort.Strings(e.Codes)
+	if e.Underlying {
+		return fmt.Sprintf("ErrorType{Field:%v, Codes:%v, Underlying:true}", e.Field, strings.Join(e.Codes, " "))
+	}
 	return fmt.Sprintf("ErrorType{Field:%v, Codes:%v}", e.Field, strings.Join(e.Codes, " "))
 }
 
@@ -71,7 +76,7 @@ func findAndTagErrorTypes(pass *analysis.Pass, lookup *funcLookup) {
 			// Export error type fact for error.
 			err := tagErrorType(pass, lookup, typ, typeSpec)
 			if err != nil {
-				pass.ReportRangef(node, "%v", err)
+				reportError(pass, node, err)
 			}
 		}
 
@@ -84,8 +89,7 @@ func findAndTagErrorTypes(pass *analysis.Pass, lookup *funcLookup) {
 func tagErrorType(pass *analysis.Pass, lookup *funcLookup, err types.Type, spec *ast.TypeSpec) error {
 	namedErr := getNamedType(err)
 	if namedErr == nil {
-		logf("err type: %#v\n", err)
-		return fmt.Errorf("type is an invalid error type")
+		return newMessageError(MsgInvalidErrorType)
 	}
 
 	// Ignore interface types: we don't need to tag them, only concrete implementations.
@@ -95,12 +99,12 @@ func tagErrorType(pass *analysis.Pass, lookup *funcLookup, err types.Type, spec
 
 	funcDecl, receiver := getCodeFuncFromError(pass, lookup, err)
 	if funcDecl == nil {
-		return fmt.Errorf(`found no method "Code() string"`)
+		return newMessageError(MsgCodeMethodNotFound)
 	}
 	errorType := analyseCodeMethod(pass, spec, funcDecl, receiver)
 
 	if errorType == nil {
-		return fmt.Errorf("type %q is an invalid error type: could not find any error codes", namedErr.Obj().Name())
+		return newMessageError(MsgErrorTypeWithoutCodes, namedErr.Obj().Name())
 	}
 
 	analyseMethodsOfErrorType(pass, lookup, errorType, err)
@@ -114,7 +118,6 @@ func tagErrorType(pass *analysis.Pass, lookup *funcLookup, err types.Type, spec
 func getErrorTypeForError(pass *analysis.Pass, err types.Type) (*ErrorType, error) {
 	namedErr := getNamedType(err)
 	if namedErr == nil {
-		logf("err type: %#v\n", err)
 		return nil, fmt.Errorf("passed invalid err type to getErrorTypeForError")
 	}
 
@@ -158,8 +161,30 @@ func getCodeFuncFromError(pass *analysis.Pass, lookup *funcLookup, err types.Typ
 // errorTypesSubset checks if type1 is a subset of type2.
 func errorTypesSubset(type1, type2 types.Type) bool {
 	pointer2, ok2 := type2.(*types.Pointer)
-	return types.Identical(type1, type2) ||
-		(ok2 && types.Identical(type1, pointer2.Elem()))
+	return identicalErrorTypes(type1, type2) ||
+		(ok2 && identicalErrorTypes(type1, pointer2.Elem()))
+}
+
+// identicalErrorTypes checks if the given types are identical.
+// Instances of a generic type, e.g. the receiver type `*Error[T]`, are treated as identical to the generic type.
+func identicalErrorTypes(type1, type2 types.Type) bool {
+	if types.Identical(type1, type2) {
+		return true
+	}
+
+	pointer1, ok1 := type1.(*types.Pointer)
+	pointer2, ok2 := type2.(*types.Pointer)
+	if ok1 != ok2 {
+		return false
+	}
+	if ok1 {
+		type1, type2 = pointer1.Elem(), pointer2.Elem()
+	}
+
+	// The type name of an instance is the type name of its generic type.
+	named1, ok1 := type1.(*types.Named)
+	named2, ok2 := type2.(*types.Named)
+	return ok1 && ok2 && named1.Obj() == named2.Obj()
 }
 
 type codeMethodAnalysis struct {
@@ -171,6 +196,7 @@ type codeMethodAnalysis struct {
 	// Output
 	codes          CodeSet
 	errorCodeField *ast.Ident
+	underlying     bool
 }
 
 // analyseCodeMethod inspects the error type.
@@ -184,6 +210,8 @@ type codeMethodAnalysis struct {
 //     - Find and return the field position and identifier
 //         - Position needed for tracking creation with a constructor
 //         - Identifier needed for creation with named constructor and tracking assignments to the field
+// If the Code() method returns the receiver converted to string, e.g. `string(e)` for `type CodeError string`:
+//     - The error code is the value of the error, which is tracked at creation, e.g. `CodeError("some-code")`
 // All other return statements are marked as invalid by emitting diagnostics.
 func analyseCodeMethod(pass *analysis.Pass, spec *ast.TypeSpec, funcDecl *ast.FuncDecl, receiver *ast.Ident) *ErrorType {
 	state := codeMethodAnalysis{
@@ -220,18 +248,18 @@ func analyseCodeMethod(pass *analysis.Pass, spec *ast.TypeSpec, funcDecl *ast.Fu
 		if position >= 0 {
 			field = &ErrorCodeField{fieldName.Name, position}
 		} else {
-			pass.Reportf(funcDecl.Pos(), "returned field %q is not a valid error code field (promoted fields are not supported currently, but might be added in the future)", fieldName)
+			reportPos(pass, funcDecl.Pos(), MsgCodeFieldInvalid, fieldName)
 		}
 	}
 
-	if len(constants) == 0 && field == nil {
+	if len(constants) == 0 && field == nil && !state.underlying {
 		// In this case errors are already reported:
 		// The signature of the Code() method requires at least one return statement in its implementation.
 		// The return statements are all analysed and only if all are invalid this branch is entered.
 		return nil
 	}
 
-	return &ErrorType{Codes: constants.Slice(), Field: field}
+	return &ErrorType{Codes: constants.Slice(), Field: field, Underlying: state.underlying}
 }
 
 func (state *codeMethodAnalysis) analyseReturnedExpression(node ast.Expr) {
@@ -240,19 +268,24 @@ func (state *codeMethodAnalysis) analyseReturnedExpression(node ast.Expr) {
 
 	// If the return statement returns a constant string value:
 	// Check if it is a valid error code and if so add it to the error code constants.
-	returnType := pass.TypesInfo.Types[returnResult]
-	if returnType.Value != nil {
-		value, err := getErrorCodeFromConstant(returnType.Value)
+	if constantValue := getConstantValue(pass, returnResult); constantValue != nil {
+		value, err := getErrorCodeFromConstant(constantValue)
 		if err == nil {
 			if value != "" { // Ignore empty string result of Code method.
 				state.codes.Add(value)
 			}
 		} else {
-			pass.ReportRangef(node, "%v", err)
+			reportError(pass, node, err)
 		}
 		return
 	}
 
+	// Otherwise check if the receiver itself is returned as string, e.g. `string(e)` or `string(*e)`.
+	if state.isReceiverConversion(returnResult) {
+		state.underlying = true
+		return
+	}
+
 	// Otherwise check if a single field is returned.
 	// Make sure that always the same field is returned and otherwise emit a diagnostic.
 	expression, ok := returnResult.(*ast.SelectorExpr)
@@ -262,7 +295,7 @@ func (state *codeMethodAnalysis) analyseReturnedExpression(node ast.Expr) {
 			if state.errorCodeField == nil {
 				state.errorCodeField = expression.Sel
 			} else if state.errorCodeField.Name != expression.Sel.Name {
-				pass.ReportRangef(node, "only single field allowed: cannot return field %q because field %q was returned previously", expression.Sel.Name, state.errorCodeField.Name)
+				report(pass, node, MsgCodeFieldMultiple, expression.Sel.Name, state.errorCodeField.Name)
 			}
 			return
 		}
@@ -276,7 +309,22 @@ func (state *codeMethodAnalysis) analyseReturnedExpression(node ast.Expr) {
 		return
 	}
 
-	pass.ReportRangef(node, `function %q should always return a string constant or a single field`, state.funcDecl.Name.Name)
+	report(pass, node, MsgCodeMethodReturn, state.funcDecl.Name.Name)
+}
+
+// isReceiverConversion checks if the given expression converts the receiver of the Code() method to string.
+func (state *codeMethodAnalysis) isReceiverConversion(expr ast.Expr) bool {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok || state.receiver == nil || len(callExpr.Args) != 1 || !state.pass.TypesInfo.Types[callExpr.Fun].IsType() {
+		return false
+	}
+
+	arg := astutil.Unparen(callExpr.Args[0])
+	if star, ok := arg.(*ast.StarExpr); ok {
+		arg = astutil.Unparen(star.X)
+	}
+	ident, ok := arg.(*ast.Ident)
+	return ok && ident.Obj == state.receiver.Obj
 }
 
 func (state *codeMethodAnalysis) analyseNamedReturn() {
@@ -299,11 +347,11 @@ func (state *codeMethodAnalysis) analyseReturnedIdentTaint(ident *ast.Ident) {
 	taintResult := taintSpreadForIdentOfImmutableType(state.pass, state.visited, ident, &funcDefinition{state.funcDecl, nil})
 
 	for _, badIdent := range taintResult.identOutOfScope {
-		pass.ReportRangef(badIdent, "error code variable may not be a parameter, receiver or global variable")
+		report(pass, badIdent, MsgCodeVarOutOfScope)
 	}
 
 	for _, destruct := range taintResult.destructAssignment {
-		pass.ReportRangef(destruct.source, "unsupported: assigning result of function call to variable %q is not allowed", destruct.target.Name)
+		report(pass, destruct.source, MsgAssignedCallResult, destruct.target.Name)
 	}
 
 	for _, expr := range taintResult.expressions {
@@ -356,6 +404,12 @@ func analyseMethodsOfErrorType(pass *analysis.Pass, lookup *funcLookup, errorTyp
 		}
 		receiver := receivers.Names[0]
 
+		// Setters assign a parameter to the error code field, the code is taken from their calls instead.
+		if position, ok := findCodeSetterParam(pass, method, receiver, errorType); ok {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[method.Name], &ErrorCodeSetter{position})
+			continue
+		}
+
 		newCodes := findCodesAssignedToErrorCodeField(pass, &funcDefinition{method, nil}, errorType, receiver.Obj)
 		assignedCodes = Union(assignedCodes, newCodes)
 	}
@@ -2,6 +2,8 @@ package analysis
 
 import (
 	"go/ast"
+	"go/token"
+	"go/types"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ast/astutil"
@@ -10,6 +12,7 @@ import (
 type (
 	taintSpreadResult struct {
 		expressions        []ast.Expr             // expressions that represent the taint, or nil
+		targets            []*ast.Ident           // the variables the expressions at the same index are assigned to
 		destructAssignment []*taintSpreadDestruct // taint originating from destructirung assignments, or nil
 		identOutOfScope    []*ast.Ident           // every used ident that was not defined in functio scope, or nil
 	}
@@ -34,7 +37,7 @@ type (
 )
 
 func newTaintSpread(pass *analysis.Pass, function *funcDefinition, immutableType bool, visited map[*ast.Object]struct{}) *taintSpread {
-	return &taintSpread{
+	ts := &taintSpread{
 		pass:          pass,
 		function:      function,
 		immutableType: immutableType,
@@ -44,6 +47,17 @@ func newTaintSpread(pass *analysis.Pass, function *funcDefinition, immutableType
 		visited: visited,
 		blocked: map[*ast.Object]struct{}{},
 	}
+
+	// Block the params of all nested function literals up front,
+	// so it does not matter in which order identifiers are visited.
+	ast.Inspect(function.body(), func(node ast.Node) bool {
+		if funcLit, ok := node.(*ast.FuncLit); ok {
+			ts.blockParams(funcLit)
+		}
+		return true
+	})
+
+	return ts
 }
 
 func taintSpreadForIdentOfImmutableType(pass *analysis.Pass, visited map[*ast.Object]struct{}, ident *ast.Ident, function *funcDefinition) *taintSpreadResult {
@@ -88,17 +102,17 @@ func (ts *taintSpread) findSpread(ident *ast.Ident) {
 	// Check if there can be an error codes extracted from the ident declaration statement if there is any.
 	initValue := ts.findValueForIdentInValueSpec(ident)
 	if initValue != nil {
-		ts.processAssignedExpr(initValue)
+		ts.processAssignedExpr(initValue, ident)
 	}
 
-	ast.Inspect(ts.function.body(), func(node ast.Node) bool {
-		funcLit, ok := node.(*ast.FuncLit)
-		if ok {
-			ts.blockParams(funcLit)
-			// Do *not* filter out `*ast.FuncLit`: statements inside closures can assign things!
-			return true
-		}
+	// Check if the ident receives its values from ranging over a channel or an error iterator.
+	rangeClause := ts.findRangeClauseForIdent(ident)
+	if rangeClause != nil {
+		ts.processAssignedExpr(rangeClause, ident)
+	}
 
+	ast.Inspect(ts.function.body(), func(node ast.Node) bool {
+		// Do *not* filter out `*ast.FuncLit`: statements inside closures can assign things!
 		assignment, ok := node.(*ast.AssignStmt)
 		if !ok {
 			return true
@@ -120,7 +134,7 @@ func (ts *taintSpread) findSpread(ident *ast.Ident) {
 			if len(assignment.Lhs) != len(assignment.Rhs) {
 				ts.result.destructAssignment = append(ts.result.destructAssignment, &taintSpreadDestruct{i, lhsEntry, assignment.Rhs[0]})
 			} else {
-				ts.processAssignedExpr(assignment.Rhs[i])
+				ts.processAssignedExpr(assignment.Rhs[i], lhsEntry)
 			}
 		}
 
@@ -128,7 +142,9 @@ func (ts *taintSpread) findSpread(ident *ast.Ident) {
 	})
 }
 
-func (ts *taintSpread) processAssignedExpr(expr ast.Expr) {
+// processAssignedExpr adds the given expression assigned to the given variable to the taint,
+// or spreads the taint to the assigned variable, if it is one.
+func (ts *taintSpread) processAssignedExpr(expr ast.Expr, target *ast.Ident) {
 	expr = astutil.Unparen(expr)
 	ident, ok := expr.(*ast.Ident)
 	if ok {
@@ -138,6 +154,7 @@ func (ts *taintSpread) processAssignedExpr(expr ast.Expr) {
 		}
 	}
 	ts.result.expressions = append(ts.result.expressions, expr)
+	ts.result.targets = append(ts.result.targets, target)
 }
 
 // findValueForIdentInValueSpec finds the respective value for the given ident if
@@ -166,6 +183,47 @@ func (ts *taintSpread) findValueForIdentInValueSpec(ident *ast.Ident) ast.Expr {
 	return nil
 }
 
+// findRangeClauseForIdent finds the range clause (e.g. `range errs`) for the given ident if
+// the ident was declared as iteration variable of a for-range statement over a channel.
+//
+// If the ident is the error yielded by a range-over-func loop over an error iterator, e.g. `err` in `for v, err := range seq`,
+// the iterator expression is returned instead, which carries the error codes of the yielded errors.
+func (ts *taintSpread) findRangeClauseForIdent(ident *ast.Ident) ast.Expr {
+	if ident == nil || ident.Obj == nil {
+		return nil
+	}
+
+	// The parser records the declaration of iteration variables as an assignment of the range clause.
+	assignment, ok := ident.Obj.Decl.(*ast.AssignStmt)
+	if !ok || len(assignment.Lhs) == 0 || len(assignment.Rhs) != 1 {
+		return nil
+	}
+
+	rangeClause, ok := assignment.Rhs[0].(*ast.UnaryExpr)
+	if !ok || rangeClause.Op != token.RANGE {
+		return nil
+	}
+
+	position := -1
+	for i, lhs := range assignment.Lhs {
+		if lhs, ok := lhs.(*ast.Ident); ok && lhs.Obj == ident.Obj {
+			position = i
+		}
+	}
+
+	rangeType := ts.pass.TypesInfo.TypeOf(rangeClause.X)
+	if errorPosition, ok := getIteratorErrorPosition(rangeType); ok && position == errorPosition {
+		return rangeClause.X
+	}
+
+	// Only the first iteration variable receives values when ranging over a channel.
+	if _, ok := getUnderlyingType(rangeType).(*types.Chan); !ok || position != 0 {
+		return nil
+	}
+
+	return rangeClause
+}
+
 // blockParams adds all params of the given function literal to a set of blocked identifiers.
 //
 // This is done, so no parameter of a function literal can be a source expression from taint spread.
@@ -4,7 +4,12 @@ func CodeNotDeclared() error { // want `function "CodeNotDeclared" is exported,
 	return &Error{"some-error"}
 }
 
-func ErrorNotLast() (error, int) { // want "error should be returned as the last argument"
+// ErrorNotLast returns an error as non-last result.
+//
+// Errors:
+//
+//    - hello-error -- is always returned
+func ErrorNotLast() (error, int) { // want ErrorNotLast:"ErrorCodes: hello-error"
 	return &Error{"hello-error"}, 0
 }
 
@@ -12,9 +17,10 @@ func ErrorNotLast() (error, int) { // want "error should be returned as the last
 //
 // Errors:
 //
+//    - hello-error -- if the called function fails
 //    - zonk-error -- is always returned
-func CallToInvalidFunction() error { // want CallToInvalidFunction:"ErrorCodes: zonk-error"
-	e, _ := ErrorNotLast() // want "unsupported: tracking error codes for function call with error as non-last return argument"
+func CallToInvalidFunction() error { // want CallToInvalidFunction:"ErrorCodes: hello-error zonk-error"
+	e, _ := ErrorNotLast()
 	if false {
 		return e
 	}
@@ -93,15 +99,32 @@ func (e *Error) InvalidErrorFromReceiver() error { // want InvalidErrorFromRecei
 
 var globalError = &Error{"global-error"}
 
+// Errors:
+//
+//    - global-error --
+//    - some-error   --
+func ErrorFromUnexportedGlobal() error { // want ErrorFromUnexportedGlobal:"ErrorCodes: global-error some-error"
+	switch {
+	case true:
+		return globalError
+	case true:
+		x := globalError
+		return x
+	}
+	return &Error{"some-error"}
+}
+
+var GlobalError = &Error{"global-error"}
+
 // Errors:
 //
 //    - some-error --
 func InvalidErrorFromGlobal() error { // want InvalidErrorFromGlobal:"ErrorCodes: some-error"
 	switch {
 	case true:
-		return globalError // want "returned error may not be a parameter, receiver or global variable"
+		return GlobalError // want `returned error may not be exported global variable "GlobalError", as it can be assigned outside of the package`
 	case true:
-		x := globalError // want "returned error may not be a parameter, receiver or global variable"
+		x := GlobalError // want `returned error may not be exported global variable "GlobalError", as it can be assigned outside of the package`
 		return x
 	}
 	return &Error{"some-error"}
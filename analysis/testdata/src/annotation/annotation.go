@@ -143,6 +143,13 @@ func AddSubReturn2() error { // want AddSubReturn2:"ErrorCodes: added-1-error ad
 	return nil
 }
 
+// Errors: none
+func SubReturnWithReason() error { // want SubReturnWithReason:"ErrorCodes:"
+	// The error is handled by all callers.
+	// Error Codes -= some-error
+	return &Error{"some-error"}
+}
+
 type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
 	TheCode string
 }
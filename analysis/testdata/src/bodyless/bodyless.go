@@ -0,0 +1,59 @@
+package bodyless
+
+import _ "unsafe" // for go:linkname
+
+// Errors:
+//
+//    - asm-failed --
+func asmCall() error // want asmCall:"ErrorCodes: asm-failed"
+
+func undeclaredAsm() error // want `function "undeclaredAsm" has no Go body, e.g. it is implemented in assembly or linked with go:linkname, so it has to declare its error codes`
+
+//go:linkname linked runtime.linked
+func linked() error // want `function "linked" has no Go body, e.g. it is implemented in assembly or linked with go:linkname, so it has to declare its error codes`
+
+// Errors:
+//
+//    - linked-failed --
+//
+//go:linkname linkedDeclared runtime.linkedDeclared
+func linkedDeclared() error // want linkedDeclared:"ErrorCodes: linked-failed"
+
+// Exported functions without body are only reported once.
+func Exported() error // want `function "Exported" has no Go body, e.g. it is implemented in assembly or linked with go:linkname, so it has to declare its error codes`
+
+// Errors:
+//
+//    - asm-failed --
+func Call() error { // want Call:"ErrorCodes: asm-failed"
+	return asmCall()
+}
+
+// Errors:
+//
+//    - asm-failed    --
+//    - linked-failed --
+func CallBoth() error { // want CallBoth:"ErrorCodes: asm-failed linked-failed"
+	if err := linkedDeclared(); err != nil {
+		return err
+	}
+	return asmCall()
+}
+
+// Errors: none
+func CallUndeclared() error { // want CallUndeclared:"ErrorCodes:"
+	if err := linked(); err != nil {
+		return err
+	}
+	return undeclaredAsm()
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
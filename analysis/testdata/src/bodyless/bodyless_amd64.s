@@ -0,0 +1,10 @@
+#include "textflag.h"
+
+TEXT ·asmCall(SB),NOSPLIT,$0-16
+	RET
+
+TEXT ·undeclaredAsm(SB),NOSPLIT,$0-16
+	RET
+
+TEXT ·Exported(SB),NOSPLIT,$0-16
+	RET
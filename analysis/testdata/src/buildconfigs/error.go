@@ -0,0 +1,21 @@
+package buildconfigs
+
+// Error is an error with an error code.
+type Error struct {
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Close returns the same error codes for all platforms.
+//
+// Errors:
+//
+//    - buildconfigs-error-closed -- if the file is already closed
+func Close(closed bool) error {
+	if closed {
+		return &Error{"buildconfigs-error-closed"}
+	}
+	return nil
+}
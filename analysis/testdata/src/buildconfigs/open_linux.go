@@ -0,0 +1,25 @@
+package buildconfigs
+
+// Open is declared for linux and windows, returning different error codes.
+//
+// Errors:
+//
+//    - buildconfigs-error-not-found -- if the file does not exist
+func Open(found bool) error {
+	if !found {
+		return &Error{"buildconfigs-error-not-found"}
+	}
+	return nil
+}
+
+// Lock is only declared for linux.
+//
+// Errors:
+//
+//    - buildconfigs-error-locked -- if the file is locked by another process
+func Lock(locked bool) error {
+	if locked {
+		return &Error{"buildconfigs-error-locked"}
+	}
+	return nil
+}
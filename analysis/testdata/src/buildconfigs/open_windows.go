@@ -0,0 +1,17 @@
+package buildconfigs
+
+// Open is declared for linux and windows, returning different error codes.
+//
+// Errors:
+//
+//    - buildconfigs-error-not-found -- if the file does not exist
+//    - buildconfigs-error-sharing-violation -- if the file is opened by another process
+func Open(found, shared bool) error {
+	if !found {
+		return &Error{"buildconfigs-error-not-found"}
+	}
+	if shared {
+		return &Error{"buildconfigs-error-sharing-violation"}
+	}
+	return &Error{"buildconfigs-error-undeclared"}
+}
@@ -0,0 +1,92 @@
+package callexpressions
+
+import "call_expressions/inner"
+
+// Errors:
+//
+//    - some-error --
+func getError() error { // want getError:"ErrorCodes: some-error"
+	return &Error{"some-error"}
+}
+
+// Errors:
+//
+//    - some-error --
+func ParenthesizedCall() error { // want ParenthesizedCall:"ErrorCodes: some-error"
+	return (getError)()
+}
+
+// Errors:
+//
+//    - some-error --
+func ParenthesizedMethodCall() error { // want ParenthesizedMethodCall:"ErrorCodes: some-error"
+	var t T
+	return (t.Method)()
+}
+
+// Errors:
+//
+//    - lit-error --
+func ParenthesizedFuncLitCall() error { // want ParenthesizedFuncLitCall:"ErrorCodes: lit-error"
+	return (func() error { return &Error{"lit-error"} })()
+}
+
+// Errors:
+//
+//    - const-error --
+func Conversion() error { // want Conversion:"ErrorCodes: const-error"
+	return ConstError("message")
+}
+
+// Errors:
+//
+//    - const-error --
+func ParenthesizedConversion() error { // want ParenthesizedConversion:"ErrorCodes: const-error"
+	return (ConstError)("message")
+}
+
+// Errors:
+//
+//    - const-error --
+func ConversionInOtherFile() error { // want ConversionInOtherFile:"ErrorCodes: const-error"
+	return OtherFileError("message")
+}
+
+// Errors:
+//
+//    - inner-const-error --
+func QualifiedConversion() error { // want QualifiedConversion:"ErrorCodes: inner-const-error"
+	return inner.ConstError("message")
+}
+
+// Errors:
+//
+//    - inner-const-error --
+func ParenthesizedQualifiedConversion() error { // want ParenthesizedQualifiedConversion:"ErrorCodes: inner-const-error"
+	return (inner.ConstError)("message")
+}
+
+type T struct{}
+
+// Errors:
+//
+//    - some-error --
+func (T) Method() error { // want Method:"ErrorCodes: some-error"
+	return &Error{"some-error"}
+}
+
+type ConstError string // want ConstError:`ErrorType{Field:<nil>, Codes:const-error}`
+
+func (ConstError) Code() string      { return "const-error" }
+func (ConstError) Error() string     { return "ConstError" }
+func (e ConstError) Message() string { return string(e) }
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,108 @@
+package callexpressions
+
+import "call_expressions/inner"
+
+type Builder struct {
+	name string
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+func (b *Builder) With(name string) *Builder {
+	b.name = name
+	return b
+}
+
+// Errors:
+//
+//    - build-failed --
+func (b *Builder) Build() error { // want Build:"ErrorCodes: build-failed"
+	return &Error{"build-failed"}
+}
+
+type validator struct{}
+
+// Errors:
+//
+//    - invalid --
+func (validator) validate() error { // want validate:"ErrorCodes: invalid"
+	return &Error{"invalid"}
+}
+
+type registry struct {
+	validator validator
+}
+
+func getRegistry() registry {
+	return registry{}
+}
+
+func getValidators() map[string]validator {
+	return nil
+}
+
+func getRegistries() []*registry {
+	return nil
+}
+
+// Errors:
+//
+//    - build-failed --
+func ChainedCall() error { // want ChainedCall:"ErrorCodes: build-failed"
+	return NewBuilder().With("a").With("b").Build()
+}
+
+// Errors:
+//
+//    - build-failed --
+func ChainedCallParenthesized() error { // want ChainedCallParenthesized:"ErrorCodes: build-failed"
+	return (NewBuilder().With("a")).Build()
+}
+
+// Errors:
+//
+//    - invalid --
+func ChainedFieldCall() error { // want ChainedFieldCall:"ErrorCodes: invalid"
+	return getRegistry().validator.validate()
+}
+
+// Errors:
+//
+//    - invalid --
+func ChainedMapCall() error { // want ChainedMapCall:"ErrorCodes: invalid"
+	return getValidators()["key"].validate()
+}
+
+// Errors:
+//
+//    - invalid --
+func ChainedSliceCall() error { // want ChainedSliceCall:"ErrorCodes: invalid"
+	return getRegistries()[0].validator.validate()
+}
+
+// Errors:
+//
+//    - invalid --
+func ChainedLiteralCall() error { // want ChainedLiteralCall:"ErrorCodes: invalid"
+	return (&registry{}).validator.validate()
+}
+
+// Errors:
+//
+//    - some-error --
+func ChainedMethodValue() error { // want ChainedMethodValue:"ErrorCodes: some-error"
+	return getT().Method()
+}
+
+func getT() T {
+	return T{}
+}
+
+// Errors:
+//
+//    - inner-const-error --
+func ChainedCallOtherPackage() error { // want ChainedCallOtherPackage:"ErrorCodes: inner-const-error"
+	return inner.NewClient().Retry().Request()
+}
@@ -0,0 +1,24 @@
+package inner
+
+type ConstError string // want ConstError:`ErrorType{Field:<nil>, Codes:inner-const-error}`
+
+func (ConstError) Code() string      { return "inner-const-error" }
+func (ConstError) Error() string     { return "ConstError" }
+func (e ConstError) Message() string { return string(e) }
+
+type Client struct{}
+
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Errors:
+//
+//    - inner-const-error --
+func (*Client) Request() error { // want Request:"ErrorCodes: inner-const-error"
+	return ConstError("request failed")
+}
+
+func (c *Client) Retry() *Client {
+	return c
+}
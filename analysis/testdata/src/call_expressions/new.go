@@ -0,0 +1,34 @@
+package callexpressions
+
+import "call_expressions/inner"
+
+// Errors:
+//
+//    - const-error --
+func NewConstError() error { // want NewConstError:"ErrorCodes: const-error"
+	return new(ConstError)
+}
+
+// Errors:
+//
+//    - inner-const-error --
+func NewQualifiedConstError() error { // want NewQualifiedConstError:"ErrorCodes: inner-const-error"
+	return new(inner.ConstError)
+}
+
+// Errors:
+//
+//    - const-error --
+func NewConstErrorVariable() error { // want NewConstErrorVariable:"ErrorCodes: const-error"
+	err := new(ConstError)
+	return err
+}
+
+// Errors:
+//
+//    - new-error --
+func NewFieldError() error { // want NewFieldError:"ErrorCodes: new-error"
+	err := new(Error)
+	err.TheCode = "new-error"
+	return err
+}
@@ -0,0 +1,76 @@
+package callexpressions
+
+// Errors:
+//
+//    - paren-error --
+func ParenthesizedComposite() error { // want ParenthesizedComposite:"ErrorCodes: paren-error"
+	return (&Error{"paren-error"})
+}
+
+// Errors:
+//
+//    - paren-error --
+func ParenthesizedCompositeInside() error { // want ParenthesizedCompositeInside:"ErrorCodes: paren-error"
+	return &(Error{("paren-error")})
+}
+
+// Errors:
+//
+//    - paren-error --
+func ParenthesizedVariable() error { // want ParenthesizedVariable:"ErrorCodes: paren-error"
+	err := (&Error{"paren-error"})
+	return (err)
+}
+
+// Errors:
+//
+//    - paren-error --
+//    - some-error  --
+func ParenthesizedAssignments() error { // want ParenthesizedAssignments:"ErrorCodes: paren-error some-error"
+	var err error = ((&Error{"paren-error"}))
+	if err != nil {
+		return ((err))
+	}
+	(err) = (getError())
+	return (err)
+}
+
+// Errors:
+//
+//    - some-error --
+func ParenthesizedDestructuring() error { // want ParenthesizedDestructuring:"ErrorCodes: some-error"
+	_, err := (getValue())
+	return (err)
+}
+
+// Errors:
+//
+//    - paren-error --
+func ParenthesizedInterfaceConversion() error { // want ParenthesizedInterfaceConversion:"ErrorCodes: paren-error"
+	return (error)(&Error{"paren-error"})
+}
+
+// Errors:
+//
+//    - paren-error --
+func ParenthesizedNil() error { // want ParenthesizedNil:"ErrorCodes: paren-error"
+	if true {
+		return (nil)
+	}
+	return (&Error{"paren-error"})
+}
+
+// Errors:
+//
+//    - some-error --
+func ParenthesizedNamedResult() (err error) { // want ParenthesizedNamedResult:"ErrorCodes: some-error"
+	(err) = getError()
+	return
+}
+
+// Errors:
+//
+//    - some-error --
+func getValue() (int, error) { // want getValue:"ErrorCodes: some-error"
+	return 0, (getError())
+}
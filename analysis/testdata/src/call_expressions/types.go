@@ -0,0 +1,7 @@
+package callexpressions
+
+type OtherFileError string // want OtherFileError:`ErrorType{Field:<nil>, Codes:const-error}`
+
+func (OtherFileError) Code() string      { return "const-error" }
+func (OtherFileError) Error() string     { return "OtherFileError" }
+func (e OtherFileError) Message() string { return string(e) }
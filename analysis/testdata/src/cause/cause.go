@@ -0,0 +1,43 @@
+package cause
+
+import "cause/inner"
+
+// Errors:
+//
+//    - cause-unknown --
+func Unwrap(err *inner.WrapError) error { // want Unwrap:"ErrorCodes: cause-unknown"
+	return err.Cause()
+}
+
+// Errors:
+//
+//    - connection-refused --
+func UnwrapDeclared(err *inner.TimeoutError) error { // want UnwrapDeclared:"ErrorCodes: connection-refused"
+	return err.Cause()
+}
+
+type reeError interface {
+	Code() string
+	Message() string
+	Details() map[string]string
+	Cause() error
+	Error() string
+}
+
+// Errors:
+//
+//    - cause-unknown --
+func UnwrapInterface(err reeError) error { // want UnwrapInterface:"ErrorCodes: cause-unknown"
+	return err.Cause()
+}
+
+// Errors:
+//
+//    - cause-unknown --
+//    - wrap-error    --
+func UnwrapOrReturn(err *inner.WrapError, unwrap bool) error { // want UnwrapOrReturn:"ErrorCodes: cause-unknown wrap-error"
+	if unwrap {
+		return err.Cause()
+	}
+	return &inner.WrapError{}
+}
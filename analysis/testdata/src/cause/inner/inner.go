@@ -0,0 +1,44 @@
+package inner
+
+// WrapError wraps another error, without declaring the error codes of its cause.
+type WrapError struct { // want WrapError:`ErrorType{Field:<nil>, Codes:wrap-error}`
+	cause error
+}
+
+func (e *WrapError) Code() string               { return "wrap-error" }
+func (e *WrapError) Message() string            { return "wrapped" }
+func (e *WrapError) Details() map[string]string { return nil }
+func (e *WrapError) Cause() error               { return e.cause }
+func (e *WrapError) Error() string              { return e.Message() }
+
+// TimeoutError wraps the error of a failed connection attempt, which is declared by its Cause() method.
+type TimeoutError struct { // want TimeoutError:`ErrorType{Field:<nil>, Codes:timeout}`
+	cause error
+}
+
+func (e *TimeoutError) Code() string               { return "timeout" }
+func (e *TimeoutError) Message() string            { return "timeout" }
+func (e *TimeoutError) Details() map[string]string { return nil }
+func (e *TimeoutError) Error() string              { return e.Message() }
+
+// Errors:
+//
+//    - connection-refused --
+func (e *TimeoutError) Cause() error { // want Cause:"ErrorCodes: connection-refused"
+	return e.cause
+}
+
+func connect() {
+	timeout := &TimeoutError{}
+	timeout.cause = &Error{"connection-refused"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,101 @@
+package channels
+
+// Errors:
+//
+//    - worker-failed  --
+//    - worker-timeout --
+func ReturnReceived(flag bool) error { // want ReturnReceived:"ErrorCodes: worker-failed worker-timeout"
+	errs := make(chan error, 1)
+	go func() {
+		if flag {
+			errs <- &Error{"worker-failed"}
+			return
+		}
+		errs <- &Error{"worker-timeout"}
+	}()
+	return <-errs
+}
+
+// Errors:
+//
+//    - worker-failed --
+func AssignReceived() error { // want AssignReceived:"ErrorCodes: worker-failed"
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		err := &Error{"worker-failed"}
+		errs <- err
+	}()
+	err := <-errs
+	return err
+}
+
+// Errors:
+//
+//    - worker-failed --
+func CommaOk() error { // want CommaOk:"ErrorCodes: worker-failed"
+	errs := make(chan error, 1)
+	errs <- &Error{"worker-failed"}
+	err, ok := <-errs
+	if !ok {
+		return nil
+	}
+	return err
+}
+
+// Errors:
+//
+//    - worker-failed --
+func Select(done chan struct{}) error { // want Select:"ErrorCodes: worker-failed"
+	errs := make(chan error, 1)
+	go func() {
+		errs <- &Error{"worker-failed"}
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-done:
+		return nil
+	}
+}
+
+// Errors: none
+func LeakedChannel() error { // want LeakedChannel:"ErrorCodes"
+	errs := make(chan error, 1)
+	go work(errs) // want "unsupported: channel of errors may not be passed to other functions"
+	return <-errs
+}
+
+func work(errs chan error) {
+	errs <- &Error{"worker-failed"}
+}
+
+// Errors:
+//
+//    - worker-failed --
+func Range() error { // want Range:"ErrorCodes: worker-failed"
+	errs := make(chan error, 2)
+	errs <- &Error{"worker-failed"}
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Errors: none
+func ParamChannel(errs chan error) error { // want ParamChannel:"ErrorCodes"
+	return <-errs // want "channel of errors may not be a parameter, receiver or global variable"
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,130 @@
+package codecomparisons
+
+import "github.com/serum-errors/go-serum-analyzer/rerr"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - comparisons-error-not-found --
+//    - comparisons-error-invalid   --
+func Lookup(key string) *Error { // want Lookup:"ErrorCodes: comparisons-error-invalid comparisons-error-not-found"
+	if key == "" {
+		return &Error{"comparisons-error-invalid"}
+	}
+	return &Error{"comparisons-error-not-found"}
+}
+
+// Errors:
+//
+//    - comparisons-error-* --
+func Storage() error { // want Storage:"ErrorCodes: comparisons-error-\\*"
+	return &Error{"comparisons-error-full"}
+}
+
+const notFound = "comparisons-error-not-found"
+
+func Comparisons(key string) bool {
+	err := Lookup(key)
+	if err.Code() == "comparisons-error-not-fuond" { // want `error code "comparisons-error-not-fuond" is compared with an error which can never carry it, possible codes: \[comparisons-error-invalid comparisons-error-not-found\]`
+		return false
+	}
+	if "comparisons-error-unknown" != err.Code() { // want `error code "comparisons-error-unknown" is compared with an error which can never carry it`
+		return false
+	}
+	return err.Code() == notFound || err.Code() == "comparisons-error-invalid"
+}
+
+func Switch(key string) int {
+	switch Lookup(key).Code() {
+	case "":
+		return 0
+	case "comparisons-error-invalid":
+		return 1
+	case notFound, "comparisons-error-missing": // want `error code "comparisons-error-missing" is compared with an error which can never carry it`
+		return 2
+	}
+	return 3
+}
+
+func CodeOf(key string) int {
+	var err error = Lookup(key)
+	switch rerr.CodeOf(err) {
+	case "comparisons-error-invalid":
+		return 1
+	case "comparisons-error-not-fuond": // want `error code "comparisons-error-not-fuond" is compared with an error which can never carry it`
+		return 2
+	}
+	if rerr.CodeOf(err) == "comparisons-error-missing" { // want `error code "comparisons-error-missing" is compared with an error which can never carry it`
+		return 3
+	}
+	return 0
+}
+
+func HasCode(key string) bool {
+	var err error = Lookup(key)
+	if rerr.HasCode(err, "comparisons-error-invalid") {
+		return false
+	}
+	return rerr.HasCode(err, "comparisons-error-not-fuond") // want `error code "comparisons-error-not-fuond" is compared with an error which can never carry it`
+}
+
+func Wildcard() bool {
+	err := Storage()
+	return rerr.HasCode(err, "comparisons-error-full") || rerr.HasCode(err, "comparisons-other") // want `error code "comparisons-other" is compared with an error which can never carry it, possible codes: \[comparisons-error-\*\]`
+}
+
+// Parameters are not checked, as their codes are not known.
+func Parameter(err *Error) bool {
+	return err.Code() == "comparisons-error-anything" || rerr.HasCode(err, "comparisons-error-anything")
+}
+
+// Errors:
+//
+//    - param: code --
+func NewError(code string) error { // want NewError:"ErrorConstructor: {CodeParamPosition:0}" NewError:"ErrorCodes:"
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - comparisons-error-not-found --
+//    - comparisons-error-invalid   --
+func ConvertCode(key string) error { // want ConvertCode:"ErrorCodes: comparisons-error-invalid comparisons-error-not-found"
+	err := Lookup(key)
+	return NewError(rerr.CodeOf(err))
+}
+
+// Errors:
+//
+//    - comparisons-error-not-found --
+//    - comparisons-error-invalid   --
+//    - comparisons-error-internal  --
+func ConvertCodeWithFallback(key string) error { // want ConvertCodeWithFallback:"ErrorCodes: comparisons-error-internal comparisons-error-invalid comparisons-error-not-found"
+	return NewError(rerr.CodeOfOr(Lookup(key), "comparisons-error-internal"))
+}
+
+// Errors:
+//
+//    - comparisons-error-not-found --
+//    - comparisons-error-invalid   --
+func ConvertCodeWithVariableFallback(key, fallback string) error { // want ConvertCodeWithVariableFallback:"ErrorCodes: comparisons-error-invalid comparisons-error-not-found"
+	return NewError(rerr.CodeOfOr(Lookup(key), fallback)) // want `require an error code parameter declaration to use "fallback" as an error code`
+}
+
+// Errors:
+//
+//    - param: code                 --
+//    - comparisons-error-not-found --
+//    - comparisons-error-invalid   --
+func ConvertCodeWithParamFallback(key, code string) error { /*
+		want
+			ConvertCodeWithParamFallback:"ErrorConstructor: {CodeParamPosition:1}"
+			ConvertCodeWithParamFallback:"ErrorCodes: comparisons-error-invalid comparisons-error-not-found" */
+	return NewError(rerr.CodeOfOr(Lookup(key), code))
+}
@@ -0,0 +1,63 @@
+package component_size
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// ParseExpr is part of a component of three mutually recursive functions, which is larger than the maximum of two.
+// Its declared error codes are trusted, although parseFactor returns an undeclared code.
+//
+// Errors:
+//
+//    - component-size-error-syntax -- if the input is invalid
+func ParseExpr(input string) error { // want ParseExpr:"ErrorCodes: component-size-error-syntax" `component too large to analyse: function "ParseExpr" is part of 3 mutually recursive functions, more than 2, so their declared error codes are trusted without verification`
+	if input == "" {
+		return &Error{"component-size-error-syntax"}
+	}
+	return parseTerm(input[1:])
+}
+
+func parseTerm(input string) error {
+	if input == "(" {
+		return parseFactor(input[1:])
+	}
+	return nil
+}
+
+func parseFactor(input string) error {
+	if input == "" {
+		return &Error{"component-size-error-unexpected-end"}
+	}
+	return ParseExpr(input)
+}
+
+// Parse calls the oversized component, whose declared error codes are used.
+//
+// Errors:
+//
+//    - component-size-error-syntax -- if the input is invalid
+func Parse(input string) error { // want Parse:"ErrorCodes: component-size-error-syntax"
+	return ParseExpr(input)
+}
+
+// Ping is part of a component of two mutually recursive functions, which is still analysed.
+//
+// Errors:
+//
+//    - component-size-error-ping -- if the ping fails
+func Ping(n int) error { // want Ping:"ErrorCodes: component-size-error-ping" `function "Ping" has a mismatch of declared and actual error codes: missing codes: \[component-size-error-pong\]`
+	if n == 0 {
+		return &Error{"component-size-error-ping"}
+	}
+	return pong(n - 1)
+}
+
+func pong(n int) error {
+	if n == 0 {
+		return &Error{"component-size-error-pong"}
+	}
+	return Ping(n - 1)
+}
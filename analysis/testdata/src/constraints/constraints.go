@@ -0,0 +1,96 @@
+package constraints
+
+// Fetcher is used as constraint of generic functions and types.
+type Fetcher interface { // want Fetcher:"ErrorInterface: Fetch"
+	// Errors:
+	//
+	//    - fetch-failed --
+	Fetch() error // want Fetch:"ErrorCodes: fetch-failed"
+}
+
+type validFetcher struct{}
+
+// Errors:
+//
+//   - fetch-failed --
+func (validFetcher) Fetch() error { // want Fetch:"ErrorCodes: fetch-failed"
+	return &Error{"fetch-failed"}
+}
+
+type invalidFetcher struct{}
+
+// Errors:
+//
+//   - fetch-failed  --
+//   - fetch-timeout --
+func (*invalidFetcher) Fetch() error { // want Fetch:"ErrorCodes: fetch-failed fetch-timeout"
+	if true {
+		return &Error{"fetch-timeout"}
+	}
+	return &Error{"fetch-failed"}
+}
+
+// Errors:
+//
+//   - fetch-failed --
+func FetchAll[F Fetcher](fetchers ...F) error { // want FetchAll:"ErrorCodes: fetch-failed"
+	for _, fetcher := range fetchers {
+		if err := fetcher.Fetch(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Errors:
+//
+//   - fetch-failed --
+func FetchTwice[F Fetcher, G Fetcher](first F, second G) error { // want FetchTwice:"ErrorCodes: fetch-failed"
+	if err := first.Fetch(); err != nil {
+		return err
+	}
+	return FetchAll(second)
+}
+
+// Cache wraps a fetcher of the given type.
+type Cache[F Fetcher] struct {
+	fetcher F
+}
+
+// Errors:
+//
+//   - fetch-failed --
+func (c *Cache[F]) Get() error { // want Get:"ErrorCodes: fetch-failed"
+	return c.fetcher.Fetch()
+}
+
+// Errors:
+//
+//   - fetch-failed --
+func UseValid() error { // want UseValid:"ErrorCodes: fetch-failed"
+	if err := FetchAll(validFetcher{}); err != nil {
+		return err
+	}
+	cache := Cache[validFetcher]{}
+	return cache.Get()
+}
+
+func UseInvalid() {
+	_ = FetchAll(&invalidFetcher{})                                    // want `cannot use "\*constraints.invalidFetcher" as type argument for "F": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	_ = FetchAll[*invalidFetcher]()                                    // want `cannot use "\*constraints.invalidFetcher" as type argument for "F": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	_ = FetchTwice(validFetcher{}, &invalidFetcher{})                  // want `cannot use "\*constraints.invalidFetcher" as type argument for "G": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	_ = FetchTwice[validFetcher, *invalidFetcher](validFetcher{}, nil) // want `cannot use "\*constraints.invalidFetcher" as type argument for "G": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	_ = FetchTwice[validFetcher](validFetcher{}, &invalidFetcher{})    // want `cannot use "\*constraints.invalidFetcher" as type argument for "G": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	_ = Cache[*invalidFetcher]{}                                       // want `cannot use "\*constraints.invalidFetcher" as type argument for "F": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+	var _ *Cache[*invalidFetcher]                                      // want `cannot use "\*constraints.invalidFetcher" as type argument for "F": method "Fetch" declares the following error codes which were not part of the constraint "Fetcher": \[fetch-timeout\]`
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,153 @@
+package controlflow
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+//    - c-error --
+func SwitchFallthrough(value int) error { // want SwitchFallthrough:"ErrorCodes: a-error b-error c-error"
+	var err error
+	switch value {
+	case 0:
+		err = &Error{"a-error"}
+		fallthrough
+	case 1:
+		if err == nil {
+			err = &Error{"b-error"}
+		}
+	case 2:
+		return &Error{"c-error"}
+	default:
+		return nil
+	}
+	return err
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func LabeledBreak(values []int) error { // want LabeledBreak:"ErrorCodes: a-error b-error"
+	var err error
+loop:
+	for _, value := range values {
+		switch value {
+		case 0:
+			err = &Error{"a-error"}
+			break loop
+		case 1:
+			err = &Error{"b-error"}
+		}
+	}
+	return err
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func LabeledContinue(values [][]int) error { // want LabeledContinue:"ErrorCodes: a-error b-error"
+	var err error
+outer:
+	for _, inner := range values {
+		for _, value := range inner {
+			if value == 0 {
+				err = &Error{"a-error"}
+				continue outer
+			}
+			if value < 0 {
+				return &Error{"b-error"}
+			}
+		}
+	}
+	return err
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func Goto(input string) error { // want Goto:"ErrorCodes: a-error b-error"
+	var err error
+	i := 0
+next:
+	if i >= len(input) {
+		return err
+	}
+	switch input[i] {
+	case 'a':
+		err = &Error{"a-error"}
+		goto fail
+	case 'b':
+		err = &Error{"b-error"}
+		goto fail
+	}
+	i++
+	goto next
+fail:
+	return err
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func SelectWithLabels(channel chan int) error { // want SelectWithLabels:"ErrorCodes: a-error b-error"
+	var err error
+loop:
+	for {
+		select {
+		case value, ok := <-channel:
+			if !ok {
+				break loop
+			}
+			if value == 0 {
+				err = &Error{"a-error"}
+				continue loop
+			}
+			err = &Error{"b-error"}
+		}
+	}
+	return err
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func TypeSwitchFallthroughLike(value interface{}) error { // want TypeSwitchFallthroughLike:"ErrorCodes: a-error b-error"
+	switch value.(type) {
+	case int, int64:
+		return &Error{"a-error"}
+	case string:
+	default:
+		return &Error{"b-error"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func LabeledStatements(value int) error { // want LabeledStatements:"ErrorCodes: a-error b-error"
+	var err error
+	if value == 0 {
+		goto assign
+	}
+	goto fail
+assign:
+	err = &Error{"a-error"}
+fail:
+	if err == nil {
+		return &Error{"b-error"}
+	}
+	return err
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
@@ -0,0 +1,124 @@
+package conversions
+
+type CodedError interface {
+	error
+	Code() string
+}
+
+type myError error
+
+// Errors:
+//
+//    - not-found --
+func find() *Error { // want find:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - not-found --
+func Direct() error { // want Direct:"ErrorCodes: not-found"
+	err := find()
+	return error(err)
+}
+
+// Errors:
+//
+//    - not-found --
+func Parenthesized() error { // want Parenthesized:"ErrorCodes: not-found"
+	return (error)(find())
+}
+
+// Errors:
+//
+//    - not-found --
+func Named() error { // want Named:"ErrorCodes: not-found"
+	return CodedError(find())
+}
+
+// Errors:
+//
+//    - not-found --
+func NamedError() error { // want NamedError:"ErrorCodes: not-found"
+	return myError(find())
+}
+
+// Errors:
+//
+//    - not-found --
+func Nested() error { // want Nested:"ErrorCodes: not-found"
+	return error(CodedError(find()))
+}
+
+// Errors:
+//
+//    - invalid --
+func Literal() error { // want Literal:"ErrorCodes: invalid"
+	return error(&Error{"invalid"})
+}
+
+// Errors: none
+func Nil() error { // want Nil:"ErrorCodes:"
+	return error(nil)
+}
+
+// Errors:
+//
+//    - not-found --
+func Assigned() error { // want Assigned:"ErrorCodes: not-found"
+	var err error = CodedError(find())
+	return err
+}
+
+// Errors:
+//
+//    - not-found --
+func Interfaces() error { // want Interfaces:"ErrorCodes: not-found"
+	var err CodedError = find()
+	return error(err)
+}
+
+// Errors:
+//
+//    - not-found --
+func Asserted() error { // want Asserted:"ErrorCodes: not-found"
+	err := error(find())
+	if coded, ok := error(err).(CodedError); ok {
+		return coded
+	}
+	return nil
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
+
+// ConvertedCopy overwrites errA after it was copied with a conversion, which does not change errB.
+//
+// Errors:
+//
+//    - not-found --
+func ConvertedCopy() error { // want ConvertedCopy:"ErrorCodes: not-found"
+	errA := error(find())
+	errB := CodedError(errA.(*Error))
+	errA = &Error{"invalid"}
+	return error(errB)
+}
+
+// ConvertedSwap swaps the errors with conversions, so only the second error is returned.
+//
+// Errors:
+//
+//    - invalid --
+func ConvertedSwap() error { // want ConvertedSwap:"ErrorCodes: invalid"
+	errA := error(find())
+	errB := error(&Error{"invalid"})
+	errA, errB = error(errB), error(errA)
+	return errA
+}
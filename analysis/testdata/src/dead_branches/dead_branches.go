@@ -0,0 +1,95 @@
+package dead_branches
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+const debug = false
+
+// Errors:
+//
+//    - dead-error-reachable --
+func IfFalse() error { // want IfFalse:"ErrorCodes: dead-error-reachable"
+	if false {
+		return &Error{"dead-error-unreachable"}
+	}
+	return &Error{"dead-error-reachable"}
+}
+
+// Errors:
+//
+//    - dead-error-reachable --
+func BuildFlag() error { // want BuildFlag:"ErrorCodes: dead-error-reachable"
+	if debug {
+		return &Error{"dead-error-debug"}
+	}
+	if !debug && len("abc") > 2 {
+		return &Error{"dead-error-reachable"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - dead-error-else --
+//    - dead-error-reachable --
+func Else(ok bool) error { // want Else:"ErrorCodes: dead-error-else dead-error-reachable"
+	if debug {
+		return &Error{"dead-error-debug"}
+	} else if ok {
+		return &Error{"dead-error-reachable"}
+	} else {
+		return &Error{"dead-error-else"}
+	}
+}
+
+// Errors:
+//
+//    - dead-error-reachable --
+func IfTrue() error { // want IfTrue:"ErrorCodes: dead-error-reachable"
+	if true {
+		return &Error{"dead-error-reachable"}
+	} else {
+		return &Error{"dead-error-unreachable"}
+	}
+}
+
+// Errors:
+//
+//    - dead-error-default --
+//    - dead-error-reachable --
+func Switch(ok bool) error { // want Switch:"ErrorCodes: dead-error-default dead-error-reachable"
+	switch {
+	case debug, false:
+		return &Error{"dead-error-debug"}
+	case ok:
+		return &Error{"dead-error-reachable"}
+	default:
+		return &Error{"dead-error-default"}
+	}
+}
+
+// Errors:
+//
+//    - dead-error-reachable --
+func Unused() error { // want Unused:"ErrorCodes: dead-error-reachable" `function "Unused" has a mismatch of declared and actual error codes: unused codes: \[dead-error-reachable]`
+	if false {
+		return &Error{"dead-error-reachable"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - dead-error-reachable --
+func FunctionLiteral() error { // want FunctionLiteral:"ErrorCodes: dead-error-reachable"
+	return func() error {
+		if debug {
+			return &Error{"dead-error-debug"}
+		}
+		return &Error{"dead-error-reachable"}
+	}()
+}
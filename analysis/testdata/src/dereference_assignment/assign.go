@@ -21,6 +21,17 @@ func DereferenceAssignment2() error { // want DereferenceAssignment2:"ErrorCodes
 	return err
 }
 
+// Errors:
+//
+//    - some-error --
+//    - other-error --
+func DereferenceFieldAssignment() error { // want DereferenceFieldAssignment:"ErrorCodes: other-error some-error"
+	err := Error{"some-error"}
+	err2 := &err
+	err2.TheCode = "other-error"
+	return &err
+}
+
 type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
 	TheCode string
 }
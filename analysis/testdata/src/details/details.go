@@ -0,0 +1,55 @@
+package details
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode    string
+	TheDetails map[string]string
+}
+
+func (e *Error) Code() string                 { return e.TheCode }
+func (e *Error) Error() string                { return e.TheCode }
+func (e *Error) Details() map[string]string { return e.TheDetails }
+
+// Errors:
+//
+//    - details-error-not-found -- details: id, kind; if the entry does not exist
+//    - details-error-invalid   -- if the id is empty
+func Find(id, kind string) error { // want Find:"ErrorCodes: details-error-invalid details-error-not-found"
+	if id == "" {
+		return &Error{TheCode: "details-error-invalid"}
+	}
+	return &Error{"details-error-not-found", map[string]string{"id": id, "kind": kind}}
+}
+
+// Errors:
+//
+//    - details-error-not-found -- details: id, kind
+func MissingKey(id string) error { // want MissingKey:"ErrorCodes: details-error-not-found"
+	return &Error{
+		TheCode:    "details-error-not-found",
+		TheDetails: map[string]string{"id": id}, // want `details of error code "details-error-not-found" do not match the declared keys: missing keys: \[kind]`
+	}
+}
+
+// Errors:
+//
+//    - details-error-not-found -- details: id
+func UnexpectedKey(id, kind string) error { // want UnexpectedKey:"ErrorCodes: details-error-not-found"
+	return &Error{
+		TheCode:    "details-error-not-found",
+		TheDetails: map[string]string{"id": id, "kind": kind, "path": ""}, // want `details of error code "details-error-not-found" do not match the declared keys: unexpected keys: \[kind path]`
+	}
+}
+
+// Errors:
+//
+//    - details-error-not-found -- details: id, kind
+func DynamicDetails(details map[string]string) error { // want DynamicDetails:"ErrorCodes: details-error-not-found"
+	return &Error{"details-error-not-found", details}
+}
+
+// Errors:
+//
+//    - details-error-not-found -- details: id kind
+func InvalidKey() error { // want `function "InvalidKey" has odd docstring: declared details have invalid key "id kind": keys have to be separated by commas and can't contain whitespace`
+	return &Error{TheCode: "details-error-not-found"}
+}
@@ -0,0 +1,39 @@
+package docs
+
+// NoDocs does not declare any error codes.
+func NoDocs() error { return nil }
+
+// One declares a single error code.
+//
+// Errors:
+//
+//    - docs-one -- if something fails
+func One() error { // want `codes: \[docs-one\]`
+	return nil
+}
+
+// Two declares two error codes.
+//
+// Errors:
+//
+//    - docs-b -- if b fails
+//    - docs-a -- if a fails
+func Two() error { // want `codes: \[docs-a docs-b\]`
+	return nil
+}
+
+// None declares that it never returns an error code.
+//
+// Errors: none -- this function only returns nil.
+func None() error { // want `codes: \[\]`
+	return nil
+}
+
+// Invalid has an invalid error code.
+//
+// Errors:
+//
+//    - docs invalid -- not a code
+func Invalid() error { // want `invalid: .*`
+	return nil
+}
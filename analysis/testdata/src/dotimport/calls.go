@@ -0,0 +1,48 @@
+package dotimport
+
+import (
+	. "dotimport/inner1"
+)
+
+// Errors:
+//
+//    - constructed-error --
+func CallDotImportedConstructor() error { // want CallDotImportedConstructor:"ErrorCodes: constructed-error"
+	return NewInner1Error("constructed-error")
+}
+
+// Errors:
+//
+//    - hello-error --
+func CallDotImportedFuncValue() error { // want CallDotImportedFuncValue:"ErrorCodes: hello-error"
+	f := ExportedFunc1
+	return f()
+}
+
+// Errors:
+//
+//    - hello-error --
+//    - x-error     --
+func CallDotImportedUnion() error { // want CallDotImportedUnion:"ErrorCodes: hello-error x-error"
+	return First(ExportedFunc1(), &Error{"x-error"})
+}
+
+// Errors:
+//
+//    - x-error --
+func CallDotImportedHookUndeclared() error { // want CallDotImportedHookUndeclared:"ErrorCodes: x-error"
+	if true {
+		return HookUndeclared() // want `function "HookUndeclared" in dot-imported package does not declare error codes`
+	}
+	return &Error{"x-error"}
+}
+
+// Errors:
+//
+//    - x-error --
+func CallLocalFuncValueOfOtherFile() error { // want CallLocalFuncValueOfOtherFile:"ErrorCodes: x-error"
+	if true {
+		return localFunc() // want "error returning function literal may not be a parameter, receiver or global variable"
+	}
+	return &Error{"x-error"}
+}
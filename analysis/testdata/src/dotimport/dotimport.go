@@ -112,3 +112,7 @@ func (e *Error) Message() string            { return e.TheCode }
 func (e *Error) Details() map[string]string { return nil }
 func (e *Error) Cause() error               { return nil }
 func (e *Error) Error() string              { return e.Message() }
+
+var localFunc = func() error {
+	return &Error{"x-error"}
+}
@@ -35,3 +35,28 @@ type Inner1UnusedError struct { // want Inner1UnusedError:`ErrorType{Field:{Name
 
 func (e *Inner1UnusedError) Code() string  { return e.TheCode }
 func (e *Inner1UnusedError) Error() string { return e.TheCode }
+
+// NewInner1Error is an error constructor.
+//
+// Errors:
+//
+//    - param: code -- is always returned
+func NewInner1Error(code string) error { // want NewInner1Error:"ErrorConstructor: {CodeParamPosition:0}" NewInner1Error:"ErrorCodes:"
+	return &Inner1Error{code}
+}
+
+// First returns the first of the given errors.
+//
+// Errors:
+//
+//    - param: errs -- union
+func First(errs ...error) error { // want First:"ErrorUnion: {ParamPosition:0}" First:"ErrorCodes:"
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var HookUndeclared func() error
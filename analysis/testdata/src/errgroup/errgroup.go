@@ -0,0 +1,74 @@
+package errgroup
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Errors:
+//
+//    - fetch-failed  --
+//    - decode-failed --
+func FuncLiterals() error { // want FuncLiterals:"ErrorCodes: decode-failed fetch-failed"
+	var g errgroup.Group
+	g.Go(func() error {
+		return &Error{"fetch-failed"}
+	})
+	g.Go(func() error {
+		return &Error{"decode-failed"}
+	})
+	return g.Wait()
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - store-failed --
+func WithContext(ctx context.Context) error { // want WithContext:"ErrorCodes: fetch-failed store-failed"
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(fetch)
+	store := func() error { return &Error{"store-failed"} }
+	g.TryGo(store)
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func fetch() error {
+	return &Error{"fetch-failed"}
+}
+
+// Errors: none
+func NoTasks() error { // want NoTasks:"ErrorCodes"
+	var g errgroup.Group
+	return g.Wait()
+}
+
+// Errors: none
+func ParamGroup(g *errgroup.Group) error { // want ParamGroup:"ErrorCodes"
+	return g.Wait() // want "error group may not be a parameter, receiver or global variable"
+}
+
+// Errors:
+//
+//    - fetch-failed --
+func LeakedGroup() error { // want LeakedGroup:"ErrorCodes: fetch-failed"
+	var g errgroup.Group
+	g.Go(fetch)
+	addTasks(&g) // want "unsupported: error group may not be passed to other functions"
+	return g.Wait()
+}
+
+func addTasks(g *errgroup.Group) {}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
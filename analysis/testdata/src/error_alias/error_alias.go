@@ -0,0 +1,99 @@
+package error_alias
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// CleanupError is an alias of error, functions returning it are analysed like functions returning error.
+type CleanupError = error
+
+// NamedError is a defined interface type equal to error.
+type NamedError error
+
+type ErrorWithContext interface {
+	error
+	Context() string
+}
+
+type CodedError interface {
+	error
+	Code() string
+}
+
+// Errors:
+//
+//    - alias-error-cleanup --
+func Cleanup() CleanupError { // want Cleanup:"ErrorCodes: alias-error-cleanup"
+	return &Error{"alias-error-cleanup"}
+}
+
+// Errors:
+//
+//    - alias-error-named --
+func Named() NamedError { // want Named:"ErrorCodes: alias-error-named"
+	return &Error{"alias-error-named"}
+}
+
+// Errors:
+//
+//    - alias-error-coded --
+func Coded() CodedError { // want Coded:"ErrorCodes: alias-error-coded"
+	return &Error{"alias-error-coded"}
+}
+
+// Errors:
+//
+//    - alias-error-cleanup --
+func Multi() (int, CleanupError) { // want Multi:"ErrorCodes: alias-error-cleanup"
+	return 0, Cleanup()
+}
+
+type Cleaner interface { // want Cleaner:"ErrorInterface: Clean"
+	// Errors:
+	//
+	//    - alias-error-cleanup --
+	Clean() CleanupError // want Clean:"ErrorCodes: alias-error-cleanup"
+}
+
+type fileCleaner struct{}
+
+// Errors:
+//
+//    - alias-error-cleanup --
+func (fileCleaner) Clean() CleanupError { // want Clean:"ErrorCodes: alias-error-cleanup"
+	return Cleanup()
+}
+
+var _ Cleaner = fileCleaner{}
+
+// Errors:
+//
+//    - alias-error-named --
+func Converted() error { // want Converted:"ErrorCodes: alias-error-named"
+	return NamedError(Named())
+}
+
+// Errors:
+//
+//    - alias-error-context --
+func WithContext() ErrorWithContext { // want WithContext:"ErrorCodes: alias-error-context"
+	return &contextError{"alias-error-context"}
+}
+
+type contextError struct{ TheCode string } // want contextError:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *contextError) Code() string    { return e.TheCode }
+func (e *contextError) Error() string   { return e.TheCode }
+func (e *contextError) Context() string { return "" }
+
+func wrap(code string, cause NamedError) CleanupError { // want wrap:"ErrorConstructor: {CodeParamPosition:0}"
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - alias-error-wrapped --
+func Wrapped() error { // want Wrapped:"ErrorCodes: alias-error-wrapped"
+	return wrap("alias-error-wrapped", Named())
+}
@@ -0,0 +1,94 @@
+package errorcollections
+
+// Errors is a collection of errors, e.g. of a batch operation.
+type Errors []error
+
+func (errs Errors) First() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// AsError combines all errors of the collection into a single error.
+//
+// Errors:
+//
+//    - batch-failed --
+func (errs Errors) AsError() error { // want AsError:"ErrorCodes: batch-failed"
+	if len(errs) == 0 {
+		return nil
+	}
+	return &Error{"batch-failed"}
+}
+
+// Errors:
+//
+//    - invalid-key   --
+//    - access-denied --
+func validate(key string) error { // want validate:"ErrorCodes: access-denied invalid-key"
+	if key == "" {
+		return &Error{"invalid-key"}
+	}
+	return &Error{"access-denied"}
+}
+
+// Errors:
+//
+//    - invalid-key   --
+//    - access-denied --
+//    - too-many-keys --
+func ValidateAll(keys []string) Errors { // want ValidateAll:"ErrorCodes: access-denied invalid-key too-many-keys"
+	if len(keys) > 100 {
+		return Errors{&Error{"too-many-keys"}}
+	}
+
+	var errs Errors
+	for _, key := range keys {
+		if err := validate(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Errors:
+//
+//    - invalid-key   --
+//    - access-denied --
+//    - too-many-keys --
+//    - empty         --
+func ValidateAllOrEmpty(keys []string) Errors { // want ValidateAllOrEmpty:"ErrorCodes: access-denied empty invalid-key too-many-keys"
+	if len(keys) == 0 {
+		return nil
+	}
+	more := []error{&Error{"empty"}}
+	return append(ValidateAll(keys), more...)
+}
+
+// Errors:
+//
+//    - invalid-key   --
+//    - access-denied --
+//    - too-many-keys --
+func First(keys []string) error { // want First:"ErrorCodes: access-denied invalid-key too-many-keys"
+	errs := ValidateAll(keys)
+	return errs.First()
+}
+
+// Errors:
+//
+//    - batch-failed --
+func Combined(keys []string) error { // want Combined:"ErrorCodes: batch-failed"
+	return ValidateAll(keys).AsError()
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,35 @@
+package errorconstructor
+
+import "log"
+
+// LogError creates an error, which is not returned, so the call is not analysed with the returned errors.
+func LogError(code string) {
+	log.Print(NewError2(code)) // want `require an error code parameter declaration to use "code" as an error code`
+}
+
+// LogConstantError creates an error with a constant code, which is fine without declarations.
+func LogConstantError() {
+	log.Print(NewError2("logged-error"))
+}
+
+// StoreError stores an error, whose code is not constant.
+func StoreError(codes map[string]string, errs []error) {
+	errs[0] = NewError2(codes["some"]) // want `error code has to be constant value or error code parameter`
+}
+
+// Errors:
+//
+//    - param: code (prefix "prefix-") --
+func LogPrefixed(code string) error { // want LogPrefixed:`ErrorConstructor: {CodeParamPosition:0, Prefix:"prefix-"}` LogPrefixed:"ErrorCodes:"
+	log.Print(NewError2("prefix-" + code))
+	log.Print(NewError2(code)) // want `error code parameter "code" is used with prefix "", but is declared with prefix "prefix-"`
+	return nil
+}
+
+// Errors:
+//
+//    - param: code --
+func LogDeclared(code string) error { // want LogDeclared:"ErrorConstructor: {CodeParamPosition:0}" LogDeclared:"ErrorCodes:"
+	log.Print(NewError2(code))
+	return nil
+}
@@ -0,0 +1,46 @@
+package errorconstructor
+
+import "error_constructor/inner"
+
+// Errors:
+//
+//    - inner-error --
+func CallInnerConstructor() error { // want CallInnerConstructor:"ErrorCodes: inner-error"
+	return inner.New("inner-error")
+}
+
+// Errors:
+//
+//    - wrapped-error --
+func CallInnerWrap() error { // want CallInnerWrap:"ErrorCodes: wrapped-error"
+	return inner.Wrap(nil, "wrapped-error")
+}
+
+// Errors:
+//
+//    - factory-error --
+func CallInnerMethodConstructor() error { // want CallInnerMethodConstructor:"ErrorCodes: factory-error"
+	return inner.Factory{}.New("message", "factory-error")
+}
+
+// Errors:
+//
+//    - expression-error --
+func CallInnerMethodExpressionConstructor() error { // want CallInnerMethodExpressionConstructor:"ErrorCodes: expression-error"
+	return inner.Factory.New(inner.Factory{}, "message", "expression-error")
+}
+
+// Errors:
+//
+//    - param: code --
+func ForwardToInnerConstructor(code string) error { // want ForwardToInnerConstructor:"ErrorConstructor: {CodeParamPosition:0}" ForwardToInnerConstructor:"ErrorCodes:"
+	return inner.New(code)
+}
+
+// Errors:
+//
+//    - variable-error --
+func CallInnerConstructorWithVariable() error { // want CallInnerConstructorWithVariable:"ErrorCodes: variable-error"
+	code := "variable-error"
+	return inner.New(code)
+}
@@ -117,10 +117,56 @@ func RecursiveConstructor(code string) error { // want RecursiveConstructor:"Err
 	return NewError2("param-error")
 }
 
-// Errors: none
-func InvalidCallConstructor() error { // want InvalidCallConstructor:"ErrorCodes:"
+// Errors:
+//
+//    - some-error --
+func CallConstructorWithVariable() error { // want CallConstructorWithVariable:"ErrorCodes: some-error"
 	var someCode string = "some-error"
-	return NewError2(someCode) // want `error code has to be constant value or error code parameter`
+	return NewError2(someCode)
+}
+
+// Errors:
+//
+//    - some-error  --
+//    - other-error --
+func CallConstructorWithReassignedVariable(flag bool) error { // want CallConstructorWithReassignedVariable:"ErrorCodes: other-error some-error"
+	someCode := "some-error"
+	if flag {
+		otherCode := "other-error"
+		someCode = otherCode
+	}
+	return NewError2(someCode)
+}
+
+// Errors:
+//
+//    - param: code --
+//    - some-error  --
+func CallConstructorWithVariableFromParam(code string, flag bool) error { /*
+		want
+			CallConstructorWithVariableFromParam:"ErrorConstructor: {CodeParamPosition:0}"
+			CallConstructorWithVariableFromParam:"ErrorCodes: some-error" */
+	someCode := code
+	if flag {
+		someCode = "some-error"
+	}
+	return NewError2(someCode)
+}
+
+// Errors: none
+func InvalidCallConstructor(message string) error { // want InvalidCallConstructor:"ErrorCodes:"
+	someCode := message // want `require an error code parameter declaration to use "message" as an error code`
+	return NewError2(someCode)
+}
+
+// Errors: none
+func InvalidCallConstructorWithCallResult() error { // want InvalidCallConstructorWithCallResult:"ErrorCodes:"
+	someCode, _ := codeAndFlag() // want `error code has to be constant value or error code parameter`
+	return NewError2(someCode)
+}
+
+func codeAndFlag() (string, bool) {
+	return "some-error", true
 }
 
 // Errors: none
@@ -137,17 +183,17 @@ func InvalidCallConstructor3() error { // want InvalidCallConstructor3:"ErrorCod
 // Errors:
 //
 //    - param: code --
-func InvalidUseOfCodeParam(code string) error { // want InvalidUseOfCodeParam:"ErrorConstructor: {CodeParamPosition:0}" InvalidUseOfCodeParam:"ErrorCodes:"
+func UseOfCodeParamInImmediatelyInvokedLambda(code string) error { // want UseOfCodeParamInImmediatelyInvokedLambda:"ErrorConstructor: {CodeParamPosition:0}" UseOfCodeParamInImmediatelyInvokedLambda:"ErrorCodes:"
 	return func() error {
-		return &Error{code} // want "error code has to be constant value or error code parameter"
+		return &Error{code}
 	}()
 }
 
 // Errors:
 //
 //    - param: code --
-func InvalidUseOfCodeParam2(code string) error { // want InvalidUseOfCodeParam2:"ErrorConstructor: {CodeParamPosition:0}" InvalidUseOfCodeParam2:"ErrorCodes:"
-	lambda := NewError2 // want `unsupported use of error constructor "NewError2"`
+func ForwardToConstructorValue(code string) error { // want ForwardToConstructorValue:"ErrorConstructor: {CodeParamPosition:0}" ForwardToConstructorValue:"ErrorCodes:"
+	lambda := NewError2
 	return lambda(code)
 }
 
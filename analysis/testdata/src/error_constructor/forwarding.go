@@ -0,0 +1,46 @@
+package errorconstructor
+
+// NewForwarding is a constructor forwarding its error code parameter to an undocumented helper.
+//
+// Errors:
+//
+//    - param: code --
+func NewForwarding(code string) error { // want NewForwarding:"ErrorConstructor: {CodeParamPosition:0}" NewForwarding:"ErrorCodes:"
+	return newErrorInternal("forwarded", code)
+}
+
+// newErrorInternal uses the code forwarded by NewForwarding without declaring it.
+func newErrorInternal(message string, code string) error { // want newErrorInternal:"ErrorConstructor: {CodeParamPosition:1}"
+	return newErrorNested(code)
+}
+
+// newErrorNested is only reached through newErrorInternal.
+func newErrorNested(code string) error { // want newErrorNested:"ErrorConstructor: {CodeParamPosition:0}"
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - param: code (prefix "forwarded-") --
+func NewPrefixedForwarding(code string) error { // want NewPrefixedForwarding:`ErrorConstructor: {CodeParamPosition:0, Prefix:"forwarded-"}` NewPrefixedForwarding:"ErrorCodes:"
+	return newPrefixedInternal(code)
+}
+
+func newPrefixedInternal(code string) error { // want newPrefixedInternal:`ErrorConstructor: {CodeParamPosition:0, Prefix:"forwarded-"}`
+	return &Error{"forwarded-" + code}
+}
+
+// Errors:
+//
+//    - forwarded-error        --
+//    - forwarded-nested-error --
+//    - forwarded-timeout      --
+func CallForwardingConstructors() error { // want CallForwardingConstructors:"ErrorCodes: forwarded-error forwarded-nested-error forwarded-timeout"
+	switch {
+	case true:
+		return NewForwarding("forwarded-error")
+	case false:
+		return newErrorNested("forwarded-nested-error")
+	}
+	return NewPrefixedForwarding("timeout")
+}
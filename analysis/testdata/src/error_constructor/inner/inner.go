@@ -0,0 +1,47 @@
+package inner
+
+// New creates an error with the given error code.
+//
+// Errors:
+//
+//    - param: code --
+func New(code string) error { // want New:"ErrorConstructor: {CodeParamPosition:0}" New:"ErrorCodes:"
+	return &Error{code}
+}
+
+// Wrap creates an error with the given error code, wrapping the given cause.
+//
+// Errors:
+//
+//    - param: code --
+func Wrap(cause error, code string) error { // want Wrap:"ErrorConstructor: {CodeParamPosition:1}" Wrap:"ErrorCodes:"
+	return &Error{code}
+}
+
+// NewStorage creates an error with the given error code, prefixed with "storage-".
+//
+// Errors:
+//
+//    - param: code (prefix "storage-") --
+func NewStorage(code string) error { // want NewStorage:`ErrorConstructor: {CodeParamPosition:0, Prefix:"storage-"}` NewStorage:"ErrorCodes:"
+	return &Error{"storage-" + code}
+}
+
+type Factory struct{}
+
+// New creates an error with the given error code.
+// The receiver does not count for the position of the error code parameter.
+//
+// Errors:
+//
+//    - param: code --
+func (Factory) New(message, code string) error { // want New:"ErrorConstructor: {CodeParamPosition:1}" New:"ErrorCodes:"
+	return &Error{code}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
@@ -0,0 +1,79 @@
+package errorconstructor
+
+type Factory struct {
+	component string
+}
+
+// Fail creates an error with the given error code.
+//
+// Errors:
+//
+//    - param: code --
+func (f *Factory) Fail(code string) error { // want Fail:"ErrorConstructor: {CodeParamPosition:0}" Fail:"ErrorCodes:"
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - factory-error --
+func CallFactoryMethod() error { // want CallFactoryMethod:"ErrorCodes: factory-error"
+	f := &Factory{}
+	return f.Fail("factory-error")
+}
+
+// Errors:
+//
+//    - factory-value-error --
+func CallFactoryValueMethod() error { // want CallFactoryValueMethod:"ErrorCodes: factory-value-error"
+	var f Factory
+	return f.Fail("factory-value-error")
+}
+
+// Errors:
+//
+//    - factory-expression-error --
+func CallFactoryMethodExpression() error { // want CallFactoryMethodExpression:"ErrorCodes: factory-expression-error"
+	return (*Factory).Fail(&Factory{}, "factory-expression-error")
+}
+
+// Errors:
+//
+//    - factory-method-value-error --
+func CallFactoryMethodValue() error { // want CallFactoryMethodValue:"ErrorCodes: factory-method-value-error"
+	fail := (&Factory{}).Fail
+	return fail("factory-method-value-error")
+}
+
+// Errors:
+//
+//    - factory-expression-value-error --
+func CallFactoryMethodExpressionValue() error { // want CallFactoryMethodExpressionValue:"ErrorCodes: factory-expression-value-error"
+	fail := (*Factory).Fail
+	return fail(&Factory{}, "factory-expression-value-error")
+}
+
+// Errors:
+//
+//    - constructor-value-error --
+func CallConstructorValue() error { // want CallConstructorValue:"ErrorCodes: constructor-value-error"
+	newError := NewError2
+	return newError("constructor-value-error")
+}
+
+// Errors:
+//
+//    - param: code --
+func (f *Factory) Forward(code string) error { // want Forward:"ErrorConstructor: {CodeParamPosition:0}" Forward:"ErrorCodes:"
+	return f.Fail(code)
+}
+
+// Errors:
+//
+//    - param: code --
+func (f *Factory) ForwardToHelper(code string) error { // want ForwardToHelper:"ErrorConstructor: {CodeParamPosition:0}" ForwardToHelper:"ErrorCodes:"
+	return f.fail(code)
+}
+
+func (f *Factory) fail(code string) error { // want fail:"ErrorConstructor: {CodeParamPosition:0}"
+	return &Error{code}
+}
@@ -0,0 +1,96 @@
+package errorconstructor
+
+import "error_constructor/inner"
+
+// NewPrefixed creates an error with the given error code, prefixed with "prefix-".
+//
+// Errors:
+//
+//    - param: code (prefix "prefix-") --
+//    - unknown-error                   --
+func NewPrefixed(code string, flag bool) error { // want NewPrefixed:`ErrorConstructor: {CodeParamPosition:0, Prefix:"prefix-"}` NewPrefixed:"ErrorCodes: unknown-error"
+	if flag {
+		return &Error{"prefix-" + code}
+	}
+	return &Error{"unknown-error"}
+}
+
+// Errors:
+//
+//    - param: code (prefix "prefix-error-") --
+func NewNestedPrefix(code string) error { // want NewNestedPrefix:`ErrorConstructor: {CodeParamPosition:0, Prefix:"prefix-error-"}` NewNestedPrefix:"ErrorCodes:"
+	const prefix = "prefix-"
+	return &Error{prefix + "error-" + code}
+}
+
+// Errors:
+//
+//    - prefix-timeout --
+//    - unknown-error  --
+func CallPrefixedConstructor() error { // want CallPrefixedConstructor:"ErrorCodes: prefix-timeout unknown-error"
+	return NewPrefixed("timeout", true)
+}
+
+// Errors:
+//
+//    - prefix-timeout  --
+//    - prefix-conflict --
+//    - unknown-error   --
+func CallPrefixedConstructorWithVariable(flag bool) error { // want CallPrefixedConstructorWithVariable:"ErrorCodes: prefix-conflict prefix-timeout unknown-error"
+	code := "timeout"
+	if flag {
+		code = "conflict"
+	}
+	return NewPrefixed(code, true)
+}
+
+// Errors:
+//
+//    - storage-full --
+func CallInnerPrefixedConstructor() error { // want CallInnerPrefixedConstructor:"ErrorCodes: storage-full"
+	return inner.NewStorage("full")
+}
+
+// Errors: none
+func CallPrefixedConstructorWithoutCode() error { // want CallPrefixedConstructorWithoutCode:"ErrorCodes:"
+	return inner.NewStorage("")
+}
+
+// Errors:
+//
+//    - param: code (prefix "prefix-") --
+//    - unknown-error                   --
+func ForwardToPrefixedConstructor(code string) error { // want ForwardToPrefixedConstructor:`ErrorConstructor: {CodeParamPosition:0, Prefix:"prefix-"}` ForwardToPrefixedConstructor:"ErrorCodes: unknown-error"
+	return NewPrefixed(code, true)
+}
+
+// Errors:
+//
+//    - param: code --
+func InvalidForwardToPrefixedConstructor(code string) error { // want InvalidForwardToPrefixedConstructor:"ErrorConstructor: {CodeParamPosition:0}" InvalidForwardToPrefixedConstructor:"ErrorCodes:"
+	return inner.NewStorage(code) // want `error code parameter "code" is used with prefix "storage-", but is declared with prefix ""`
+}
+
+// Errors:
+//
+//    - param: code (prefix "prefix-") --
+func InvalidPrefixUse(code string, flag bool) error { // want InvalidPrefixUse:`ErrorConstructor: {CodeParamPosition:0, Prefix:"prefix-"}` InvalidPrefixUse:"ErrorCodes:"
+	if flag {
+		return &Error{"other-" + code} // want `error code parameter "code" is used with prefix "other-", but is declared with prefix "prefix-"`
+	}
+	return &Error{code} // want `error code parameter "code" is used with prefix "", but is declared with prefix "prefix-"`
+}
+
+// Errors:
+//
+//    - param: code (prefix "-invalid") --
+func InvalidPrefix(code string) error { // want `function "InvalidPrefix" has odd docstring: error code parameter has invalid prefix "-invalid": codes starting with the prefix have to be valid error codes`
+	return &Error{"-invalid" + code}
+}
+
+// Errors:
+//
+//    - param: code (suffix "-error") --
+func InvalidPrefixOption(code string) error { // want `function "InvalidPrefixOption" has odd docstring: error code parameter has invalid option \(suffix "-error"\): should be \(prefix "<prefix>"\)`
+	return &Error{code + "-error"}
+}
@@ -0,0 +1,109 @@
+package errorcontainers
+
+import "error_containers/inner"
+
+// Result holds either a value or an error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func (r Result[T]) Value() T {
+	return r.value
+}
+
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func Fetch(key string) Result[string] { // want Fetch:"ErrorCodes: fetch-empty fetch-failed"
+	if key == "" {
+		return Result[string]{"", &Error{"fetch-empty"}}
+	}
+	if key == "fail" {
+		return Result[string]{err: &Error{"fetch-failed"}}
+	}
+	return Ok(key)
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func FetchPointer(key string) *Result[int] { // want FetchPointer:"ErrorCodes: fetch-empty fetch-failed"
+	if key == "" {
+		return &Result[int]{0, &Error{"fetch-empty"}}
+	}
+	return &Result[int]{err: &Error{"fetch-failed"}}
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func FetchTwice(key string) Result[string] { // want FetchTwice:"ErrorCodes: fetch-empty fetch-failed"
+	result := Fetch(key)
+	if result.Err() != nil {
+		return result
+	}
+	return Fetch(result.Value())
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func Use(key string) error { // want Use:"ErrorCodes: fetch-empty fetch-failed"
+	result := Fetch(key)
+	if err := result.Err(); err != nil {
+		return err
+	}
+	return Fetch(result.Value()).Err()
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func UseInferred(key string) error { // want UseInferred:"ErrorCodes: fetch-empty fetch-failed"
+	return FetchTwice(key).Err()
+}
+
+// Errors: none -- Ok never contains an error.
+func UseOk() error { // want UseOk:"ErrorCodes: "
+	result := Ok(42)
+	return result.Err()
+}
+
+// Errors:
+//
+//    - fetch-failed --
+//    - fetch-empty  --
+func UsePointer(key string) error { // want UsePointer:"ErrorCodes: fetch-empty fetch-failed"
+	return FetchPointer(key).Err()
+}
+
+// Errors:
+//
+//    - load-failed --
+func UseOtherPackage() error { // want UseOtherPackage:"ErrorCodes: load-failed"
+	return inner.Load().Err()
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,27 @@
+package inner
+
+type Result struct {
+	Value string
+	err   error
+}
+
+func (r *Result) Err() error {
+	return r.err
+}
+
+// Errors:
+//
+//    - load-failed --
+func Load() *Result { // want Load:"ErrorCodes: load-failed"
+	return &Result{err: &Error{"load-failed"}}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
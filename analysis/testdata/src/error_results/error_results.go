@@ -0,0 +1,81 @@
+package error_results
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - error-results-first --
+func ErrorFirst() (error, int) { // want ErrorFirst:"ErrorCodes: error-results-first"
+	return &Error{"error-results-first"}, 1
+}
+
+// Errors:
+//
+//    - error-results-first --
+func ErrorFirstMissing() (error, int) { // want ErrorFirstMissing:"ErrorCodes: error-results-first" `function "ErrorFirstMissing" has a mismatch of declared and actual error codes: missing codes: \[error-results-missing]`
+	if false {
+		return &Error{"error-results-missing"}, 0
+	}
+	return &Error{"error-results-first"}, 1
+}
+
+// Errors:
+//
+//    - error-results-first --
+//    - error-results-second --
+func TwoErrors() (error, error) { // want TwoErrors:"ErrorCodes: error-results-first error-results-second"
+	if false {
+		return &Error{"error-results-first"}, nil
+	}
+	return nil, &Error{"error-results-second"}
+}
+
+// Errors:
+//
+//    - error-results-first --
+func TwoErrorsUnused() (error, error) { // want TwoErrorsUnused:"ErrorCodes: error-results-first" `function "TwoErrorsUnused" has a mismatch of declared and actual error codes: missing codes: \[error-results-second]`
+	return &Error{"error-results-first"}, &Error{"error-results-second"}
+}
+
+// Errors:
+//
+//    - error-results-first --
+//    - error-results-second --
+func NamedResults() (first error, n int, second error) { // want NamedResults:"ErrorCodes: error-results-first error-results-second"
+	first = &Error{"error-results-first"}
+	second = &Error{"error-results-second"}
+	return
+}
+
+// Errors:
+//
+//    - error-results-first --
+//    - error-results-second --
+func ForwardCall() (error, error) { // want ForwardCall:"ErrorCodes: error-results-first error-results-second"
+	return TwoErrors()
+}
+
+// Errors:
+//
+//    - error-results-first --
+func DestructFirst() error { // want DestructFirst:"ErrorCodes: error-results-first"
+	err, _ := ErrorFirst()
+	return err
+}
+
+// Errors:
+//
+//    - error-results-first --
+//    - error-results-second --
+func DestructBoth() error { // want DestructBoth:"ErrorCodes: error-results-first error-results-second"
+	first, second := TwoErrors()
+	if first != nil {
+		return first
+	}
+	return second
+}
@@ -0,0 +1,39 @@
+package error_struct
+
+import "github.com/serum-errors/go-serum-analyzer/rerr"
+
+// Errors:
+//
+//    - app-error-not-found -- if the item does not exist
+//    - app-error-forbidden -- if the item may not be read
+func Read(name string) *rerr.ErrorStruct { // want Read:"ErrorCodes: app-error-forbidden app-error-not-found"
+	switch name {
+	case "":
+		return &rerr.ErrorStruct{TheTag: "app-error-not-found", TheMessage: "no such item"}
+	case "secret":
+		return &rerr.ErrorStruct{"app-error-forbidden", "may not be read", nil}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - app-error-not-found -- if the item does not exist
+func ReadMissing(name string) error { // want ReadMissing:"ErrorCodes: app-error-not-found" `function "ReadMissing" has a mismatch of declared and actual error codes: missing codes: \[app-error-forbidden]`
+	if err := Read(name); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - app-error-invalid -- if the name is empty
+func Validate(name string) error { // want Validate:"ErrorCodes: app-error-invalid"
+	if name == "" {
+		err := &rerr.ErrorStruct{TheMessage: "empty name"}
+		err.TheTag = "app-error-invalid"
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package errorunion
+
+// combine returns the first of the given errors, which is not nil.
+//
+// Errors:
+//
+//    - param: errs -- union
+func combine(errs ...error) error { // want combine:"ErrorUnion: {ParamPosition:0}" combine:"ErrorCodes:"
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - param: errs  -- union
+//    - no-errors    --
+func first(message string, errs ...error) error { // want first:"ErrorUnion: {ParamPosition:1}" first:"ErrorCodes: no-errors"
+	if len(errs) == 0 {
+		return &Error{"no-errors"}
+	}
+	return errs[0]
+}
+
+// Errors:
+//
+//    - not-found --
+func fetch() error { // want fetch:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func Combine() error { // want Combine:"ErrorCodes: access-denied not-found"
+	return combine(&Error{"access-denied"}, fetch())
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func CombineSlice() error { // want CombineSlice:"ErrorCodes: access-denied not-found"
+	errs := []error{&Error{"access-denied"}}
+	errs = append(errs, fetch())
+	return combine(errs...)
+}
+
+// Errors:
+//
+//    - no-errors --
+//    - not-found --
+func First() error { // want First:"ErrorCodes: no-errors not-found"
+	err := first("fetching", fetch())
+	return err
+}
+
+// Errors: none
+func CombineNothing() error { // want CombineNothing:"ErrorCodes:"
+	return combine()
+}
+
+type joiner struct{}
+
+// Errors:
+//
+//    - param: errs --
+func (j *joiner) Join(errs ...error) error { // want Join:"ErrorUnion: {ParamPosition:0}" Join:"ErrorCodes:"
+	return combine(errs...)
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func Join(j *joiner) error { // want Join:"ErrorCodes: access-denied not-found"
+	if j != nil {
+		return j.Join(fetch())
+	}
+	return (*joiner).Join(j, &Error{"access-denied"})
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
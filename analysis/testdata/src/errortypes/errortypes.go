@@ -26,7 +26,8 @@ package errortypes
 //    - combined-2-error --
 //    - combined-3-error --
 //    - string-error     --
-func AllErrors() error { // want AllErrors:"ErrorCodes: combined-1-error combined-2-error combined-3-error field-1-error field-2-error field-3-error field-4-error field-5-error field-6-error multiple-1-error multiple-2-error multiple-3-error promoted-1-error promoted-2-error promoted-3-error some-2-error some-3-error some-4-error some-error string-error value-1-error value-2-error"
+//    - string-2-error   --
+func AllErrors() error { // want AllErrors:"ErrorCodes: combined-1-error combined-2-error combined-3-error field-1-error field-2-error field-3-error field-4-error field-5-error field-6-error multiple-1-error multiple-2-error multiple-3-error promoted-1-error promoted-2-error promoted-3-error some-2-error some-3-error some-4-error some-error string-2-error string-error value-1-error value-2-error"
 	var someVariable string
 	switch {
 	case true:
@@ -86,7 +87,7 @@ func AllErrors() error { // want AllErrors:"ErrorCodes: combined-1-error combine
 	case true:
 		return ValidStringError("some error text")
 	case true:
-		return InvalidStringError("string-2-error") // want "expression is not a valid error: error types must return constant error codes or a single field"
+		return ValueStringError("string-2-error")
 	}
 	return nil
 }
@@ -229,10 +230,10 @@ type ValidStringError string // want ValidStringError:`ErrorType{Field:<nil>, Co
 func (e ValidStringError) Code() string  { return "string-error" }
 func (e ValidStringError) Error() string { return "ValidStringError" }
 
-type InvalidStringError string // want `type "InvalidStringError" is an invalid error type: could not find any error codes`
+type ValueStringError string // want ValueStringError:`ErrorType{Field:<nil>, Codes:, Underlying:true}`
 
-func (e InvalidStringError) Code() string  { return string(e) } // want `function "Code" should always return a string constant or a single field`
-func (e InvalidStringError) Error() string { return "InvalidStringError" }
+func (e ValueStringError) Code() string  { return string(e) }
+func (e ValueStringError) Error() string { return "ValueStringError" }
 
 type ModifyingError1 struct { // want ModifyingError1:`ErrorType{Field:{Name:"code", Position:0}, Codes:replaced-1-error replaced-2-error replaced-3-error}`
 	code         string
@@ -22,7 +22,3 @@ func DeadBranchError() error { // want DeadBranchError:"ErrorCodes: example-erro
 	}
 	return nil
 }
-
-func ErrorNotLast() (error, string) { // want "error should be returned as the last argument"
-	return nil, ""
-}
@@ -0,0 +1,10 @@
+package externaltests
+
+// Lookup exports lookup to the external test package.
+//
+// Errors:
+//
+//    - external-not-found --
+func Lookup(key string) error { // want Lookup:"ErrorCodes: external-not-found"
+	return lookup(key)
+}
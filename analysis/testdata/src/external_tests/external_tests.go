@@ -0,0 +1,29 @@
+package externaltests
+
+// Errors:
+//
+//    - external-not-found --
+//    - external-invalid   --
+func Find(key string) error { // want Find:"ErrorCodes: external-invalid external-not-found"
+	if key == "" {
+		return &Error{"external-invalid"}
+	}
+	return lookup(key)
+}
+
+// Errors:
+//
+//    - external-not-found --
+func lookup(key string) error { // want lookup:"ErrorCodes: external-not-found"
+	return &Error{"external-not-found"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
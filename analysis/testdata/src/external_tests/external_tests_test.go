@@ -0,0 +1,42 @@
+package externaltests_test
+
+import (
+	"testing"
+
+	"external_tests"
+)
+
+// The external test package uses the facts of the package under test, including the test variant's exports.
+func TestFind(t *testing.T) {
+	err := externaltests.Find("key")
+	if err.(*externaltests.Error).Code() == "external-not-fuond" { // want `error code "external-not-fuond" is compared with an error which can never carry it, possible codes: \[external-invalid external-not-found\]`
+		t.Fatal(err)
+	}
+
+	lookupErr := externaltests.Lookup("key")
+	if lookupErr.(*externaltests.Error).Code() == "external-invalid" { // want `error code "external-invalid" is compared with an error which can never carry it, possible codes: \[external-not-found\]`
+		t.Fatal(lookupErr)
+	}
+}
+
+// Errors:
+//
+//    - external-invalid   --
+//    - external-not-found --
+func find(key string) error { // want find:"ErrorCodes: external-invalid external-not-found"
+	return externaltests.Find(key)
+}
+
+// Errors:
+//
+//    - external-not-found --
+func findMismatch() error { // want findMismatch:"ErrorCodes: external-not-found" `function "findMismatch" has a mismatch of declared and actual error codes: missing codes: \[external-invalid\]`
+	return externaltests.Find("key")
+}
+
+// Errors:
+//
+//    - external-not-found --
+func lookup() error { // want lookup:"ErrorCodes: external-not-found"
+	return externaltests.Lookup("key")
+}
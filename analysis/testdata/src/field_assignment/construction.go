@@ -0,0 +1,60 @@
+package fieldassignment
+
+// Errors:
+//
+//    - oops-error --
+func AssignAfterEmptyConstruction() error { // want AssignAfterEmptyConstruction:"ErrorCodes: oops-error"
+	e := &Error{}
+	e.TheCode = "oops-error"
+	return e
+}
+
+// Errors:
+//
+//    - oops-error --
+func AssignAfterNew() error { // want AssignAfterNew:"ErrorCodes: oops-error"
+	e := new(Error)
+	e.TheCode = "oops-error"
+	return e
+}
+
+// Errors:
+//
+//    - oops-error --
+func AssignToValue() error { // want AssignToValue:"ErrorCodes: oops-error"
+	var e Error
+	e.TheCode = "oops-error"
+	return &e
+}
+
+// Errors:
+//
+//    - oops-error --
+func AssignThroughDereference() error { // want AssignThroughDereference:"ErrorCodes: oops-error"
+	e := &Error{}
+	(*e).TheCode = "oops-error"
+	return e
+}
+
+// Errors:
+//
+//    - oops-error --
+func AssignThroughParentheses() error { // want AssignThroughParentheses:"ErrorCodes: oops-error"
+	e := &Error{}
+	(e).TheCode = "oops-error"
+	return e
+}
+
+// Errors:
+//
+//    - oops-error  --
+//    - other-error --
+func AssignInBranches(flag bool) error { // want AssignInBranches:"ErrorCodes: oops-error other-error"
+	e := &Error{}
+	if flag {
+		e.TheCode = "oops-error"
+	} else {
+		e.TheCode = "other-error"
+	}
+	return e
+}
@@ -0,0 +1,72 @@
+package fieldassignment
+
+func (e *Error) WithCode(code string) *Error { // want WithCode:"ErrorCodeSetter: {CodeParamPosition:0}"
+	e.TheCode = code
+	return e
+}
+
+func (e *Error) SetCode(code string) { // want SetCode:"ErrorCodeSetter: {CodeParamPosition:0}"
+	e.TheCode = code
+}
+
+// Errors: none
+func NewEmpty() *Error { // want NewEmpty:"ErrorCodes:"
+	return &Error{}
+}
+
+// Errors:
+//
+//    - io-error --
+func ReturnWithCode() error { // want ReturnWithCode:"ErrorCodes: io-error"
+	return NewEmpty().WithCode("io-error")
+}
+
+// Errors:
+//
+//    - io-error    --
+//    - other-error --
+func ReturnAfterSetCode(flag bool) error { // want ReturnAfterSetCode:"ErrorCodes: io-error other-error"
+	e := &Error{}
+	e.SetCode("io-error")
+	if flag {
+		(*Error).SetCode(e, "other-error")
+	}
+	return e
+}
+
+// Errors:
+//
+//    - io-error --
+func ReturnAfterWithCode() error { // want ReturnAfterWithCode:"ErrorCodes: io-error"
+	var e Error
+	e.WithCode("io-error")
+	return &e
+}
+
+// Errors:
+//
+//    - param: code --
+func NewWithCode(code string) error { // want NewWithCode:"ErrorConstructor: {CodeParamPosition:0}" NewWithCode:"ErrorCodes:"
+	return NewEmpty().WithCode(code)
+}
+
+// Errors: none
+func SetNonConstantCode(code string) error { // want SetNonConstantCode:"ErrorCodes:"
+	return NewEmpty().WithCode(code) // want `require an error code parameter declaration to use "code" as an error code`
+}
+
+// Errors:
+//
+//    - io-error --
+func CallConstructorUsingSetter() error { // want CallConstructorUsingSetter:"ErrorCodes: io-error"
+	return NewWithCode("io-error")
+}
+
+// WithCodeAndMessage is no setter, as it does more than assigning fields.
+func (e *Error2) WithCodeAndMessage(code string) *Error2 { // want `function "WithCodeAndMessage" is exported, but does not declare any error codes`
+	e.TheCode = code // want `require an error code parameter declaration to use "code" as an error code`
+	if code == "" {
+		e.Other = "empty"
+	}
+	return e
+}
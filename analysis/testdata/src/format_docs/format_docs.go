@@ -0,0 +1,60 @@
+package formatdocs
+
+// Errors:
+//
+//   - some-error    -- if something happens.
+//   - another-error --
+func Canonical() error { // want Canonical:"ErrorCodes: another-error some-error"
+	if true {
+		return &Error{"some-error"}
+	}
+	return &Error{"another-error"}
+}
+
+// Some text that is not changed.
+//
+// Errors:
+//
+// - some-error -- if something happens.
+//  - another-error --
+//
+// More text that is not changed.
+func Unaligned() error { // want Unaligned:"ErrorCodes: another-error some-error"
+	if true {
+		return &Error{"some-error"}
+	}
+	return &Error{"another-error"}
+}
+
+// Errors:
+//
+//	- param:code -- the error code.
+//	- some-error --  is returned
+//     for more than one reason.
+func NewError(code string) error { // want NewError:"ErrorConstructor: {CodeParamPosition:0}" NewError:"ErrorCodes: some-error"
+	if code == "" {
+		return &Error{"some-error"}
+	}
+	return &Error{code}
+}
+
+type Task interface { // want Task:"ErrorInterface: Run"
+	// Errors:
+	//
+	//  - task-failed --
+	Run() error // want Run:"ErrorCodes: task-failed"
+}
+
+// Errors:
+// - invalid --
+func Invalid() {}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,81 @@
+package formatted_codes
+
+import "fmt"
+
+const prefix = "formatted"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - formatted-timeout --
+//    - formatted-error-3 --
+//    - formatted-nested-timeout --
+func Constant(attempt int) error { // want Constant:"ErrorCodes: formatted-error-3 formatted-nested-timeout formatted-timeout"
+	switch attempt {
+	case 0:
+		return &Error{fmt.Sprintf("%s-timeout", prefix)}
+	case 1:
+		return &Error{fmt.Sprintf("%s-error-%d", prefix, 3)}
+	}
+	return &Error{fmt.Sprintf("%s-timeout", fmt.Sprintf("%s-nested", prefix))}
+}
+
+const typedPrefix string = "formatted-typed"
+
+// Errors:
+//
+//    - formatted-typed-timeout --
+//    - formatted-error-timeout --
+//    - formatted-nested-error-timeout --
+//    - formatted-a-very-long-error-code-which-is-longer-than-the-short-form-of-constant-values --
+func Concatenated(attempt int) error { // want Concatenated:"ErrorCodes: formatted-a-very-long-error-code-which-is-longer-than-the-short-form-of-constant-values formatted-error-timeout formatted-nested-error-timeout formatted-typed-timeout"
+	switch attempt {
+	case 0:
+		return &Error{typedPrefix + "-timeout"}
+	case 1:
+		return &Error{fmt.Sprintf("%s-error", prefix) + "-timeout"}
+	case 2:
+		return &Error{prefix + "-" + fmt.Sprintf("nested-%s", "error") + "-timeout"}
+	}
+	return &Error{prefix + "-a-very-long-error-code-which-is-longer-than-the-short-form-of-constant-values"}
+}
+
+// Errors: none
+func NotConstant(name string) error { // want NotConstant:"ErrorCodes:"
+	switch name {
+	case "":
+		return &Error{fmt.Sprintf("%s-timeout", name)} // want "error code has to be constant value or error code parameter"
+	case "missing":
+		return &Error{fmt.Sprintf("%s-%s-timeout", prefix)} // want "error code has to be constant value or error code parameter"
+	case "concatenated":
+		return &Error{fmt.Sprintf("%s-error", prefix) + name} // want `require an error code parameter declaration to use "name" as an error code`
+	}
+	return &Error{fmt.Sprintf("%s-timeout", stringer(1))} // want "error code has to be constant value or error code parameter"
+}
+
+// Errors: none
+func InvalidFormat() error { // want InvalidFormat:"ErrorCodes:"
+	return &Error{fmt.Sprintf("%s timeout", prefix)} // want "error code has invalid format: .*"
+}
+
+type stringer int
+
+func (s stringer) String() string { return "stringer" }
+
+type FormattedCodeError struct{} // want FormattedCodeError:`ErrorType{Field:<nil>, Codes:formatted-fixed}`
+
+func (FormattedCodeError) Code() string  { return fmt.Sprintf("%s-fixed", prefix) }
+func (FormattedCodeError) Error() string { return "fixed" }
+
+// Errors:
+//
+//    - formatted-fixed --
+func Fixed() error { // want Fixed:"ErrorCodes: formatted-fixed"
+	return FormattedCodeError{}
+}
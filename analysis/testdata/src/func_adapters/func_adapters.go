@@ -0,0 +1,55 @@
+package funcadapters
+
+import "func_adapters/inner"
+
+// Errors:
+//
+//    - adapter-not-found --
+func notFound(path string) error { // want notFound:"ErrorCodes: adapter-not-found"
+	return &inner.Error{"adapter-not-found"}
+}
+
+// Errors:
+//
+//    - adapter-forbidden --
+func forbidden(path string) error { // want forbidden:"ErrorCodes: adapter-forbidden"
+	return &inner.Error{"adapter-forbidden"}
+}
+
+func Register() {
+	inner.Serve("/", inner.HandlerFunc(notFound))
+	inner.Serve("/admin", inner.HandlerFunc(forbidden)) // want `cannot use expression as "Handler" value: method "Handle" declares the following error codes which were not part of the interface: \[adapter-forbidden\]`
+	inner.Serve("/", inner.HandlerFunc(func(path string) error {
+		return &inner.Error{"adapter-invalid"}
+	}))
+
+	var handler inner.Handler = inner.HandlerFunc(notFound)
+	handler = inner.HandlerFunc(forbidden) // want `cannot use expression as "Handler" value: method "Handle" declares the following error codes which were not part of the interface: \[adapter-forbidden\]`
+	_ = handler
+
+	adapted := inner.HandlerFunc(forbidden)
+	inner.Serve("/adapted", adapted) // want `cannot use expression as "Handler" value: method "Handle" declares the following error codes which were not part of the interface: \[adapter-forbidden\]`
+}
+
+func RegisterFunc(fn inner.HandlerFunc) {
+	inner.Serve("/fn", fn) // want `unsupported: function passed as "HandlerFunc" has to be a function name, method value, function literal, local variable or a function value with a contract`
+}
+
+// checkedFunc declares a contract, so its method returns the codes of the contract.
+//
+// Errors:
+//
+//    - adapter-not-found --
+type checkedFunc func(path string) error // want checkedFunc:"ErrorCodes: adapter-not-found"
+
+// Errors:
+//
+//    - adapter-not-found --
+func (f checkedFunc) Handle(path string) error { // want Handle:"ErrorCodes: adapter-not-found"
+	return f(path)
+}
+
+func RegisterChecked() {
+	inner.Serve("/checked", checkedFunc(notFound))
+	inner.Serve("/checked", checkedFunc(forbidden)) // want `cannot use function as "checkedFunc": it returns the following error codes which are not part of the contract: \[adapter-forbidden\]`
+}
@@ -0,0 +1,26 @@
+package inner
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Handler handles a request.
+type Handler interface { // want Handler:"ErrorInterface: Handle"
+	// Errors:
+	//
+	//    - adapter-not-found --
+	//    - adapter-invalid   --
+	Handle(path string) error // want Handle:"ErrorCodes: adapter-invalid adapter-not-found"
+}
+
+// HandlerFunc adapts functions to the Handler interface.
+type HandlerFunc func(path string) error // want HandlerFunc:"ErrorFuncAdapter: Handle"
+
+// Handle calls f(path).
+func (f HandlerFunc) Handle(path string) error {
+	return f(path)
+}
+
+// Serve registers the handler for the given path.
+func Serve(path string, handler Handler) {}
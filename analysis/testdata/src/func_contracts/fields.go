@@ -0,0 +1,51 @@
+package funccontracts
+
+type handlers struct {
+	// Errors:
+	//
+	//    - handler-failed --
+	handler func() error // want handler:"ErrorCodes: handler-failed"
+
+	walk WalkFunc
+
+	undeclared func() error
+}
+
+// Errors:
+//
+//    - handler-failed --
+//    - walk-skip      --
+//    - walk-abort     --
+func (h *handlers) Run() error { // want Run:"ErrorCodes: handler-failed walk-abort walk-skip"
+	if err := h.handler(); err != nil {
+		return err
+	}
+	return h.walk("")
+}
+
+// Errors: none
+func (h *handlers) RunUndeclared() error { // want RunUndeclared:"ErrorCodes:"
+	return h.undeclared() // want "called function does not declare error codes"
+}
+
+// Errors:
+//
+//    - handler-failed --
+func handlerFailed() error { // want handlerFailed:"ErrorCodes: handler-failed"
+	return &Error{"handler-failed"}
+}
+
+func NewHandlers() *handlers {
+	h := &handlers{handler: handlerFailed, walk: skip}
+	h.handler = func() error { return &Error{"handler-failed"} }
+	h.handler = func() error { return &Error{"other-error"} } // want `cannot use function as "handler": it returns the following error codes which are not part of the contract: \[other-error\]`
+	_ = handlers{otherHandler, nil, nil}                      // want `cannot use function as "handler": it returns the following error codes which are not part of the contract: \[other-error\]`
+	return h
+}
+
+// Errors:
+//
+//    - other-error --
+func otherHandler() error { // want otherHandler:"ErrorCodes: other-error"
+	return &Error{"other-error"}
+}
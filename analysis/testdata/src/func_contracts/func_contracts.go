@@ -0,0 +1,85 @@
+package funccontracts
+
+// WalkFunc is called for every visited path.
+//
+// Errors:
+//
+//    - walk-skip  --
+//    - walk-abort --
+type WalkFunc func(path string) error // want WalkFunc:"ErrorCodes: walk-abort walk-skip"
+
+// Errors:
+//
+//    - walk-skip    --
+//    - walk-abort   --
+//    - walk-invalid --
+func Walk(root string, fn WalkFunc) error { // want Walk:"ErrorCodes: walk-abort walk-invalid walk-skip"
+	if root == "" {
+		return &Error{"walk-invalid"}
+	}
+	return fn(root)
+}
+
+// Errors:
+//
+//    - visit-failed --
+func Visit( // want Visit:"ErrorCodes: visit-failed"
+	root string,
+	// Errors:
+	//
+	//    - visit-failed --
+	fn func(path string) error,
+) error {
+	err := fn(root)
+	return err
+}
+
+// Errors:
+//
+//    - walk-skip --
+func skip(path string) error { // want skip:"ErrorCodes: walk-skip"
+	return &Error{"walk-skip"}
+}
+
+// Errors:
+//
+//    - other-error --
+func other(path string) error { // want other:"ErrorCodes: other-error"
+	return &Error{"other-error"}
+}
+
+func Callers(fn WalkFunc) {
+	_ = Walk("", skip)
+	_ = Walk("", other) // want `cannot use function as "WalkFunc": it returns the following error codes which are not part of the contract: \[other-error\]`
+	_ = Walk("", fn)
+	_ = Walk("", nil)
+	_ = Walk("", func(path string) error {
+		return &Error{"walk-abort"}
+	})
+	_ = Walk("", func(path string) error { // want `cannot use function as "WalkFunc": it returns the following error codes which are not part of the contract: \[lambda-error\]`
+		return &Error{"lambda-error"}
+	})
+
+	_ = Visit("", func(path string) error {
+		return &Error{"visit-failed"}
+	})
+	_ = Visit("", skip) // want `cannot use function as "fn": it returns the following error codes which are not part of the contract: \[walk-skip\]`
+}
+
+// Errors: none
+type NoErrors func() error // want NoErrors:"ErrorCodes:"
+
+// Errors:
+//
+//    - param: code --
+type Constructor func(code string) error // want "declaration of error constructors for function types is currently not supported"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,37 @@
+package funccontracts
+
+import "func_contracts/inner"
+
+// Errors:
+//
+//   - access-denied --
+func authorize(user string) error { // want authorize:"ErrorCodes: access-denied"
+	return &Error{"access-denied"}
+}
+
+// Errors:
+//
+//   - access-denied --
+//   - unknown-user  --
+func authorizeKnown(user string) error { // want authorizeKnown:"ErrorCodes: access-denied unknown-user"
+	if user == "" {
+		return &Error{"unknown-user"}
+	}
+	return &Error{"access-denied"}
+}
+
+func NewServer() *inner.Server {
+	server := &inner.Server{Authorize: authorize}
+	server.Authorize = authorizeKnown           // want `cannot use function as "Authorize": it returns the following error codes which are not part of the contract: \[unknown-user\]`
+	_ = inner.Server{Authorize: authorizeKnown} // want `cannot use function as "Authorize": it returns the following error codes which are not part of the contract: \[unknown-user\]`
+	server.Hooks.BeforeRequest = func() error { return &Error{"request-rejected"} }
+	server.Hooks.BeforeRequest = func() error { return &Error{"access-denied"} } // want `cannot use function as "BeforeRequest": it returns the following error codes which are not part of the contract: \[access-denied\]`
+	return server
+}
+
+// Errors:
+//
+//   - access-denied --
+func Authorize(server *inner.Server) error { // want Authorize:"ErrorCodes: access-denied"
+	return server.Authorize("user")
+}
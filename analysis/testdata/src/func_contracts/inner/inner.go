@@ -0,0 +1,47 @@
+package inner
+
+// Server calls its hooks for every request.
+type Server struct {
+	// Authorize is called before every request.
+	//
+	// Errors:
+	//
+	//    - access-denied --
+	Authorize func(user string) error // want Authorize:"ErrorCodes: access-denied"
+
+	Hooks struct {
+		// Errors:
+		//
+		//    - request-rejected --
+		BeforeRequest func() error // want BeforeRequest:"ErrorCodes: request-rejected"
+	}
+}
+
+// Errors:
+//
+//    - access-denied    --
+//    - request-rejected --
+func (s *Server) Handle(user string) error { // want Handle:"ErrorCodes: access-denied request-rejected"
+	if err := s.Authorize(user); err != nil {
+		return err
+	}
+	return s.Hooks.BeforeRequest()
+}
+
+// OnShutdown is called when the server shuts down.
+//
+// Errors:
+//
+//    - shutdown-timeout --
+var OnShutdown func() error = defaultShutdown // want OnShutdown:"ErrorCodes: shutdown-timeout"
+
+func defaultShutdown() error {
+	return nil
+}
+
+// Errors:
+//
+//    - shutdown-timeout --
+func Shutdown() error { // want Shutdown:"ErrorCodes: shutdown-timeout"
+	return OnShutdown()
+}
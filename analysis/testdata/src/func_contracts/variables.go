@@ -0,0 +1,57 @@
+package funccontracts
+
+import "func_contracts/inner"
+
+// Errors:
+//
+//    - hook-failed --
+var Hook = defaultHook // want Hook:"ErrorCodes: hook-failed"
+
+var (
+	// Errors:
+	//
+	//    - hook-failed --
+	BeforeHook func() error = otherHook // want BeforeHook:"ErrorCodes: hook-failed" `cannot use function as "BeforeHook": it returns the following error codes which are not part of the contract: \[other-error\]`
+
+	// Errors: none
+	AfterHook func() error // want AfterHook:"ErrorCodes:"
+)
+
+// Errors:
+//
+//    - hook-failed --
+func defaultHook() error { // want defaultHook:"ErrorCodes: hook-failed"
+	return &Error{"hook-failed"}
+}
+
+// Errors:
+//
+//    - other-error --
+func otherHook() error { // want otherHook:"ErrorCodes: other-error"
+	return &Error{"other-error"}
+}
+
+// Errors:
+//
+//    - hook-failed --
+func RunHooks() error { // want RunHooks:"ErrorCodes: hook-failed"
+	if err := BeforeHook(); err != nil {
+		return err
+	}
+	if err := AfterHook(); err != nil {
+		return err
+	}
+	return Hook()
+}
+
+func SetHooks() {
+	Hook = func() error { return &Error{"hook-failed"} }
+	Hook = otherHook // want `cannot use function as "Hook": it returns the following error codes which are not part of the contract: \[other-error\]`
+	AfterHook = nil
+	inner.OnShutdown = func() error { return &Error{"shutdown-timeout"} }
+	inner.OnShutdown = defaultHook // want `cannot use function as "OnShutdown": it returns the following error codes which are not part of the contract: \[hook-failed\]`
+}
+
+func SetHandlerToHook(h *handlers) {
+	h.handler = Hook // want `cannot use function as "handler": it returns the following error codes which are not part of the contract: \[hook-failed\]`
+}
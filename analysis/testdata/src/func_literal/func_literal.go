@@ -177,11 +177,6 @@ func OutOfBounds() *Error { // want OutOfBounds:"ErrorCodes:"
 	case true:
 		funcLit := returningLambda() // want `assignment to variable "funcLit" can only be an identifier or function literal`
 		return funcLit()
-	case true:
-		err := &Error{"context-error"}
-		return func() *Error {
-			return err // want "returned error may not be a parameter, global variable or other variables declared outside of the function body"
-		}()
 	}
 	return nil
 }
@@ -216,7 +211,7 @@ func ReturnTwinLambda() (func() error, func() error) {
 // Errors: none
 func InvalidErrorFromLambdaParam() error { // want InvalidErrorFromLambdaParam:"ErrorCodes"
 	return func(e error) error {
-		return e // want "returned error may not be a parameter, global variable or other variables declared outside of the function body"
+		return e // want "returned error may not be a parameter, receiver or global variable"
 	}(fmt.Errorf("error without code"))
 }
 
@@ -0,0 +1,54 @@
+package funcliteral
+
+// Errors:
+//
+//    - x-failed --
+//    - y-failed --
+func ImmediatelyInvokedLambda(flag bool) error { // want ImmediatelyInvokedLambda:"ErrorCodes: x-failed y-failed"
+	if flag {
+		return func() error { return &Error{"x-failed"} }()
+	}
+	return (func() error {
+		if flag {
+			return nil
+		}
+		return &Error{"y-failed"}
+	})()
+}
+
+// Errors:
+//
+//    - context-error --
+func ImmediatelyInvokedLambdaCapturingError() *Error { // want ImmediatelyInvokedLambdaCapturingError:"ErrorCodes: context-error"
+	err := &Error{"context-error"}
+	return func() *Error {
+		return err
+	}()
+}
+
+// Errors:
+//
+//    - x-failed --
+func ImmediatelyInvokedLambdaWithNamedResult() error { // want ImmediatelyInvokedLambdaWithNamedResult:"ErrorCodes: x-failed"
+	return func() (err error) {
+		err = &Error{"x-failed"}
+		return
+	}()
+}
+
+// Errors:
+//
+//    - x-failed --
+func ImmediatelyInvokedLambdaDestructured() error { // want ImmediatelyInvokedLambdaDestructured:"ErrorCodes: x-failed"
+	_, err := func() (int, error) { return 0, &Error{"x-failed"} }()
+	return err
+}
+
+// Errors:
+//
+//    - x-failed --
+func NestedImmediatelyInvokedLambdas() error { // want NestedImmediatelyInvokedLambdas:"ErrorCodes: x-failed"
+	return func() error {
+		return func() error { return &Error{"x-failed"} }()
+	}()
+}
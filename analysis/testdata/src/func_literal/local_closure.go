@@ -0,0 +1,24 @@
+package funcliteral
+
+// Errors:
+//
+//    - x-failed --
+func LocalClosure() error { // want LocalClosure:"ErrorCodes: x-failed"
+	f := func() error { return &Error{"x-failed"} }
+	return f()
+}
+
+// Errors:
+//
+//    - x-failed --
+//    - y-failed --
+func LocalClosureCalledTwice(flag bool) error { // want LocalClosureCalledTwice:"ErrorCodes: x-failed y-failed"
+	f := func() error { return &Error{"x-failed"} }
+	if flag {
+		return f()
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	return &Error{"y-failed"}
+}
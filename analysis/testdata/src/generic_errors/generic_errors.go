@@ -0,0 +1,78 @@
+package genericerrors
+
+import "generics/inner"
+
+// PayloadError carries the value which caused the error.
+type PayloadError[T any] struct { // want PayloadError:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+	Payload T
+}
+
+func (e *PayloadError[T]) Code() string               { return e.TheCode }
+func (e *PayloadError[T]) Message() string            { return e.TheCode }
+func (e *PayloadError[T]) Details() map[string]string { return nil }
+func (e *PayloadError[T]) Cause() error               { return nil }
+func (e *PayloadError[T]) Error() string              { return e.Message() }
+
+// Errors:
+//
+//    - invalid-payload --
+func Validate(payload int) error { // want Validate:"ErrorCodes: invalid-payload"
+	return &PayloadError[int]{"invalid-payload", payload}
+}
+
+// Errors:
+//
+//    - invalid-payload --
+//    - empty-payload   --
+func ValidateKeyed(payload string) error { // want ValidateKeyed:"ErrorCodes: empty-payload invalid-payload"
+	if payload == "" {
+		return &PayloadError[string]{TheCode: "empty-payload"}
+	}
+	err := &PayloadError[string]{Payload: payload}
+	err.TheCode = "invalid-payload"
+	return err
+}
+
+// Errors:
+//
+//    - param: code --
+func NewPayloadError[T any](code string, payload T) error { // want NewPayloadError:"ErrorConstructor: {CodeParamPosition:0}" NewPayloadError:"ErrorCodes:"
+	return &PayloadError[T]{code, payload}
+}
+
+// Errors:
+//
+//    - payload-too-large --
+func ValidateSize(payload []byte) error { // want ValidateSize:"ErrorCodes: payload-too-large"
+	return NewPayloadError("payload-too-large", payload)
+}
+
+// NotFoundError has a constant error code.
+type NotFoundError[K comparable] struct { // want NotFoundError:`ErrorType{Field:<nil>, Codes:not-found}`
+	Key K
+}
+
+func (e NotFoundError[K]) Code() string  { return "not-found" }
+func (e NotFoundError[K]) Error() string { return "not found" }
+
+// Errors:
+//
+//    - not-found --
+func Find(key string) error { // want Find:"ErrorCodes: not-found"
+	return NotFoundError[string]{key}
+}
+
+// Errors:
+//
+//    - not-found --
+func FindPointer(key int) error { // want FindPointer:"ErrorCodes: not-found"
+	return &NotFoundError[int]{Key: key}
+}
+
+// Errors:
+//
+//    - parse-failed --
+func Parse(input string) error { // want Parse:"ErrorCodes: parse-failed"
+	return &inner.ParseError[string]{TheCode: "parse-failed", Input: input}
+}
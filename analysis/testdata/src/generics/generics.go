@@ -0,0 +1,109 @@
+package generics
+
+import "generics/inner"
+
+// Errors:
+//
+//    - not-found --
+func Get[T any](key string) (T, error) { // want Get:"ErrorCodes: not-found"
+	var zero T
+	return zero, &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - invalid-value --
+func Parse[T any](value T) (T, error) { // want Parse:"ErrorCodes: invalid-value"
+	return value, &Error{"invalid-value"}
+}
+
+// Errors:
+//
+//    - not-found     --
+//    - invalid-value --
+func Pair[K comparable, V any](key K, value V) (V, error) { // want Pair:"ErrorCodes: invalid-value not-found"
+	if _, err := Get[K]("key"); err != nil {
+		return value, err
+	}
+	return Parse(value)
+}
+
+// Errors:
+//
+//    - not-found --
+func CallExplicit() error { // want CallExplicit:"ErrorCodes: not-found"
+	_, err := Get[int]("key")
+	return err
+}
+
+// Errors:
+//
+//    - invalid-value --
+func CallInferred() error { // want CallInferred:"ErrorCodes: invalid-value"
+	_, err := Parse(42)
+	return err
+}
+
+// Errors:
+//
+//    - not-found     --
+//    - invalid-value --
+func CallMultipleTypeParams() error { // want CallMultipleTypeParams:"ErrorCodes: invalid-value not-found"
+	_, err := Pair[string, int]("key", 42)
+	return err
+}
+
+// Errors:
+//
+//    - not-found --
+func CallFuncValue() error { // want CallFuncValue:"ErrorCodes: not-found"
+	get := Get[string]
+	_, err := get("key")
+	return err
+}
+
+// Errors:
+//
+//    - decode-failed  --
+//    - convert-failed --
+func CallOtherPackage(data []byte) error { // want CallOtherPackage:"ErrorCodes: convert-failed decode-failed"
+	value, err := inner.Decode[int](data)
+	if err != nil {
+		return err
+	}
+	_, err = inner.Convert[int, string](value)
+	return err
+}
+
+type Cache[T any] struct {
+	values map[string]T
+}
+
+// Errors:
+//
+//    - not-found --
+func (c *Cache[T]) Lookup(key string) (T, error) { // want Lookup:"ErrorCodes: not-found"
+	value, ok := c.values[key]
+	if !ok {
+		return value, &Error{"not-found"}
+	}
+	return value, nil
+}
+
+// Errors:
+//
+//    - not-found --
+func CallGenericMethod(c *Cache[int]) error { // want CallGenericMethod:"ErrorCodes: not-found"
+	_, err := c.Lookup("key")
+	return err
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,36 @@
+package inner
+
+// Errors:
+//
+//    - decode-failed --
+func Decode[T any](data []byte) (T, error) { // want Decode:"ErrorCodes: decode-failed"
+	var zero T
+	return zero, &Error{"decode-failed"}
+}
+
+// Errors:
+//
+//    - convert-failed --
+func Convert[From, To any](value From) (To, error) { // want Convert:"ErrorCodes: convert-failed"
+	var zero To
+	return zero, &Error{"convert-failed"}
+}
+
+// ParseError is a generic error type, whose error code is set by its users.
+type ParseError[T any] struct { // want ParseError:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+	Input   T
+}
+
+func (e *ParseError[T]) Code() string  { return e.TheCode }
+func (e *ParseError[T]) Error() string { return e.TheCode }
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,18 @@
+// Package require is a minimal stand-in for github.com/stretchr/testify/require,
+// containing only the assertions used in the testdata.
+package require
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+func Error(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+func ErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) {}
+
+func ErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interface{}) {}
+
+func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{}) {}
@@ -0,0 +1,89 @@
+package globals
+
+import "sync"
+
+var (
+	once    sync.Once
+	initErr error
+)
+
+// Errors:
+//
+//    - init-failed --
+func Init() error { // want Init:"ErrorCodes: init-failed"
+	once.Do(func() {
+		initErr = &Error{"init-failed"}
+	})
+	return initErr
+}
+
+var (
+	errNotFound = &Error{"not-found"}
+	errTimeout  = &Error{"timeout"}
+	lastError   error
+)
+
+func setLastError(timeout bool) {
+	if timeout {
+		lastError = errTimeout
+	} else {
+		lastError = errNotFound
+	}
+}
+
+// Errors:
+//
+//    - not-found --
+//    - timeout   --
+func LastError() error { // want LastError:"ErrorCodes: not-found timeout"
+	return lastError
+}
+
+// Errors:
+//
+//    - not-found --
+func Lookup() error { // want Lookup:"ErrorCodes: not-found"
+	err := errNotFound
+	return err
+}
+
+var cycleA, cycleB error
+
+func swap() {
+	cycleA = cycleB
+	cycleB = cycleA
+}
+
+// Errors: none
+func Cycle() error { // want Cycle:"ErrorCodes:"
+	return cycleA
+}
+
+var ErrExported = &Error{"exported"}
+
+// Errors: none
+func Exported() error { // want Exported:"ErrorCodes:"
+	return ErrExported // want `returned error may not be exported global variable "ErrExported", as it can be assigned outside of the package`
+}
+
+var leakedError error = &Error{"leaked"}
+
+func leak(err *error) {}
+
+// Errors:
+//
+//    - leaked --
+func Leaked() error { // want Leaked:"ErrorCodes: leaked"
+	leak(&leakedError) // want `unsupported: address of global error variable "leakedError" may not be taken, as assignments through the pointer can not be tracked`
+	return leakedError
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
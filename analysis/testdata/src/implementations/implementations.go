@@ -0,0 +1,79 @@
+package implementations
+
+import (
+	"implementations/inner"
+)
+
+type Store interface {
+	Store(data string) error // want `interface method "Store" does not declare any error codes`
+}
+
+type memory struct{}
+
+func (memory) Store(data string) error { // want `function "Store" is exported, but does not declare any error codes`
+	return &Error{"memory-failed"}
+}
+
+type disk struct{}
+
+// Errors:
+//
+//    - disk-full --
+func (*disk) Store(data string) error { // want Store:"ErrorCodes: disk-full"
+	return &Error{"disk-full"}
+}
+
+var _ inner.Remote
+
+// Errors:
+//
+//    - disk-full --
+//    - memory-failed --
+//    - remote-failed --
+func Save(store Store) error { // want Save:"ErrorCodes: disk-full memory-failed remote-failed"
+	return store.Store("data")
+}
+
+type Loader interface { // want Loader:"ErrorInterface: Load"
+	// Errors:
+	//
+	//    - load-failed --
+	Load() error // want Load:"ErrorCodes: load-failed"
+}
+
+type file struct{}
+
+func (file) Load() error { // want `function "Load" is exported, but does not declare any error codes`
+	return &Error{"file-failed"}
+}
+
+// Errors:
+//
+//    - load-failed --
+func Load(loader Loader) error { // want Load:"ErrorCodes: load-failed"
+	return loader.Load()
+}
+
+type Closer interface {
+	Close() error // want `interface method "Close" does not declare any error codes`
+}
+
+// Errors:
+//
+//    - close-failed --
+func Close(closer Closer) error { // want Close:"ErrorCodes: close-failed"
+	if err := closer.Close(); err != nil { // want "called function does not declare error codes"
+		return err
+	}
+	return &Error{"close-failed"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
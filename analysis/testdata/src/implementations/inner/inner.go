@@ -0,0 +1,20 @@
+package inner
+
+type Remote struct{}
+
+// Errors:
+//
+//    - remote-failed --
+func (Remote) Store(data string) error { // want Store:"ErrorCodes: remote-failed"
+	return &Error{"remote-failed"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
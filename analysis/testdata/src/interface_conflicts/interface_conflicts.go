@@ -0,0 +1,75 @@
+package interface_conflicts
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+type Reader interface { // want Reader:"ErrorInterface: Read"
+	// Errors:
+	//
+	//    - conflict-error-not-found --
+	//    - conflict-error-timeout   --
+	Read() error // want Read:"ErrorCodes: conflict-error-not-found conflict-error-timeout"
+}
+
+type CachedReader interface { // want CachedReader:"ErrorInterface: Read"
+	// Errors:
+	//
+	//    - conflict-error-not-found --
+	Read() error // want Read:"ErrorCodes: conflict-error-not-found"
+}
+
+type WildcardReader interface { // want WildcardReader:"ErrorInterface: Read"
+	// Errors:
+	//
+	//    - conflict-error-* --
+	Read() error // want Read:"ErrorCodes: conflict-error-\\*"
+}
+
+// fileReader is used as Reader and CachedReader, but returns a code only declared by Reader.
+type fileReader struct{} // want `type "fileReader" is used as "CachedReader" and "Reader", which declare different error codes for method "Read": the method may only return codes declared by both, but also returns \[conflict-error-timeout]`
+
+// Errors:
+//
+//    - conflict-error-not-found --
+//    - conflict-error-timeout   --
+func (*fileReader) Read() error { // want Read:"ErrorCodes: conflict-error-not-found conflict-error-timeout"
+	if false {
+		return &Error{"conflict-error-not-found"}
+	}
+	return &Error{"conflict-error-timeout"}
+}
+
+// memoryReader is used as Reader and CachedReader and only returns the code declared by both.
+type memoryReader struct{}
+
+// Errors:
+//
+//    - conflict-error-not-found --
+func (memoryReader) Read() error { // want Read:"ErrorCodes: conflict-error-not-found"
+	return &Error{"conflict-error-not-found"}
+}
+
+// netReader is used as Reader and WildcardReader, which both cover its codes.
+type netReader struct{}
+
+// Errors:
+//
+//    - conflict-error-timeout --
+func (*netReader) Read() error { // want Read:"ErrorCodes: conflict-error-timeout"
+	return &Error{"conflict-error-timeout"}
+}
+
+func Use() {
+	var reader Reader = &fileReader{}
+	var cached CachedReader = &fileReader{} // want `cannot use expression as "CachedReader" value: method "Read" declares the following error codes which were not part of the interface: \[conflict-error-timeout]`
+	_, _ = reader, cached
+
+	reader = memoryReader{}
+	cached = &memoryReader{}
+
+	var wildcard WildcardReader = &netReader{}
+	reader = &netReader{}
+	_, _ = reader, wildcard
+}
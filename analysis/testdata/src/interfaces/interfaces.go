@@ -36,7 +36,7 @@ type SomeInterface interface { // want SomeInterface:"ErrorInterface: InterfaceM
 }
 
 type WithInvalidMethods interface {
-	InvalidMethod1() (error, string) // want "error should be returned as the last argument"
+	InvalidMethod1() (error, string) // want `interface method "InvalidMethod1" does not declare any error codes`
 }
 
 type (
@@ -0,0 +1,32 @@
+package consumer
+
+import "iterators"
+
+// Read consumes an iterator of another package, whose declared error codes are used.
+//
+// Errors:
+//
+//    - iterators-error-closed -- if the file is closed
+//    - iterators-error-read   -- if a line can't be read
+func Read() error { // want Read:"ErrorCodes: iterators-error-closed iterators-error-read"
+	for _, err := range iterators.Lines(false) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadAll declares less codes than yielded by the iterator of another package.
+//
+// Errors:
+//
+//    - iterators-error-read -- if a line can't be read
+func ReadAll() error { // want ReadAll:"ErrorCodes: iterators-error-read" `function "ReadAll" has a mismatch of declared and actual error codes: missing codes: \[iterators-error-closed\]`
+	for _, err := range iterators.Lines(false) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package iterators
+
+import "iter"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Lines yields the lines of a file and the errors reading them.
+//
+// Errors:
+//
+//    - iterators-error-closed -- if the file is closed
+//    - iterators-error-read   -- if a line can't be read
+func Lines(closed bool) iter.Seq2[string, error] { // want Lines:"ErrorCodes: iterators-error-closed iterators-error-read"
+	return func(yield func(string, error) bool) {
+		if closed {
+			yield("", &Error{"iterators-error-closed"})
+			return
+		}
+		for i := 0; i < 3; i++ {
+			if i == 2 {
+				yield("", &Error{"iterators-error-read"})
+				return
+			}
+			if !yield("line", nil) {
+				return
+			}
+		}
+	}
+}
+
+// Undeclared yields an error code, which is not declared.
+//
+// Errors:
+//
+//    - iterators-error-read -- if a line can't be read
+func Undeclared() iter.Seq2[string, error] { // want Undeclared:"ErrorCodes: iterators-error-read" `function "Undeclared" has a mismatch of declared and actual error codes: missing codes: \[iterators-error-timeout\]`
+	var seq iter.Seq2[string, error] = func(yield func(string, error) bool) {
+		if !yield("", &Error{"iterators-error-read"}) {
+			return
+		}
+		err := &Error{"iterators-error-timeout"}
+		yield("", err)
+	}
+	return seq
+}
+
+// Errors yields only errors.
+//
+// Errors:
+//
+//    - iterators-error-read -- for every line that can't be read
+func Errors() iter.Seq[error] { // want Errors:"ErrorCodes: iterators-error-read"
+	return iter.Seq[error](func(yield func(error) bool) {
+		yield(&Error{"iterators-error-read"})
+	})
+}
+
+// First returns the error of the first line, consuming an iterator of the package.
+//
+// Errors:
+//
+//    - iterators-error-closed -- if the file is closed
+//    - iterators-error-read   -- if a line can't be read
+func First() (string, error) { // want First:"ErrorCodes: iterators-error-closed iterators-error-read"
+	for line, err := range Lines(false) {
+		if err != nil {
+			return "", err
+		}
+		return line, nil
+	}
+	return "", nil
+}
+
+// FirstError returns the first error of an iterator yielding only errors.
+//
+// Errors:
+//
+//    - iterators-error-read -- if a line can't be read
+func FirstError() error { // want FirstError:"ErrorCodes: iterators-error-read"
+	for err := range Errors() {
+		return err
+	}
+	return nil
+}
+
+// Missing does not declare all codes yielded by the consumed iterator.
+//
+// Errors:
+//
+//    - iterators-error-read -- if a line can't be read
+func Missing() error { // want Missing:"ErrorCodes: iterators-error-read" `function "Missing" has a mismatch of declared and actual error codes: missing codes: \[iterators-error-closed\]`
+	seq := Lines(true)
+	for _, err := range seq {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Forwarded passes the yield function to another function, which is not supported.
+//
+// Errors:
+//
+//    - iterators-error-read -- if a line can't be read
+func Forwarded() iter.Seq2[string, error] { // want Forwarded:"ErrorCodes: iterators-error-read" `function "Forwarded" has a mismatch of declared and actual error codes: unused codes: \[iterators-error-read\]`
+	return func(yield func(string, error) bool) {
+		forward(yield) // want `unsupported: yield function "yield" of an error iterator may only be called with the yielded values`
+	}
+}
+
+func forward(yield func(string, error) bool) {
+	yield("", &Error{"iterators-error-read"})
+}
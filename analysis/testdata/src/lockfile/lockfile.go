@@ -0,0 +1,66 @@
+package lockfile // want `"Removed" is listed in the lockfile, but is no longer exported or does not declare error codes`
+
+// Errors:
+//
+//    - not-found --
+func Unchanged() error { // want Unchanged:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func Changed() error { // want Changed:"ErrorCodes: access-denied not-found" `error codes of "Changed" differ from the lockfile: added codes: \[not-found\] removed codes: \[timeout\]`
+	if true {
+		return &Error{"access-denied"}
+	}
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - conflict --
+func Added() error { // want Added:"ErrorCodes: conflict" `"Added" declares error codes, but is missing in the lockfile`
+	return &Error{"conflict"}
+}
+
+// Errors: none
+func Close() error { // want Close:"ErrorCodes:"
+	return nil
+}
+
+// Errors:
+//
+//    - not-found --
+func unexported() error { // want unexported:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+type Account struct{}
+
+// Errors:
+//
+//    - account-not-found --
+func (a *Account) Balance() (int, error) { // want Balance:"ErrorCodes: account-not-found"
+	return 0, &Error{"account-not-found"}
+}
+
+type account struct{}
+
+// Errors:
+//
+//    - account-not-found --
+func (a account) Balance() (int, error) { // want Balance:"ErrorCodes: account-not-found"
+	return 0, &Error{"account-not-found"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,15 @@
+// Package lockfileupdate is checked against and written to temporary lockfiles by TestLockfileDiagnostics and TestUpdateLockfile,
+// which assert on the diagnostics directly, so it has no want comments.
+package lockfileupdate
+
+type Error struct {
+	code string
+}
+
+func (e *Error) Code() string {
+	return e.code
+}
+
+func (e *Error) Error() string {
+	return e.code
+}
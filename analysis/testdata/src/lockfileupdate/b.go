@@ -0,0 +1,19 @@
+package lockfileupdate
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func Changed() error {
+	if true {
+		return &Error{"access-denied"}
+	}
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - conflict --
+func Added() error {
+	return &Error{"conflict"}
+}
@@ -0,0 +1,137 @@
+package maplookup
+
+var prebuilt = map[string]error{
+	"not-found": &Error{"lookup-not-found"},
+	"timeout":   &Error{"lookup-timeout"},
+}
+
+const keyNotFound = "not-found"
+
+// Errors:
+//
+//    - lookup-not-found --
+func ConstantKey() error { // want ConstantKey:"ErrorCodes: lookup-not-found"
+	return prebuilt[keyNotFound]
+}
+
+// Errors:
+//
+//    - lookup-not-found --
+//    - lookup-timeout   --
+func VariableKey(key string) error { // want VariableKey:"ErrorCodes: lookup-not-found lookup-timeout"
+	return prebuilt[key]
+}
+
+// Errors:
+//
+//    - lookup-not-found --
+func AssignedFromLookup() error { // want AssignedFromLookup:"ErrorCodes: lookup-not-found"
+	err := prebuilt["not-found"]
+	return err
+}
+
+// Errors: none
+func MissingKey() error { // want MissingKey:"ErrorCodes"
+	return prebuilt["unknown"]
+}
+
+// Errors:
+//
+//    - local-first  --
+//    - local-second --
+func LocalMap(key string) error { // want LocalMap:"ErrorCodes: local-first local-second"
+	errs := map[string]*Error{
+		"first": {"local-first"},
+	}
+	errs["second"] = &Error{"local-second"}
+	return errs[key]
+}
+
+// Errors:
+//
+//    - local-second --
+func LocalMapConstantKey() error { // want LocalMapConstantKey:"ErrorCodes: local-second"
+	errs := map[string]*Error{
+		"first": {"local-first"},
+	}
+	errs["second"] = &Error{"local-second"}
+	return errs["second"]
+}
+
+var built = buildErrors() // want "unsupported: map of errors may only be assigned map literals"
+
+func buildErrors() map[string]error { return nil }
+
+// Errors: none
+func UnknownMap() error { // want UnknownMap:"ErrorCodes"
+	return built["anything"]
+}
+
+// Errors: none
+func NotAMap(errs []error) error { // want NotAMap:"ErrorCodes"
+	return errs[0] // want "expression is not supported in error code analysis"
+}
+
+var Exported = map[string]error{
+	"not-found": &Error{"lookup-not-found"},
+}
+
+// Errors: none
+func ExportedMap() error { // want ExportedMap:"ErrorCodes"
+	return Exported["not-found"] // want `returned error may not be looked up in exported map "Exported", as values can be stored in it outside of the package`
+}
+
+// Errors:
+//
+//    - local-first --
+func Alias() error { // want Alias:"ErrorCodes: local-first"
+	errs := map[string]error{
+		"first": &Error{"local-first"},
+	}
+	alias := errs // want `unsupported: map of errors "errs" may only be used in index expressions and assignments, as values stored through other references can not be tracked`
+	alias["first"] = &Error{"local-second"}
+	return errs["first"]
+}
+
+// Errors:
+//
+//    - local-first --
+func PassedToHelper() error { // want PassedToHelper:"ErrorCodes: local-first"
+	errs := map[string]error{
+		"first": &Error{"local-first"},
+	}
+	fill(errs) // want `unsupported: map of errors "errs" may only be used in index expressions and assignments, as values stored through other references can not be tracked`
+	return errs["first"]
+}
+
+func fill(errs map[string]error) {
+	errs["first"] = &Error{"local-second"}
+}
+
+// Errors: none
+func Parameter(errs map[string]error) error { // want Parameter:"ErrorCodes"
+	return errs["first"] // want `map of errors "errs" may not be a parameter, receiver or result`
+}
+
+// Errors:
+//
+//    - lookup-not-found --
+//    - lookup-timeout   --
+func SupportedUses() error { // want SupportedUses:"ErrorCodes: lookup-not-found lookup-timeout"
+	for key := range prebuilt {
+		if len(prebuilt) > 1 {
+			return prebuilt[key]
+		}
+	}
+	return nil
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
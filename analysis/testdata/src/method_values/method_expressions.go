@@ -0,0 +1,100 @@
+package methodvalues
+
+// Errors:
+//
+//    - work-failed --
+func MethodExpression() error { // want MethodExpression:"ErrorCodes: work-failed"
+	var w Worker
+	return Worker.Do(w)
+}
+
+// Errors:
+//
+//    - work-stopped --
+func PointerMethodExpression() error { // want PointerMethodExpression:"ErrorCodes: work-stopped"
+	var w Worker
+	return (*Worker).Stop(&w)
+}
+
+// Errors:
+//
+//    - undocumented-failed --
+func UndocumentedMethodExpression() error { // want UndocumentedMethodExpression:"ErrorCodes: undocumented-failed"
+	return (Worker).undocumented(Worker{})
+}
+
+// Errors:
+//
+//    - work-failed --
+func PromotedMethodExpression() error { // want PromotedMethodExpression:"ErrorCodes: work-failed"
+	return Wrapper.Do(Wrapper{})
+}
+
+// Errors:
+//
+//    - work-failed --
+func AssignedMethodExpression() error { // want AssignedMethodExpression:"ErrorCodes: work-failed"
+	do := Worker.Do
+	return do(Worker{})
+}
+
+// Errors:
+//
+//    - doer-failed --
+func InterfaceMethodExpression(d Doer) error { // want InterfaceMethodExpression:"ErrorCodes: doer-failed"
+	return Doer.Do(d)
+}
+
+type unexportedWorker struct{}
+
+func (unexportedWorker) work() error {
+	return &Error{"unexported-failed"}
+}
+
+// Errors:
+//
+//    - unexported-failed --
+func ParenthesizedMethodExpression() error { // want ParenthesizedMethodExpression:"ErrorCodes: unexported-failed"
+	return ((unexportedWorker).work)(unexportedWorker{})
+}
+
+type factory struct{}
+
+// Errors:
+//
+//    - param: code --
+func (factory) New(code string) error { // want New:"ErrorConstructor: {CodeParamPosition:0}" New:"ErrorCodes: "
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - created-error --
+func MethodExpressionConstructor() error { // want MethodExpressionConstructor:"ErrorCodes: created-error"
+	return factory.New(factory{}, "created-error")
+}
+
+// Errors:
+//
+//    - work-failed --
+func (Worker) Run(task Task) error { // want Run:"ErrorCodes: work-failed"
+	return task()
+}
+
+func MethodExpressionCallbacks(w Worker) {
+	_ = Worker.Run(w, w.Do)
+	_ = Worker.Run(w, w.Stop) // want `cannot use function as "Task": it returns the following error codes which are not part of the contract: \[work-stopped\]`
+}
+
+func (w Worker) Visit(
+	// Errors:
+	//
+	//    - work-failed --
+	visitor func() error,
+) {
+}
+
+func MethodExpressionParamContract(w Worker) {
+	Worker.Visit(w, w.Do)
+	Worker.Visit(w, w.Stop) // want `cannot use function as "visitor": it returns the following error codes which are not part of the contract: \[work-stopped\]`
+}
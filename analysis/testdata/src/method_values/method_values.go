@@ -0,0 +1,187 @@
+package methodvalues
+
+type Worker struct{}
+
+// Errors:
+//
+//    - work-failed --
+func (Worker) Do() error { // want Do:"ErrorCodes: work-failed"
+	return &Error{"work-failed"}
+}
+
+// Errors:
+//
+//    - work-stopped --
+func (*Worker) Stop() error { // want Stop:"ErrorCodes: work-stopped"
+	return &Error{"work-stopped"}
+}
+
+func (Worker) undocumented() error {
+	return &Error{"undocumented-failed"}
+}
+
+type Wrapper struct {
+	Worker
+}
+
+// Errors:
+//
+//    - work-failed --
+func MethodValue() error { // want MethodValue:"ErrorCodes: work-failed"
+	var w Worker
+	do := w.Do
+	return do()
+}
+
+// Errors:
+//
+//    - work-stopped --
+func PointerMethodValue() error { // want PointerMethodValue:"ErrorCodes: work-stopped"
+	var w Worker
+	stop := w.Stop
+	return stop()
+}
+
+// Errors:
+//
+//    - undocumented-failed --
+func UndocumentedMethodValue() error { // want UndocumentedMethodValue:"ErrorCodes: undocumented-failed"
+	var w Worker
+	f := w.undocumented
+	return f()
+}
+
+// Errors:
+//
+//    - work-failed --
+func PromotedMethodValue() error { // want PromotedMethodValue:"ErrorCodes: work-failed"
+	var w Wrapper
+	do := w.Do
+	return do()
+}
+
+// Errors:
+//
+//    - work-failed --
+//    - work-stopped --
+func ReassignedMethodValue(flag bool) error { // want ReassignedMethodValue:"ErrorCodes: work-failed work-stopped"
+	var w Worker
+	f := w.Do
+	if flag {
+		f = w.Stop
+	}
+	return f()
+}
+
+// Errors:
+//
+//    - work-failed --
+func ParenthesizedMethodValue() error { // want ParenthesizedMethodValue:"ErrorCodes: work-failed"
+	do := (Worker{}).Do
+	return (do)()
+}
+
+// Errors:
+//
+//    - work-failed --
+func MethodValueOfCallResult() error { // want MethodValueOfCallResult:"ErrorCodes: work-failed"
+	do := newWorker().Do
+	return do()
+}
+
+func newWorker() Worker {
+	return Worker{}
+}
+
+// Errors:
+//
+//    - work-failed --
+func LocalClosureCallingMethodValue() error { // want LocalClosureCallingMethodValue:"ErrorCodes: work-failed"
+	var w Worker
+	run := func() error {
+		do := w.Do
+		return do()
+	}
+	return run()
+}
+
+type Doer interface { // want Doer:"ErrorInterface: Do"
+	// Errors:
+	//
+	//    - doer-failed --
+	Do() error // want Do:"ErrorCodes: doer-failed"
+}
+
+// Errors:
+//
+//    - doer-failed --
+func InterfaceMethodValue(d Doer) error { // want InterfaceMethodValue:"ErrorCodes: doer-failed"
+	do := d.Do
+	return do()
+}
+
+// Errors:
+//
+//    - work-failed --
+func DeclaredMethodValue() error { // want DeclaredMethodValue:"ErrorCodes: work-failed"
+	var w Worker
+	var do func() error = w.Do
+	return do()
+}
+
+type service struct {
+	worker Worker
+}
+
+// Errors:
+//
+//    - work-failed --
+func (s *service) Run() error { // want Run:"ErrorCodes: work-failed"
+	do := s.worker.Do
+	return do()
+}
+
+// Task is a function that does some work.
+//
+// Errors:
+//
+//    - work-failed --
+type Task func() error // want Task:"ErrorCodes: work-failed"
+
+// Errors:
+//
+//    - work-failed --
+func run(task Task) error { // want run:"ErrorCodes: work-failed"
+	return task()
+}
+
+func MethodValueCallbacks(w Worker) {
+	_ = run(w.Do)
+	_ = run(w.Stop) // want `cannot use function as "Task": it returns the following error codes which are not part of the contract: \[work-stopped\]`
+
+	do := w.Do
+	_ = run(do)
+
+	stop := w.Stop
+	_ = run(stop) // want `cannot use function as "Task": it returns the following error codes which are not part of the contract: \[work-stopped\]`
+
+	callback := w.Do
+	if stop != nil {
+		callback = w.Stop
+	}
+	_ = run(callback) // want `cannot use function as "Task": it returns the following error codes which are not part of the contract: \[work-stopped\]`
+}
+
+func ForwardCallback(do func() error) {
+	_ = run(do) // want `unsupported: function passed as "Task" has to be a function name, method value, function literal, local variable or a function value with a contract`
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,65 @@
+package methods
+
+type (
+	// E embeds D, so the methods of A, B and C are promoted through two levels of embedding.
+	E struct {
+		*D
+	}
+
+	// F embeds an interface, so its methods are promoted from the interface.
+	F struct {
+		Loader
+	}
+
+	Loader interface { // want Loader:"ErrorInterface: Load"
+		// Errors:
+		//
+		//    - load-error --
+		Load() error // want Load:"ErrorCodes: load-error"
+	}
+)
+
+// Errors:
+//
+//    - a-error --
+//    - b-error --
+func (e E) NestedPromotedCall() error { // want NestedPromotedCall:"ErrorCodes: a-error b-error"
+	if err := e.methodA(); err != nil {
+		return err
+	}
+	return e.valueMethodB2()
+}
+
+func (b B) valueMethodB2() error {
+	return b.methodB()
+}
+
+// Errors:
+//
+//    - c-value-error --
+func NestedPromotedValueCall(e *E) error { // want NestedPromotedValueCall:"ErrorCodes: c-value-error"
+	return e.D.C.valueMethodC()
+}
+
+// Errors:
+//
+//    - a-error --
+func PromotedMethodValue(e E) error { // want PromotedMethodValue:"ErrorCodes: a-error"
+	method := e.methodA
+	return method()
+}
+
+// Errors:
+//
+//    - load-error --
+func PromotedInterfaceMethod(f F) error { // want PromotedInterfaceMethod:"ErrorCodes: load-error"
+	return f.Load()
+}
+
+// Errors:
+//
+//    - c-error --
+func PromotedFromAnonymousStruct() error { // want PromotedFromAnonymousStruct:"ErrorCodes: c-error"
+	s := struct{ *C }{&C{}}
+	return s.methodC()
+}
@@ -14,9 +14,10 @@ func Func1() error { // want Func1:"ErrorCodes: func1-error func2-error"
 // Errors:
 //
 //    - local-error --
-func GlobalFromOtherFile() error { // want GlobalFromOtherFile:"ErrorCodes: local-error"
+//    - global-error --
+func GlobalFromOtherFile() error { // want GlobalFromOtherFile:"ErrorCodes: global-error local-error"
 	if true {
-		return globalError // want "returned error may not be a parameter, receiver or global variable"
+		return globalError
 	}
 	return &Error{"local-error"}
 }
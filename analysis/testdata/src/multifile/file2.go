@@ -2,6 +2,10 @@ package multifile
 
 var globalError error
 
+func init() {
+	globalError = &Error{"global-error"}
+}
+
 // Errors:
 //
 //    - func2-error --
@@ -0,0 +1,55 @@
+package multipackage
+
+import (
+	in1 "multipackage/inner1"
+	"multipackage/inner2"
+)
+
+// Errors:
+//
+//    - hello-error --
+func CallAliasedPackage() error { // want CallAliasedPackage:"ErrorCodes: hello-error"
+	return in1.ExportedFunc1()
+}
+
+// Errors:
+//
+//    - x-error --
+func CallAliasedPackageUndeclared() error { // want CallAliasedPackageUndeclared:"ErrorCodes: x-error"
+	if true {
+		return in1.CodeNotDeclared() // Reported at the first call, in CallToUndeclared1.
+	}
+	return &Error{"x-error"}
+}
+
+type client struct{}
+
+// Errors:
+//
+//    - client-error --
+func (client) CodeNotDeclared() error { // want CodeNotDeclared:"ErrorCodes: client-error"
+	return &Error{"client-error"}
+}
+
+// Errors:
+//
+//    - client-error --
+//    - hello-error  --
+func CallShadowedPackage() error { // want CallShadowedPackage:"ErrorCodes: client-error hello-error"
+	if err := inner2.ExportedFunc2(); err != nil {
+		return err
+	}
+	inner2 := client{}
+	return inner2.CodeNotDeclared()
+}
+
+// Errors:
+//
+//    - x-error --
+func CallShadowedPackageUndeclared() error { // want CallShadowedPackageUndeclared:"ErrorCodes: x-error"
+	if true {
+		inner2 := inner2.SomeType{}
+		return inner2.CodeNotDeclared() // Reported at the first call, in CallToUndeclared4.
+	}
+	return &Error{"x-error"}
+}
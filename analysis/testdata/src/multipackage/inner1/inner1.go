@@ -19,6 +19,36 @@ func (SomeType) CodeNotDeclared() error { // want `function "CodeNotDeclared" is
 	return &Error{"some-error"}
 }
 
+type Store interface { // want Store:"ErrorInterface: Close Load Save"
+	// Errors:
+	//
+	//    - load-failed --
+	Load() error // want Load:"ErrorCodes: load-failed"
+
+	// Errors:
+	//
+	//    - save-failed --
+	//    - disk-full   --
+	Save(data string) error // want Save:"ErrorCodes: disk-full save-failed"
+
+	// Errors: none -- closing never fails, the method only returns error to implement io.Closer.
+	Close() error // want Close:"ErrorCodes: "
+}
+
+type Service struct {
+	Backend interface {
+		// Errors:
+		//
+		//    - fetch-failed --
+		Fetch() error // want Fetch:"ErrorCodes: fetch-failed"
+
+		// Errors: none -- pinging never fails.
+		Ping() error // want Ping:"ErrorCodes: "
+
+		Undeclared() error
+	}
+}
+
 type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
 	TheCode string
 }
@@ -35,3 +65,22 @@ type UnusedError struct { // want UnusedError:`ErrorType{Field:{Name:"TheCode",
 
 func (e *UnusedError) Code() string  { return e.TheCode }
 func (e *UnusedError) Error() string { return e.TheCode }
+
+type Client struct{}
+
+// NewClient creates a new client.
+func NewClient() *Client { return &Client{} }
+
+// Errors:
+//
+//    - client-closed --
+func (c *Client) Send(message string) error { // want Send:"ErrorCodes: client-closed"
+	return &Error{"client-closed"}
+}
+
+// Errors:
+//
+//    - client-timeout --
+func (c Client) Receive() error { // want Receive:"ErrorCodes: client-timeout"
+	return &Error{"client-timeout"}
+}
@@ -0,0 +1,76 @@
+package multipackage
+
+import "multipackage/inner1"
+
+// Errors:
+//
+//    - client-closed --
+func CallMethodOfOtherPackage() error { // want CallMethodOfOtherPackage:"ErrorCodes: client-closed"
+	client := &inner1.Client{}
+	return client.Send("message")
+}
+
+// Errors:
+//
+//    - client-timeout --
+func CallValueMethodOfOtherPackageOnPointer() error { // want CallValueMethodOfOtherPackageOnPointer:"ErrorCodes: client-timeout"
+	client := &inner1.Client{}
+	return client.Receive()
+}
+
+// Errors:
+//
+//    - client-closed --
+func CallMethodOfOtherPackageChained() error { // want CallMethodOfOtherPackageChained:"ErrorCodes: client-closed"
+	return inner1.NewClient().Send("message")
+}
+
+// Errors:
+//
+//    - client-timeout --
+func CallMethodExpressionOfOtherPackage() error { // want CallMethodExpressionOfOtherPackage:"ErrorCodes: client-timeout"
+	return inner1.Client.Receive(inner1.Client{})
+}
+
+// Errors:
+//
+//    - client-closed --
+func CallMethodValueOfOtherPackage() error { // want CallMethodValueOfOtherPackage:"ErrorCodes: client-closed"
+	send := inner1.NewClient().Send
+	return send("message")
+}
+
+type wrappedClient struct {
+	*inner1.Client
+}
+
+// Errors:
+//
+//    - client-closed  --
+//    - client-timeout --
+func CallPromotedMethodOfOtherPackage() error { // want CallPromotedMethodOfOtherPackage:"ErrorCodes: client-closed client-timeout"
+	client := wrappedClient{inner1.NewClient()}
+	if err := client.Send("message"); err != nil {
+		return err
+	}
+	return client.Receive()
+}
+
+// Errors:
+//
+//    - client-closed --
+func CallPromotedMethodValueOfOtherPackage() error { // want CallPromotedMethodValueOfOtherPackage:"ErrorCodes: client-closed"
+	client := wrappedClient{inner1.NewClient()}
+	send := client.Send
+	return send("message")
+}
+
+// Errors:
+//
+//    - client-timeout --
+func CallMethodOfOtherPackageInFuncLit() error { // want CallMethodOfOtherPackageInFuncLit:"ErrorCodes: client-timeout"
+	client := inner1.Client{}
+	return func() error {
+		return client.Receive()
+	}()
+}
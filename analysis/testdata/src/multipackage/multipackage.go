@@ -23,6 +23,35 @@ func RunPackage2() error { // want RunPackage2:"ErrorCodes: hello-error"
 	return inner2.ExportedFunc2()
 }
 
+// Errors:
+//
+//    - load-failed --
+//    - save-failed --
+//    - disk-full   --
+func CopyStore(from, to inner1.Store) error { // want CopyStore:"ErrorCodes: disk-full load-failed save-failed"
+	if err := from.Load(); err != nil {
+		return err
+	}
+	if err := to.Close(); err != nil {
+		return err
+	}
+	save := to.Save
+	return save("data")
+}
+
+// Errors:
+//
+//    - fetch-failed --
+func Fetch(service inner1.Service) error { // want Fetch:"ErrorCodes: fetch-failed"
+	if err := service.Backend.Ping(); err != nil {
+		return err
+	}
+	if err := service.Backend.Undeclared(); err != nil { // want "called function does not declare error codes"
+		return err
+	}
+	return service.Backend.Fetch()
+}
+
 // Trap1 is a demo function.
 //
 // Errors:
@@ -95,7 +124,7 @@ func Inner2UnusedError() error { // want Inner2UnusedError:"ErrorCodes: inner2-u
 //    - x-error --
 func CallToUndeclared1() error { // want CallToUndeclared1:"ErrorCodes: x-error"
 	if true {
-		return inner1.CodeNotDeclared() // want `function "CodeNotDeclared" in package "inner1" does not declare error codes`
+		return inner1.CodeNotDeclared() // want `function "CodeNotDeclared" in package "inner1" does not declare error codes \(called 2 times\)`
 	}
 	return &Error{"x-error"}
 }
@@ -127,7 +156,7 @@ func CallToUndeclared3() error { // want CallToUndeclared3:"ErrorCodes: x-error"
 func CallToUndeclared4() error { // want CallToUndeclared4:"ErrorCodes: x-error"
 	if true {
 		object := inner2.SomeType{}
-		return object.CodeNotDeclared() // want "called function does not declare error codes"
+		return object.CodeNotDeclared() // want `called function does not declare error codes \(called 2 times\)`
 	}
 	return &Error{"x-error"}
 }
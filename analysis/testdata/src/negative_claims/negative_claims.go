@@ -0,0 +1,99 @@
+package negativeclaims
+
+import "wildcards/storage"
+
+// Errors:
+//
+//    - negative-claims-failed --
+//    - !context-canceled      -- cancellation is retried internally
+func Retry() error { // want Retry:"ErrorCodes: negative-claims-failed"
+	if err := cancelable(); err != nil {
+		return &Error{"negative-claims-failed"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - !context-canceled -- cancellation is retried internally
+func RetryOnly() error { // want RetryOnly:"ErrorCodes:"
+	for cancelable() != nil {
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - context-canceled       --
+//    - negative-claims-failed --
+//    - !context-canceled      --
+func Contradicting() error { // want `function "Contradicting" has odd docstring: error code "context-canceled" is declared as returned and as never returned`
+	return cancelable()
+}
+
+// Errors:
+//
+//    - context-canceled  --
+//    - !context-canceled --
+func ContradictingOnly() error { // want `function "ContradictingOnly" has odd docstring: error code "context-canceled" is declared as returned and as never returned`
+	return cancelable()
+}
+
+// Errors:
+//
+//    - !context-canceled --
+func Leaking() error { // want Leaking:"ErrorCodes:" `function "Leaking" declares to never return "context-canceled", but may return the following error codes: \[context-canceled\]` `function "Leaking" has a mismatch of declared and actual error codes: missing codes: \[context-canceled\]`
+	return cancelable()
+}
+
+// Errors:
+//
+//    - storage-*        --
+//    - !storage-timeout -- timeouts are retried
+func LoadWithoutTimeouts(key string) error { // want LoadWithoutTimeouts:"ErrorCodes: storage-\\*" `function "LoadWithoutTimeouts" declares to never return "storage-timeout", but may return the following error codes: \[storage-timeout\]`
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - storage-*        --
+//    - !storage-timeout -- timeouts are retried
+func WriteWithoutTimeouts(key string) error { // want WriteWithoutTimeouts:"ErrorCodes: storage-\\*" `function "WriteWithoutTimeouts" declares to never return "storage-timeout", but may return the following error codes: \[storage-\*\]`
+	return storage.Write(key)
+}
+
+// Errors:
+//
+//    - negative-claims-failed --
+//    - !storage-*             -- storage errors are handled internally
+func LoadOrFail(key string) error { // want LoadOrFail:"ErrorCodes: negative-claims-failed"
+	if err := storage.Read(key); err != nil {
+		return &Error{"negative-claims-failed"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - storage-not-found --
+//    - !storage-*        --
+func LoadNotFound(key string) error { // want `function "LoadNotFound" has odd docstring: error code "storage-\*" is declared as returned and as never returned`
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - context-canceled --
+func cancelable() error { // want cancelable:"ErrorCodes: context-canceled"
+	return &Error{"context-canceled"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
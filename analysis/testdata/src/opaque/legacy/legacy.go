@@ -0,0 +1,27 @@
+package legacy
+
+// Fetch does not declare error codes yet.
+func Fetch() error {
+	return &Error{"fetch-failed"}
+}
+
+// Errors:
+//
+//    - store-failed --
+func Store() error {
+	return &Error{"store-failed"}
+}
+
+type Client struct{}
+
+// Close does not declare error codes yet.
+func (Client) Close() error {
+	return nil
+}
+
+type Error struct {
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
@@ -0,0 +1,6 @@
+package sub
+
+// Fetch does not declare error codes yet.
+func Fetch() error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package opaque
+
+import (
+	"opaque/legacy"
+	"opaque/legacy/sub"
+	"opaque/other"
+)
+
+// Errors: none
+func CallOpaqueFunction() error { // want CallOpaqueFunction:"ErrorCodes:"
+	return legacy.Fetch()
+}
+
+// Errors: none
+func CallOpaqueMethod() error { // want CallOpaqueMethod:"ErrorCodes:"
+	return legacy.Client{}.Close()
+}
+
+// Errors: none
+func CallOpaqueSubPackage() error { // want CallOpaqueSubPackage:"ErrorCodes:"
+	return sub.Fetch()
+}
+
+// Declared error codes of opaque packages are still used.
+//
+// Errors:
+//
+//    - store-failed --
+func CallOpaqueDeclared() error { // want CallOpaqueDeclared:"ErrorCodes: store-failed"
+	return legacy.Store()
+}
+
+// Errors: none
+func CallOtherPackage() error { // want CallOtherPackage:"ErrorCodes:"
+	return other.Fetch() // want `function "Fetch" in package "other" does not declare error codes`
+}
@@ -0,0 +1,6 @@
+package other
+
+// Fetch does not declare error codes.
+func Fetch() error {
+	return nil
+}
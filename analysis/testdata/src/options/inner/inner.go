@@ -0,0 +1,24 @@
+package inner
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+	Message string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.Message }
+
+type Option func(*Error)
+
+func WithCode(code string) Option { // want WithCode:"ErrorCodeOption: {CodeParamPosition:0}"
+	return func(e *Error) { e.TheCode = code }
+}
+
+// Errors: none
+func New(opts ...Option) error { // want New:"ErrorCodes:"
+	e := &Error{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
@@ -0,0 +1,110 @@
+package options
+
+import "options/inner"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+	Message string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.Message }
+
+type Option func(*Error)
+
+func WithMessage(message string) Option {
+	return func(e *Error) { e.Message = message }
+}
+
+func WithCode(code string) Option { // want WithCode:"ErrorCodeOption: {CodeParamPosition:0}"
+	return func(e *Error) { e.TheCode = code }
+}
+
+func WithCodeAndMessage(message, code string) Option { // want WithCodeAndMessage:"ErrorCodeOption: {CodeParamPosition:1}"
+	return func(e *Error) {
+		e.Message = message
+		(*e).TheCode = code
+	}
+}
+
+// WithCopy is not an option setting the code, as it sets the field of a copy.
+func WithCopy(code string) func(Error) {
+	return func(e Error) { e.TheCode = code }
+}
+
+// Errors:
+//
+//    - param: code --
+func New(code string, opts ...Option) error { // want New:"ErrorConstructor: {CodeParamPosition:0}" New:"ErrorCodes:"
+	e := &Error{TheCode: code}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Errors: none
+func NewOpts(opts ...Option) error { // want NewOpts:"ErrorCodes:"
+	e := &Error{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Errors:
+//
+//    - options-error-timeout --
+func CallConstructor() error { // want CallConstructor:"ErrorCodes: options-error-timeout"
+	return New("options-error-timeout", WithMessage("took too long"))
+}
+
+// Errors:
+//
+//    - options-error-full --
+func CallOptions() error { // want CallOptions:"ErrorCodes: options-error-full"
+	return NewOpts(WithMessage("full"), WithCode("options-error-full"))
+}
+
+// Errors:
+//
+//    - options-error-full    --
+//    - options-error-timeout --
+func CallBoth() error { // want CallBoth:"ErrorCodes: options-error-full options-error-timeout"
+	return New("options-error-timeout", WithCodeAndMessage("full", "options-error-full"))
+}
+
+// Errors:
+//
+//    - options-error-full --
+func MissingOptionCode() error { // want MissingOptionCode:"ErrorCodes: options-error-full" `function "MissingOptionCode" has a mismatch of declared and actual error codes: missing codes: \[options-error-timeout]`
+	return NewOpts(WithCode("options-error-timeout"))
+}
+
+// Errors:
+//
+//    - param: code --
+func NewWithCode(code string) error { // want NewWithCode:"ErrorConstructor: {CodeParamPosition:0}" NewWithCode:"ErrorCodes:"
+	return NewOpts(WithCode(code))
+}
+
+// Errors:
+//
+//    - options-error-full --
+func CallForwardingConstructor() error { // want CallForwardingConstructor:"ErrorCodes: options-error-full"
+	return NewWithCode("options-error-full")
+}
+
+// Errors:
+//
+//    - options-error-full --
+func NonConstantOptionCode(codes []string) error { // want NonConstantOptionCode:"ErrorCodes: options-error-full" `function "NonConstantOptionCode" has a mismatch of declared and actual error codes: unused codes: \[options-error-full]`
+	return NewOpts(WithCode(codes[0])) // want `error code has to be constant value or error code parameter`
+}
+
+// Errors:
+//
+//    - inner-error-full --
+func CallOtherPackage() error { // want CallOtherPackage:"ErrorCodes: inner-error-full"
+	return inner.New(inner.WithCode("inner-error-full"))
+}
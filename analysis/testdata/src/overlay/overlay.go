@@ -0,0 +1,22 @@
+package overlay
+
+// Error is an error with an error code.
+type Error struct {
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Load is analysed with the content of an overlay in TestAnalyzeOverlay,
+// which adds an error code that is not declared.
+//
+// Errors:
+//
+//    - overlay-error-not-found -- if nothing was found
+func Load(found bool) error {
+	if !found {
+		return &Error{"overlay-error-not-found"}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package owners
+
+// Errors:
+//
+//    - payments-declined --
+func Pay() error { // want Pay:"ErrorCodes: payments-declined" `function "Pay" has a mismatch of declared and actual error codes: missing codes: \[payments-failed\] unused codes: \[payments-declined\] \(owners: @oncall @owners-team @payments-team\)`
+	return &Error{"payments-failed"}
+}
+
+// Errors: none
+func Other() error { // want Other:"ErrorCodes:" `function "Other" has a mismatch of declared and actual error codes: missing codes: \[other-error\] \(owners: @owners-team\)`
+	return &Error{"other-error"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,24 @@
+package inner
+
+// OrTimeout returns the given error, or a timeout error if it is nil.
+//
+// Errors:
+//
+//    - param-passthrough: err --
+//    - timeout                --
+func OrTimeout(err error) error { // want OrTimeout:"ErrorPassthrough: {ParamPositions:\\[0\\]}" OrTimeout:"ErrorCodes: timeout"
+	if err != nil {
+		return err
+	}
+	return &Error{"timeout"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,123 @@
+package passthrough
+
+import "passthrough/inner"
+
+// annotate returns the given error, after logging the given message.
+//
+// Errors:
+//
+//    - param-passthrough: err --
+func annotate(message string, err error) error { // want annotate:"ErrorPassthrough: {ParamPositions:\\[1\\]}" annotate:"ErrorCodes:"
+	println(message)
+	return err
+}
+
+// Errors:
+//
+//    - param-passthrough: first  --
+//    - param-passthrough: second --
+//    - no-errors                 --
+func either(first, second error) error { // want either:"ErrorPassthrough: {ParamPositions:\\[0 1\\]}" either:"ErrorCodes: no-errors"
+	if first != nil {
+		return first
+	}
+	if second != nil {
+		return second
+	}
+	return &Error{"no-errors"}
+}
+
+// Errors:
+//
+//    - not-found --
+func fetch() error { // want fetch:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - not-found --
+func Annotate() error { // want Annotate:"ErrorCodes: not-found"
+	return annotate("fetching", fetch())
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - no-errors     --
+//    - not-found     --
+func Either() error { // want Either:"ErrorCodes: access-denied no-errors not-found"
+	err := either(fetch(), &Error{"access-denied"})
+	return err
+}
+
+// Errors:
+//
+//    - not-found --
+//    - timeout   --
+func OrTimeout() error { // want OrTimeout:"ErrorCodes: not-found timeout"
+	return inner.OrTimeout(fetch())
+}
+
+type retrier struct{}
+
+// Errors:
+//
+//    - param-passthrough: err --
+func (r *retrier) Last(attempts int, err error) error { // want Last:"ErrorPassthrough: {ParamPositions:\\[1\\]}" Last:"ErrorCodes:"
+	return err
+}
+
+// Errors:
+//
+//    - access-denied --
+//    - not-found     --
+func Retry(r *retrier) error { // want Retry:"ErrorCodes: access-denied not-found"
+	if r != nil {
+		return r.Last(3, fetch())
+	}
+	return (*retrier).Last(r, 1, &Error{"access-denied"})
+}
+
+// Errors: none
+func AnnotateNil() error { // want AnnotateNil:"ErrorCodes:"
+	return annotate("nothing", nil)
+}
+
+// Errors:
+//
+//    - param-passthrough: err --
+func wrongParam(other error, err error) error { // want wrongParam:"ErrorPassthrough: {ParamPositions:\\[1\\]}" wrongParam:"ErrorCodes:"
+	return other // want "returned error may not be a parameter, receiver or global variable"
+}
+
+// Errors:
+//
+//    - param-passthrough: missing --
+func notFound(err error) error { // want "declared pass-through parameter \"missing\" could not be found in parameter list"
+	return nil
+}
+
+// Errors:
+//
+//    - param-passthrough: message --
+func notAnError(message string) error { // want "pass-through parameter \"message\" has to be of an error type"
+	return nil
+}
+
+// Errors:
+//
+//    - param-passthrough: --
+func emptyParam(err error) error { // want "function \"emptyParam\" has odd docstring: a pass-through error parameter can't be purely whitespace"
+	return err
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
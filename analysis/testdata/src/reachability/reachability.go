@@ -0,0 +1,82 @@
+package reachability
+
+import "os"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - reachability-error-invalid --
+func AfterPanic(input string) error { // want AfterPanic:"ErrorCodes: reachability-error-invalid"
+	if input == "" {
+		return &Error{"reachability-error-invalid"}
+	}
+	panic("not implemented")
+	return &Error{"reachability-error-unreachable"}
+}
+
+// Errors:
+//
+//    - reachability-error-invalid --
+func AfterExit(input string) error { // want AfterExit:"ErrorCodes: reachability-error-invalid"
+	if input == "" {
+		return &Error{"reachability-error-invalid"}
+	}
+	os.Exit(1)
+	return &Error{"reachability-error-unreachable"}
+}
+
+// Errors:
+//
+//    - reachability-error-invalid --
+func AfterLoop(inputs []string) error { // want AfterLoop:"ErrorCodes: reachability-error-invalid"
+	for {
+		if len(inputs) == 0 {
+			return &Error{"reachability-error-invalid"}
+		}
+		inputs = inputs[1:]
+	}
+	return &Error{"reachability-error-unreachable"}
+}
+
+// Errors:
+//
+//    - reachability-error-invalid --
+func Overwritten(input string) error { // want Overwritten:"ErrorCodes: reachability-error-invalid"
+	err := error(&Error{"reachability-error-overwritten"})
+	err = &Error{"reachability-error-invalid"}
+	return err
+}
+
+// Errors:
+//
+//    - reachability-error-invalid --
+func AssignedAfterReturn(input string) error { // want AssignedAfterReturn:"ErrorCodes: reachability-error-invalid"
+	var err error = &Error{"reachability-error-invalid"}
+	if input == "" {
+		return err
+	}
+	err = &Error{"reachability-error-unused"}
+	_ = input
+	return nil
+}
+
+// Errors:
+//
+//    - reachability-error-first --
+//    - reachability-error-second --
+func Loop(inputs []string) error { // want Loop:"ErrorCodes: reachability-error-first reachability-error-second"
+	var err error = &Error{"reachability-error-first"}
+	for range inputs {
+		if err != nil {
+			return err
+		}
+		err = &Error{"reachability-error-second"}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package recover
+
+import "github.com/serum-errors/go-serum-analyzer/rerr"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - recover-error-invalid  --
+//    - recover-error-internal -- if processing panics
+func Process(input []string) (err error) { // want Process:"ErrorCodes: recover-error-internal recover-error-invalid"
+	defer rerr.Recover(&err, "recover-error-internal")
+
+	if len(input) == 0 {
+		return &Error{"recover-error-invalid"}
+	}
+	_ = input[10]
+	return nil
+}
+
+// Errors:
+//
+//    - recover-error-invalid --
+func MissingRecoveredCode(input []string) (err error) { // want MissingRecoveredCode:"ErrorCodes: recover-error-invalid" `function "MissingRecoveredCode" has a mismatch of declared and actual error codes: missing codes: \[recover-error-internal]`
+	defer rerr.Recover(&err, "recover-error-internal")
+
+	if len(input) == 0 {
+		return &Error{"recover-error-invalid"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - param: code --
+func RecoverWithCode(code string) (err error) { // want RecoverWithCode:"ErrorConstructor: {CodeParamPosition:0}" RecoverWithCode:"ErrorCodes:"
+	defer rerr.Recover(&err, code)
+	return nil
+}
+
+// Errors:
+//
+//    - recover-error-internal --
+func CallRecoverWithCode() error { // want CallRecoverWithCode:"ErrorCodes: recover-error-internal"
+	return RecoverWithCode("recover-error-internal")
+}
+
+// Errors:
+//
+//    - recover-error-invalid --
+func RecoverInLiteral(input []string) error { // want RecoverInLiteral:"ErrorCodes: recover-error-invalid"
+	go func() {
+		var err error
+		defer rerr.Recover(&err, "recover-error-internal") // belongs to the literal, which does not return errors
+		_ = input[10]
+	}()
+	return &Error{"recover-error-invalid"}
+}
+
+// Errors: none
+func RecoverNotResult() error { // want RecoverNotResult:"ErrorCodes:"
+	var err error
+	defer rerr.Recover(&err, "recover-error-internal") // want `unsupported: error recovered by rerr.Recover has to be the address of a named error result, e.g. &err`
+	return err
+}
+
+// Errors: none
+func RecoverInvalidCode() (err error) { // want RecoverInvalidCode:"ErrorCodes:"
+	defer rerr.Recover(&err, "-internal") // want "error code has invalid format: should match .*"
+	return nil
+}
+
+// Errors:
+//
+//    - recover-error-invalid --
+//    - recover-error-panic   -- if processing panics
+func RecoverInDeferredLiteral(input []string) (err error) { // want RecoverInDeferredLiteral:"ErrorCodes: recover-error-invalid recover-error-panic"
+	defer func() {
+		if r := recover(); r != nil {
+			err = &Error{"recover-error-panic"}
+		}
+	}()
+
+	if len(input) == 0 {
+		return &Error{"recover-error-invalid"}
+	}
+	_ = input[10]
+	return nil
+}
+
+// Errors:
+//
+//    - recover-error-invalid --
+func MissingDeferredLiteralCode(input []string) (err error) { // want MissingDeferredLiteralCode:"ErrorCodes: recover-error-invalid" `function "MissingDeferredLiteralCode" has a mismatch of declared and actual error codes: missing codes: \[recover-error-panic]`
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err = &Error{"recover-error-panic"}
+	}()
+
+	if len(input) == 0 {
+		return &Error{"recover-error-invalid"}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - param: code --
+func RecoverInDeferredLiteralWithCode(code string) (err error) { // want RecoverInDeferredLiteralWithCode:"ErrorConstructor: {CodeParamPosition:0}" RecoverInDeferredLiteralWithCode:"ErrorCodes:"
+	defer func() {
+		if recover() != nil {
+			err = &Error{code}
+		}
+	}()
+	return nil
+}
+
+// Errors:
+//
+//    - recover-error-panic --
+func CallRecoverInDeferredLiteralWithCode() error { // want CallRecoverInDeferredLiteralWithCode:"ErrorCodes: recover-error-panic"
+	return RecoverInDeferredLiteralWithCode("recover-error-panic")
+}
+
+// Errors: none
+func RecoverIntoLocalVariable() (err error) { // want RecoverIntoLocalVariable:"ErrorCodes:"
+	defer func() {
+		var recovered error
+		if recover() != nil {
+			recovered = &Error{"recover-error-panic"} // not returned, as it is not assigned to the named result
+		}
+		_ = recovered
+	}()
+	return nil
+}
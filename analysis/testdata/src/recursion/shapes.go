@@ -0,0 +1,77 @@
+package recursion
+
+type Tree struct {
+	Children []*Tree
+	Valid    bool
+}
+
+// Errors:
+//
+//    - tree-invalid-error --
+func (t *Tree) Validate() error { // want Validate:"ErrorCodes: tree-invalid-error"
+	if !t.Valid {
+		return &Error{"tree-invalid-error"}
+	}
+	for _, child := range t.Children {
+		if err := child.validateChild(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Tree) validateChild() error {
+	if len(t.Children) == 0 {
+		return nil
+	}
+	return t.Validate()
+}
+
+// Errors:
+//
+//    - literal-error --
+func RecursionThroughLiteral(depth int) error { // want RecursionThroughLiteral:"ErrorCodes: literal-error"
+	return func() error {
+		if depth == 0 {
+			return &Error{"literal-error"}
+		}
+		return RecursionThroughLiteral(depth - 1)
+	}()
+}
+
+// Errors:
+//
+//    - value-error --
+func RecursionThroughFuncValue(depth int) error { // want RecursionThroughFuncValue:"ErrorCodes: value-error"
+	next := recursionThroughFuncValue
+	return next(depth)
+}
+
+func recursionThroughFuncValue(depth int) error {
+	if depth == 0 {
+		return &Error{"value-error"}
+	}
+	return RecursionThroughFuncValue(depth - 1)
+}
+
+// Errors:
+//
+//    - param: code       --
+//    - constructor-error --
+func RecursiveConstructor(code string, depth int) error { // want RecursiveConstructor:"ErrorConstructor: {CodeParamPosition:0}" RecursiveConstructor:"ErrorCodes: constructor-error"
+	if depth == 0 {
+		return &Error{code}
+	}
+	if depth < 0 {
+		return &Error{"constructor-error"}
+	}
+	return RecursiveConstructor(code, depth-1)
+}
+
+// Errors:
+//
+//    - constructor-error --
+//    - called-error      --
+func CallRecursiveConstructor() error { // want CallRecursiveConstructor:"ErrorCodes: called-error constructor-error"
+	return RecursiveConstructor("called-error", 3)
+}
@@ -0,0 +1,39 @@
+package result
+
+func log(err error) {}
+
+// Errors:
+//
+//    - not-found --
+//    - timeout --
+func Lookup(key string) error {
+	if key == "" {
+		return &Error{"not-found"}
+	}
+	return &Error{"timeout"}
+}
+
+func Caller() {
+	err := Lookup("key")
+	log(err) // want "codes: not-found timeout"
+
+	var other error = &Error{"other-error"}
+	log(other) // want "codes: other-error"
+
+	func() {
+		err := Lookup("")
+		log(err) // want "codes: not-found timeout"
+	}()
+
+	log(nil) // want "codes: "
+}
+
+type Error struct {
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
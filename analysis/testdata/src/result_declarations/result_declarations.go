@@ -0,0 +1,53 @@
+package resultdeclarations
+
+// Lookup finds the value stored for the given key.
+//
+// Errors:
+//
+//    - not-found        -- if the key does not exist.
+//    - storage-*        -- if the storage fails.
+//    - !context-canceled -- never returned, the lookup can't be canceled.
+//    - invalid-key      -- if the key is empty,
+//                          or if it contains whitespace.
+func Lookup(key string) error { // want `Lookup: not-found: if the key does not exist.` `Lookup: invalid-key: if the key is empty, or if it contains whitespace.`
+	if key == "" {
+		return &Error{"invalid-key"}
+	}
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - param: code   --
+//    - unknown-error --
+func NewError(code string) error { // want `NewError: unknown-error: `
+	if code == "" {
+		return &Error{"unknown-error"}
+	}
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - not-found -- details: key, kind; if the key does not exist.
+//    - no-space  -- details: size
+func Store(key, kind string) error { // want `Store: not-found: details \[key kind]: if the key does not exist.` `Store: no-space: details \[size]: `
+	return &Error{"not-found"}
+}
+
+// Errors: none
+func Close() error {
+	return nil
+}
+
+// Undeclared does not declare error codes, so there are no declarations.
+func Undeclared() error {
+	return &Error{"undeclared"}
+}
+
+type Error struct {
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
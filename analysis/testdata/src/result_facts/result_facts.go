@@ -0,0 +1,27 @@
+package resultfacts
+
+// Errors:
+//
+//    - not-found --
+func Lookup(key string) error { // want `Lookup: ErrorCodes: not-found`
+	return &Error{"not-found"}
+}
+
+// Errors:
+//
+//    - param: code --
+func NewError(code string) error { // want `NewError: ErrorConstructor: {CodeParamPosition:0}` `NewError: ErrorCodes:`
+	return &Error{code}
+}
+
+// Undeclared does not declare error codes, so callers can't see its error codes.
+func Undeclared() error {
+	return &Error{"undeclared"}
+}
+
+type Error struct { // want `Error: ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
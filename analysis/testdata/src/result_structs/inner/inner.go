@@ -0,0 +1,24 @@
+package inner
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Response is returned by requests, it carries the error of a failed request.
+type Response struct {
+	Body string
+	Err  error
+}
+
+// Errors:
+//
+//    - inner-error-timeout --
+func Request(url string) Response { // want Request:"ErrorCodes: inner-error-timeout"
+	if url == "" {
+		return Response{Err: &Error{"inner-error-timeout"}}
+	}
+	return Response{Body: url}
+}
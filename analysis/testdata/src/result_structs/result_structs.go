@@ -0,0 +1,85 @@
+package result_structs
+
+import "result_structs/inner"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+type Result struct {
+	Value int
+	Err   error
+}
+
+// Errors:
+//
+//    - result-error-not-found --
+//    - result-error-invalid --
+func Lookup(key string) Result { // want Lookup:"ErrorCodes: result-error-invalid result-error-not-found"
+	switch key {
+	case "":
+		return Result{Err: &Error{"result-error-invalid"}}
+	case "missing":
+		return Result{0, &Error{"result-error-not-found"}}
+	}
+	return Result{Value: len(key)}
+}
+
+// Errors:
+//
+//    - result-error-not-found --
+func LookupPointer(key string) *Result { // want LookupPointer:"ErrorCodes: result-error-not-found"
+	if key == "" {
+		return &Result{Err: &Error{"result-error-not-found"}}
+	}
+	return &Result{Value: len(key)}
+}
+
+// Errors:
+//
+//    - result-error-invalid --
+func LookupMissing(key string) Result { // want LookupMissing:"ErrorCodes: result-error-invalid" `function "LookupMissing" has a mismatch of declared and actual error codes: missing codes: \[result-error-not-found]`
+	if key == "" {
+		return Result{Err: &Error{"result-error-invalid"}}
+	}
+	return Result{Err: &Error{"result-error-not-found"}}
+}
+
+// Errors:
+//
+//    - result-error-invalid --
+//    - result-error-not-found --
+func Forward(key string) Result { // want Forward:"ErrorCodes: result-error-invalid result-error-not-found"
+	result := Lookup(key)
+	return result
+}
+
+// Errors:
+//
+//    - result-error-invalid --
+//    - result-error-not-found --
+func UseField(key string) error { // want UseField:"ErrorCodes: result-error-invalid result-error-not-found"
+	return Lookup(key).Err
+}
+
+// Errors:
+//
+//    - result-error-not-found --
+func UseVariable(key string) error { // want UseVariable:"ErrorCodes: result-error-not-found"
+	result := LookupPointer(key)
+	return result.Err
+}
+
+// Errors:
+//
+//    - inner-error-timeout --
+func UseOtherPackage(url string) error { // want UseOtherPackage:"ErrorCodes: inner-error-timeout"
+	response := inner.Request(url)
+	if response.Err != nil {
+		return response.Err
+	}
+	return nil
+}
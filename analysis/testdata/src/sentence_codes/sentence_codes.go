@@ -0,0 +1,52 @@
+package sentencecodes
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - storage-not-found -- if the key does not exist.
+//    - storage-full      -- if there is no space left.
+func Short(key string) error { // want Short:"ErrorCodes: storage-full storage-not-found"
+	if key == "" {
+		return &Error{"storage-full"}
+	}
+	return &Error{"storage-not-found"}
+}
+
+// Errors:
+//
+//    - storage-the-file-could-not-be-found -- if the key does not exist.
+func Sentence() error { // want Sentence:"ErrorCodes: storage-the-file-could-not-be-found"
+	return &Error{"storage-the-file-could-not-be-found"}
+}
+
+// Errors:
+//
+//    - storage-is-full                          --
+//    - !storage-quota-exceeded-for-user-account -- never returned, there are no quotas.
+func Negative() error { // want Negative:"ErrorCodes: storage-is-full"
+	return &Error{"storage-is-full"}
+}
+
+// Errors:
+//
+//    - param: code                                --
+//    - storage-unknown-error-while-reading-index-file --
+func NewError(code string) error { // want NewError:"ErrorConstructor: {CodeParamPosition:0}" NewError:"ErrorCodes: storage-unknown-error-while-reading-index-file"
+	if code == "" {
+		return &Error{"storage-unknown-error-while-reading-index-file"}
+	}
+	return &Error{code}
+}
+
+// Errors:
+//
+//    - storage-* --
+func Wildcard() error { // want Wildcard:`ErrorCodes: storage-\*`
+	return NewError("storage-full")
+}
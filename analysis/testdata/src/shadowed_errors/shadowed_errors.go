@@ -0,0 +1,93 @@
+package shadowed_errors
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - shadowed-error-foo --
+func foo() error { // want foo:"ErrorCodes: shadowed-error-foo"
+	return &Error{"shadowed-error-foo"}
+}
+
+// Errors:
+//
+//    - shadowed-error-bar --
+func bar() error { // want bar:"ErrorCodes: shadowed-error-bar"
+	return &Error{"shadowed-error-bar"}
+}
+
+// Errors:
+//
+//    - shadowed-error-foo --
+func Shadowed(x bool) error { // want Shadowed:"ErrorCodes: shadowed-error-foo"
+	err := foo()
+	if x {
+		err := bar() // want `error variable "err" shadows the error variable declared in line 26, codes of errors assigned to it do not reach the outer variable`
+		_ = err
+	}
+	return err
+}
+
+// Errors:
+//
+//    - shadowed-error-foo --
+func ShadowedResult(x bool) (err error) { // want ShadowedResult:"ErrorCodes: shadowed-error-foo"
+	err = foo()
+	for i := 0; i < 3 && x; i++ {
+		var err error // want `error variable "err" shadows the error variable declared in line 37, codes of errors assigned to it do not reach the outer variable`
+		err = bar()
+		_ = err
+	}
+	return err
+}
+
+// Errors:
+//
+//    - shadowed-error-bar --
+//    - shadowed-error-foo --
+func ReturnedInner(x bool) error { // want ReturnedInner:"ErrorCodes: shadowed-error-bar shadowed-error-foo"
+	err := foo()
+	if x {
+		if err := bar(); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Errors:
+//
+//    - shadowed-error-foo --
+func DifferentNames(x bool) error { // want DifferentNames:"ErrorCodes: shadowed-error-foo"
+	err := foo()
+	if x {
+		barErr := bar()
+		_ = barErr
+	}
+	return err
+}
+
+// Errors:
+//
+//    - shadowed-error-foo --
+func ShadowedInClosure() error { // want ShadowedInClosure:"ErrorCodes: shadowed-error-foo"
+	err := foo()
+	func() {
+		err := bar() // want `error variable "err" shadows the error variable declared in line 77, codes of errors assigned to it do not reach the outer variable`
+		_ = err
+	}()
+	return err
+}
+
+// notAnalysed does not declare error codes, so shadowed variables are not reported.
+func notAnalysed(x bool) error {
+	err := foo()
+	if x {
+		err := bar()
+		_ = err
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+package skip_generated
+
+// Call uses the fact of the generated function.
+//
+// Errors:
+//
+//    - skip-generated-error-declared -- always
+func Call() error { // want Call:"ErrorCodes: skip-generated-error-declared"
+	return GeneratedDeclared()
+}
+
+// Undeclared is not generated, so it is reported.
+func Undeclared() error { // want `function "Undeclared" is exported, but does not declare any error codes`
+	return nil
+}
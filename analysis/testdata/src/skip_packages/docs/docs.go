@@ -0,0 +1,8 @@
+package docs
+
+// Count does not return errors, but its doc comment has an "Errors" block anyway.
+//
+// Errors: none
+func Count() int {
+	return 0
+}
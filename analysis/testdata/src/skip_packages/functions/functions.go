@@ -0,0 +1,8 @@
+package functions
+
+func check(ok bool) error {
+	if !ok {
+		return nil
+	}
+	return nil
+}
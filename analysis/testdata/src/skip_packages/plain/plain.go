@@ -0,0 +1,18 @@
+package plain // want "package without errors"
+
+// Point is a plain data type, which has nothing to do with errors.
+type Point struct {
+	X, Y int
+}
+
+func (p Point) Add(other Point) Point {
+	return Point{p.X + other.X, p.Y + other.Y}
+}
+
+func Sum(points ...Point) Point {
+	var result Point
+	for _, point := range points {
+		result = result.Add(point)
+	}
+	return result
+}
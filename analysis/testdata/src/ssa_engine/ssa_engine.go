@@ -0,0 +1,118 @@
+package ssaengine
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+type holder struct {
+	name string
+	err  error
+}
+
+// Errors:
+//
+//    - ssa-engine-error-first --
+func FirstHolder() error { // want FirstHolder:"ErrorCodes: ssa-engine-error-first"
+	h := holder{}
+	h.name = "first"
+	h.err = &Error{"ssa-engine-error-first"}
+	return h.err
+}
+
+// Errors:
+//
+//    - ssa-engine-error-second --
+func SecondHolder() error { // want SecondHolder:"ErrorCodes: ssa-engine-error-second"
+	h := holder{}
+	h.name = "second"
+	h.err = &Error{"ssa-engine-error-second"}
+	return h.err
+}
+
+// Errors:
+//
+//    - ssa-engine-error-invalid --
+//    - ssa-engine-error-empty   --
+func Branches(input string) error { // want Branches:"ErrorCodes: ssa-engine-error-empty ssa-engine-error-invalid"
+	var err error
+	if input == "" {
+		err = &Error{"ssa-engine-error-empty"}
+	} else if input[0] == '-' {
+		err = &Error{"ssa-engine-error-invalid"}
+	}
+	return err
+}
+
+// Errors:
+//
+//    - ssa-engine-error-closed --
+//    - ssa-engine-error-failed --
+func Deferred() (err error) { // want Deferred:"ErrorCodes: ssa-engine-error-closed ssa-engine-error-failed"
+	defer func() {
+		if err == nil {
+			err = &Error{"ssa-engine-error-closed"}
+		}
+	}()
+	return &Error{"ssa-engine-error-failed"}
+}
+
+// Errors:
+//
+//    - ssa-engine-error-lambda --
+func Lambda() error { // want Lambda:"ErrorCodes: ssa-engine-error-lambda"
+	prefix := "ssa-engine"
+	f := func() error {
+		if prefix == "" {
+			return nil
+		}
+		return &Error{"ssa-engine-error-lambda"}
+	}
+	return f()
+}
+
+// Errors:
+//
+//    - ssa-engine-error-first  --
+//    - ssa-engine-error-second --
+func Calls(first bool) error { // want Calls:"ErrorCodes: ssa-engine-error-first ssa-engine-error-second"
+	if first {
+		return FirstHolder()
+	}
+	return helper()
+}
+
+func helper() error {
+	return SecondHolder()
+}
+
+// Errors:
+//
+//    - ssa-engine-error-recursive --
+func Recursive(depth int) error { // want Recursive:"ErrorCodes: ssa-engine-error-recursive"
+	if depth == 0 {
+		return &Error{"ssa-engine-error-recursive"}
+	}
+	return Recursive(depth - 1)
+}
+
+// Unsupported functions are analysed by the AST engine, which reports the same diagnostics as without the flag.
+
+// Errors:
+//
+//    - ssa-engine-error-param --
+func FromParameter(err error) error { // want FromParameter:"ErrorCodes: ssa-engine-error-param"
+	if err != nil {
+		return err // want "returned error may not be a parameter, receiver or global variable"
+	}
+	return &Error{"ssa-engine-error-param"}
+}
+
+// Errors:
+//
+//    - ssa-engine-error-unused --
+func Mismatch() error { // want Mismatch:"ErrorCodes: ssa-engine-error-unused" `function "Mismatch" has a mismatch of declared and actual error codes: missing codes: \[ssa-engine-error-first\] unused codes: \[ssa-engine-error-unused\]`
+	return FirstHolder()
+}
@@ -0,0 +1,60 @@
+package structfields
+
+import "struct_fields/inner"
+
+type client struct {
+	// lastErr is the error of the last request, it is set by the caller of do.
+	//
+	// Errors:
+	//
+	//    - timeout     --
+	//    - unavailable --
+	lastErr error // want lastErr:"ErrorCodes: timeout unavailable"
+
+	// Errors: none
+	closedErr error // want closedErr:"ErrorCodes:"
+}
+
+// Errors:
+//
+//    - timeout     --
+//    - unavailable --
+func (c *client) Err() error { // want Err:"ErrorCodes: timeout unavailable"
+	return c.lastErr
+}
+
+func (c *client) store(err error) {
+	c.lastErr = err // want "returned error may not be a parameter, receiver or global variable"
+}
+
+func (c *client) fail(timeout bool) {
+	if timeout {
+		c.lastErr = &Error{"timeout"}
+	} else {
+		c.lastErr = &Error{"not-started"} // want `cannot store error in field "lastErr": it may have the following error codes which are not declared for the field: \[not-started\]`
+	}
+	c.closedErr = nil
+}
+
+func newClient() *client {
+	return &client{lastErr: &Error{"unavailable"}, closedErr: &Error{"closed"}} // want `cannot store error in field "closedErr": it may have the following error codes which are not declared for the field: \[closed\]`
+}
+
+func leakDeclared(c *client) {
+	leak(&c.lastErr) // want `unsupported: address of error field "lastErr" may not be taken, as assignments through the pointer can not be tracked`
+}
+
+// Exported fields from other packages can be read, if they declare error codes.
+//
+// Errors:
+//
+//    - conn-closed  --
+//    - conn-timeout --
+func ConnErr(c *inner.Conn) error { // want ConnErr:"ErrorCodes: conn-closed conn-timeout"
+	err := c.LastErr
+	return err
+}
+
+func resetConn(c *inner.Conn) {
+	c.LastErr = &Error{"reset"} // want `cannot store error in field "LastErr": it may have the following error codes which are not declared for the field: \[reset\]`
+}
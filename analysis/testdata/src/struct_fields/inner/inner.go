@@ -0,0 +1,26 @@
+package inner
+
+// Conn is a connection, which keeps the error of its last operation.
+type Conn struct {
+	// LastErr is the error of the last operation.
+	//
+	// Errors:
+	//
+	//    - conn-closed  --
+	//    - conn-timeout --
+	LastErr error // want LastErr:"ErrorCodes: conn-closed conn-timeout"
+}
+
+func (c *Conn) Close() {
+	c.LastErr = &Error{"conn-closed"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
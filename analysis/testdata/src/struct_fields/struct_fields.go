@@ -0,0 +1,102 @@
+package structfields
+
+import "sync"
+
+type service struct {
+	mu  sync.Mutex
+	err error
+}
+
+var instance = &service{err: &Error{"not-started"}}
+
+func (s *service) fail(timeout bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if timeout {
+		s.err = &Error{"timeout"}
+	} else {
+		s.err = &Error{"unavailable"}
+	}
+}
+
+// Errors:
+//
+//    - not-started --
+//    - timeout     --
+//    - unavailable --
+func (s *service) Err() error { // want Err:"ErrorCodes: not-started timeout unavailable"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Errors:
+//
+//    - not-started --
+//    - timeout     --
+//    - unavailable --
+func InstanceErr() error { // want InstanceErr:"ErrorCodes: not-started timeout unavailable"
+	err := instance.err
+	return err
+}
+
+type positional struct {
+	name string
+	err  *Error
+}
+
+var defaultPositional = positional{"default", &Error{"positional"}}
+
+// Errors:
+//
+//    - positional --
+func (p positional) Err() error { // want Err:"ErrorCodes: positional"
+	return p.err
+}
+
+type Exported struct {
+	Err error
+}
+
+// Errors: none
+func (e *Exported) Error() error { // want Error:"ErrorCodes:"
+	return e.Err // want `returned error may not be exported field "Err", as it can be assigned outside of the package`
+}
+
+type leaked struct {
+	err error
+}
+
+func leak(err *error) {}
+
+// Errors:
+//
+//    - leaked --
+func (l *leaked) Err() error { // want Err:"ErrorCodes: leaked"
+	l.err = &Error{"leaked"}
+	leak(&l.err) // want `unsupported: address of error field "err" may not be taken, as assignments through the pointer can not be tracked`
+	return l.err
+}
+
+type fromParam struct {
+	err error
+}
+
+func (f *fromParam) set(err error) {
+	f.err = err // want "returned error may not be a parameter, receiver or global variable"
+}
+
+// Errors: none
+func (f *fromParam) Err() error { // want Err:"ErrorCodes:"
+	return f.err
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
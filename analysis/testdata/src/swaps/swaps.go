@@ -0,0 +1,117 @@
+package swaps
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - swaps-error-b --
+func Swap() error { // want Swap:"ErrorCodes: swaps-error-b"
+	errA := error(&Error{"swaps-error-a"})
+	errB := error(&Error{"swaps-error-b"})
+	errA, errB = errB, errA
+	return errA
+}
+
+// SwapTwice swaps the errors back, so errB holds its original error again.
+//
+// Errors:
+//
+//    - swaps-error-b --
+func SwapTwice() error { // want SwapTwice:"ErrorCodes: swaps-error-b"
+	errA := error(&Error{"swaps-error-a"})
+	errB := error(&Error{"swaps-error-b"})
+	errA, errB = errB, errA
+	errA, errB = errB, errA
+	return errB
+}
+
+// Errors:
+//
+//    - swaps-error-b --
+func Rotate() error { // want Rotate:"ErrorCodes: swaps-error-b"
+	errA := error(&Error{"swaps-error-a"})
+	errB := error(&Error{"swaps-error-b"})
+	errC := error(&Error{"swaps-error-c"})
+	errA, errB, errC = errB, errC, errA
+	return errA
+}
+
+// SwapInLoop might swap the errors any number of times, so both errors can be returned.
+//
+// Errors:
+//
+//    - swaps-error-a --
+//    - swaps-error-b --
+func SwapInLoop(n int) error { // want SwapInLoop:"ErrorCodes: swaps-error-a swaps-error-b"
+	errA := error(&Error{"swaps-error-a"})
+	errB := error(&Error{"swaps-error-b"})
+	for i := 0; i < n; i++ {
+		errA, errB = errB, errA
+	}
+	return errA
+}
+
+// Errors:
+//
+//    - swaps-error-a --
+func Chain() error { // want Chain:"ErrorCodes: swaps-error-a"
+	var errA, errB, errC error
+	errA = &Error{"swaps-error-a"}
+	errB = errA
+	errC = errB
+	return errC
+}
+
+// ChainOverwritten overwrites errA after it was copied, which does not change errB.
+//
+// Errors:
+//
+//    - swaps-error-a --
+func ChainOverwritten() error { // want ChainOverwritten:"ErrorCodes: swaps-error-a"
+	errA := error(&Error{"swaps-error-a"})
+	errB := errA
+	errA = &Error{"swaps-error-b"}
+	return errB
+}
+
+// ReassignedCopy overwrites the copy of errA before it is returned.
+//
+// Errors:
+//
+//    - swaps-error-b --
+func ReassignedCopy() error { // want ReassignedCopy:"ErrorCodes: swaps-error-b"
+	var errA, errB error
+	errA, errB = &Error{"swaps-error-a"}, &Error{"swaps-error-b"}
+	errC := errA
+	errC = errB
+	return errC
+}
+
+// CopiedAndReturned returns errB before errA is overwritten, and errA afterwards.
+//
+// Errors:
+//
+//    - swaps-error-a --
+//    - swaps-error-c --
+func CopiedAndReturned(x bool) error { // want CopiedAndReturned:"ErrorCodes: swaps-error-a swaps-error-c"
+	errA := error(&Error{"swaps-error-a"})
+	errB := errA
+	if x {
+		return errB
+	}
+	errA = &Error{"swaps-error-c"}
+	return errA
+}
+
+// Errors:
+//
+//    - swaps-error-a --
+func Mismatch() error { // want Mismatch:"ErrorCodes: swaps-error-a" `function "Mismatch" has a mismatch of declared and actual error codes: missing codes: \[swaps-error-b] unused codes: \[swaps-error-a]`
+	errA := error(&Error{"swaps-error-a"})
+	errB := error(&Error{"swaps-error-b"})
+	errA, errB = errB, errA
+	return errA
+}
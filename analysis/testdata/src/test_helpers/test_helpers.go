@@ -0,0 +1,18 @@
+package testhelpers
+
+// Errors:
+//
+//    - not-found --
+func Find(key string) error { // want Find:"ErrorCodes: not-found"
+	return &Error{"not-found"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,32 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Assertion helpers only consume errors, so they neither have to declare error codes,
+// nor do they cause diagnostics in test files.
+func TestFind(t *testing.T) {
+	err := Find("key")
+	require.Error(t, err)
+	require.ErrorIs(t, err, &Error{"not-found"})
+	require.EqualError(t, err, "not-found")
+
+	var target *Error
+	require.ErrorAs(t, err, &target)
+
+	t.Run("subtest", func(t *testing.T) {
+		require.NoError(t, nil)
+	})
+}
+
+// Errors:
+//
+//    - not-found --
+func findTwice(t *testing.T) error { // want findTwice:"ErrorCodes: not-found"
+	err := Find("key")
+	require.ErrorIs(t, err, &Error{"not-found"})
+	return Find("other")
+}
@@ -0,0 +1,5 @@
+package translations
+
+func Undeclared() error { // want `Funktion "Undeclared" ist exportiert, deklariert aber keine Fehlercodes`
+	return nil
+}
@@ -0,0 +1,37 @@
+package legacy
+
+import "fmt"
+
+// Lookup returns errors the analysis can't follow, but its declared error codes are trusted.
+//
+// Errors:
+//
+//    - not-found --
+//    - timeout   --
+func Lookup(key string) error { // want Lookup:"ErrorCodes: not-found timeout"
+	return fmt.Errorf("lookup %q failed", key)
+}
+
+// Store declares fewer error codes than it returns, which is not verified in trusted packages.
+//
+// Errors:
+//
+//    - store-failed --
+func Store() error { // want Store:"ErrorCodes: store-failed"
+	if err := Lookup("store"); err != nil {
+		return err
+	}
+	return &Error{"store-failed"}
+}
+
+// Errors: none
+func Close() error { // want Close:"ErrorCodes:"
+	return &Error{"close-failed"}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
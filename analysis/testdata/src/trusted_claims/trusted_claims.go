@@ -0,0 +1,32 @@
+package trusted_claims
+
+import "trusted_claims/legacy"
+
+// Callers of trusted packages are verified against the declared error codes.
+//
+// Errors:
+//
+//    - not-found --
+//    - timeout   --
+func CallLookup() error { // want CallLookup:"ErrorCodes: not-found timeout"
+	return legacy.Lookup("key")
+}
+
+// Errors:
+//
+//    - store-failed --
+func CallStore() error { // want CallStore:"ErrorCodes: store-failed"
+	return legacy.Store()
+}
+
+// Errors:
+//
+//    - not-found --
+func CallLookupMismatch() error { // want CallLookupMismatch:"ErrorCodes: not-found" `function "CallLookupMismatch" has a mismatch of declared and actual error codes: missing codes: \[timeout]`
+	return legacy.Lookup("key")
+}
+
+// Errors: none
+func CallClose() error { // want CallClose:"ErrorCodes:"
+	return legacy.Close()
+}
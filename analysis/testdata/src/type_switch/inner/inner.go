@@ -0,0 +1,36 @@
+package inner
+
+type TimeoutError struct { // want TimeoutError:`ErrorType{Field:<nil>, Codes:connect-timeout read-timeout}`
+	Connecting bool
+}
+
+func (e *TimeoutError) Code() string {
+	if e.Connecting {
+		return "connect-timeout"
+	}
+	return "read-timeout"
+}
+
+func (e *TimeoutError) Error() string { return "timeout" }
+
+// Errors:
+//
+//    - connect-timeout --
+//    - read-timeout    --
+//    - closed          --
+func Fetch() error { // want Fetch:"ErrorCodes: closed connect-timeout read-timeout"
+	if true {
+		return &Error{"closed"}
+	}
+	return &TimeoutError{}
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
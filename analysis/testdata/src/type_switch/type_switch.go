@@ -0,0 +1,151 @@
+package typeswitch
+
+import "type_switch/inner"
+
+type NotFoundError struct { // want NotFoundError:`ErrorType{Field:<nil>, Codes:not-found}`
+}
+
+func (e *NotFoundError) Code() string  { return "not-found" }
+func (e *NotFoundError) Error() string { return "not found" }
+
+// Errors:
+//
+//    - not-found     --
+//    - access-denied --
+func find(key string) error { // want find:"ErrorCodes: access-denied not-found"
+	if key == "" {
+		return &NotFoundError{}
+	}
+	return &Error{"access-denied"}
+}
+
+// Errors:
+//
+//    - not-found --
+func TypeSwitch(key string) error { // want TypeSwitch:"ErrorCodes: not-found"
+	err := find(key)
+	switch e := err.(type) {
+	case *NotFoundError:
+		return e
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - not-found     --
+//    - access-denied --
+func TypeSwitchCases(key string) error { // want TypeSwitchCases:"ErrorCodes: access-denied not-found"
+	err := find(key)
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *NotFoundError:
+		return e
+	default:
+		return e
+	}
+}
+
+// Errors:
+//
+//    - not-found     --
+//    - access-denied --
+func TypeSwitchFieldCode(key string) error { // want TypeSwitchFieldCode:"ErrorCodes: access-denied not-found"
+	switch e := find(key).(type) {
+	case *Error:
+		return e
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - not-found --
+func TypeAssertion(key string) error { // want TypeAssertion:"ErrorCodes: not-found"
+	if e, ok := find(key).(*NotFoundError); ok {
+		return e
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - not-found --
+func TypeAssertionAssigned(key string) error { // want TypeAssertionAssigned:"ErrorCodes: not-found"
+	err := find(key)
+	notFound := err.(*NotFoundError)
+	return notFound
+}
+
+// Errors:
+//
+//    - not-found --
+func TypeSwitchLoop(keys []string) error { // want TypeSwitchLoop:"ErrorCodes: not-found"
+	var err error
+	for _, key := range keys {
+		switch e := err.(type) {
+		case *NotFoundError:
+			err = e
+		default:
+			err = find(key)
+		}
+	}
+	if e, ok := err.(*NotFoundError); ok {
+		return e
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - connect-timeout --
+//    - read-timeout    --
+func TypeAssertionOtherPackage() error { // want TypeAssertionOtherPackage:"ErrorCodes: connect-timeout read-timeout"
+	err := inner.Fetch()
+	if timeout, ok := err.(*inner.TimeoutError); ok {
+		return timeout
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - closed          --
+//    - connect-timeout --
+//    - read-timeout    --
+func TypeSwitchOtherPackage() error { // want TypeSwitchOtherPackage:"ErrorCodes: closed connect-timeout read-timeout"
+	switch e := inner.Fetch().(type) {
+	case *inner.TimeoutError:
+		return e
+	case *inner.Error:
+		return e
+	}
+	return nil
+}
+
+type coder interface {
+	Code() string
+}
+
+// Errors:
+//
+//    - not-found     --
+//    - access-denied --
+func TypeSwitchInterface(key string) error { // want TypeSwitchInterface:"ErrorCodes: access-denied not-found"
+	switch e := find(key).(type) {
+	case coder:
+		return e.(error)
+	}
+	return nil
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,74 @@
+package typecast
+
+import "strings"
+
+// CodeError is an error, whose value is its error code.
+type CodeError string // want CodeError:`ErrorType{Field:<nil>, Codes:, Underlying:true}`
+
+func (e CodeError) Code() string  { return string(e) }
+func (e CodeError) Error() string { return string(e) }
+
+// PointerCodeError is an error with pointer receiver, whose value is its error code.
+type PointerCodeError string // want PointerCodeError:`ErrorType{Field:<nil>, Codes:typecast-unknown, Underlying:true}`
+
+func (e *PointerCodeError) Code() string {
+	if e == nil {
+		return "typecast-unknown"
+	}
+	return string(*e)
+}
+func (e *PointerCodeError) Error() string { return e.Code() }
+
+const ErrTimeout CodeError = "typecast-timeout"
+
+// Errors:
+//
+//    - typecast-not-found --
+func ConversionOfConstant() error { // want ConversionOfConstant:"ErrorCodes: typecast-not-found"
+	return CodeError("typecast-not-found")
+}
+
+// Errors:
+//
+//    - typecast-timeout --
+func NamedConstant() error { // want NamedConstant:"ErrorCodes: typecast-timeout"
+	return ErrTimeout
+}
+
+// Errors:
+//
+//    - typecast-not-found --
+//    - typecast-timeout   --
+func Variable(timeout bool) error { // want Variable:"ErrorCodes: typecast-not-found typecast-timeout"
+	err := CodeError("typecast-not-found")
+	if timeout {
+		err = "typecast-timeout"
+	}
+	return err
+}
+
+// Errors:
+//
+//    - param: code --
+func NewCodeError(code string) error { // want NewCodeError:"ErrorConstructor: {CodeParamPosition:0}" NewCodeError:"ErrorCodes:"
+	return CodeError(code)
+}
+
+// Errors:
+//
+//    - typecast-from-param --
+func Constructor() error { // want Constructor:"ErrorCodes: typecast-from-param"
+	return NewCodeError("typecast-from-param")
+}
+
+// Errors: none -- the error code is not known
+func NonConstant(message string) error { // want NonConstant:"ErrorCodes:"
+	return CodeError(strings.ToLower(message)) // want "error code has to be constant value or error code parameter"
+}
+
+// Errors:
+//
+//    - typecast-unknown --
+func PointerNil() error { // want PointerNil:"ErrorCodes: typecast-unknown"
+	return (*PointerCodeError)(nil)
+}
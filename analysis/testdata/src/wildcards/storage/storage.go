@@ -0,0 +1,29 @@
+package storage
+
+// Errors:
+//
+//    - storage-not-found --
+//    - storage-timeout   --
+func Read(key string) error { // want Read:"ErrorCodes: storage-not-found storage-timeout"
+	if key == "" {
+		return &Error{"storage-not-found"}
+	}
+	return &Error{"storage-timeout"}
+}
+
+// Errors:
+//
+//    - storage-* -- any error of the storage
+func Write(key string) error { // want Write:"ErrorCodes: storage-\\*"
+	return Read(key)
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
@@ -0,0 +1,133 @@
+package wildcards
+
+import "wildcards/storage"
+
+// Errors:
+//
+//    - storage-* -- passes through all errors of the storage
+func Load(key string) error { // want Load:"ErrorCodes: storage-\\*"
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - storage-*        --
+//    - wildcards-closed --
+func LoadOrClose(key string, closed bool) error { // want LoadOrClose:"ErrorCodes: storage-\\* wildcards-closed"
+	if closed {
+		return &Error{"wildcards-closed"}
+	}
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - storage-*       --
+//    - storage-timeout -- also declared explicitly, as it is retried by callers
+func LoadExplicit(key string) error { // want LoadExplicit:"ErrorCodes: storage-\\* storage-timeout"
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - storage-* --
+func LoadWildcard(key string) error { // want LoadWildcard:"ErrorCodes: storage-\\*"
+	if key == "" {
+		return Load(key)
+	}
+	return storage.Write(key)
+}
+
+// Errors:
+//
+//    - stor* --
+func LoadShorterPrefix(key string) error { // want LoadShorterPrefix:"ErrorCodes: stor\\*"
+	return storage.Write(key)
+}
+
+// Errors:
+//
+//    - storage-not-found --
+//    - storage-timeout   --
+func LoadSpecific(key string) error { // want LoadSpecific:"ErrorCodes: storage-not-found storage-timeout" `function "LoadSpecific" has a mismatch of declared and actual error codes: missing codes: \[storage-\*\] unused codes: \[storage-not-found storage-timeout\]`
+	return storage.Write(key)
+}
+
+// Errors:
+//
+//    - storage-* --
+//    - cache-*   --
+func LoadUnusedWildcard(key string) error { // want LoadUnusedWildcard:"ErrorCodes: cache-\\* storage-\\*" `function "LoadUnusedWildcard" has a mismatch of declared and actual error codes: unused codes: \[cache-\*\]`
+	return storage.Read(key)
+}
+
+// Errors:
+//
+//    - storage-timeout-* --
+func LoadLongerPrefix(key string) error { // want LoadLongerPrefix:"ErrorCodes: storage-timeout-\\*" `function "LoadLongerPrefix" has a mismatch of declared and actual error codes: missing codes: \[storage-\*\] unused codes: \[storage-timeout-\*\]`
+	return storage.Write(key)
+}
+
+// Errors:
+//
+//    - * --
+func InvalidWildcard1() error { // want `function "InvalidWildcard1" has odd docstring: declared error code has invalid format: wildcards should match .*`
+	return nil
+}
+
+// Errors:
+//
+//    - -storage-* --
+func InvalidWildcard2() error { // want `function "InvalidWildcard2" has odd docstring: declared error code has invalid format: wildcards should match .*`
+	return nil
+}
+
+// Errors:
+//
+//    - storage-*-error --
+func InvalidWildcard3() error { // want `function "InvalidWildcard3" has odd docstring: declared error code has invalid format: should match .*`
+	return nil
+}
+
+// Loader loads values of any storage.
+type Loader interface { // want Loader:"ErrorInterface: Load"
+	// Errors:
+	//
+	//    - storage-* --
+	Load(key string) error // want Load:"ErrorCodes: storage-\\*"
+}
+
+type storageLoader struct{}
+
+// Errors:
+//
+//    - storage-not-found --
+//    - storage-timeout   --
+func (storageLoader) Load(key string) error { // want Load:"ErrorCodes: storage-not-found storage-timeout"
+	return storage.Read(key)
+}
+
+type closingLoader struct{}
+
+// Errors:
+//
+//    - wildcards-closed --
+func (closingLoader) Load(key string) error { // want Load:"ErrorCodes: wildcards-closed"
+	return &Error{"wildcards-closed"}
+}
+
+func UseLoaders() {
+	var loader Loader = storageLoader{}
+	loader = closingLoader{} // want `cannot use expression as "Loader" value: method "Load" declares the following error codes which were not part of the interface: \[wildcards-closed\]`
+	_ = loader
+}
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+}
+
+func (e *Error) Code() string               { return e.TheCode }
+func (e *Error) Message() string            { return e.TheCode }
+func (e *Error) Details() map[string]string { return nil }
+func (e *Error) Cause() error               { return nil }
+func (e *Error) Error() string              { return e.Message() }
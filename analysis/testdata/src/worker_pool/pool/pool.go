@@ -0,0 +1,36 @@
+package pool
+
+import "sync"
+
+// Pool runs submitted tasks concurrently.
+//
+// Error Group: Submit, TrySubmit -> Wait
+type Pool struct { // want Pool:"ErrorGroup: {Submit:\\[Submit TrySubmit\\], Wait:Wait}"
+	wg   sync.WaitGroup
+	once sync.Once
+	err  error
+}
+
+func New() *Pool {
+	return &Pool{}
+}
+
+func (p *Pool) Submit(task func() error) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if err := task(); err != nil {
+			p.once.Do(func() { p.err = err })
+		}
+	}()
+}
+
+func (p *Pool) TrySubmit(task func() error) bool {
+	p.Submit(task)
+	return true
+}
+
+func (p *Pool) Wait() error {
+	p.wg.Wait()
+	return p.err
+}
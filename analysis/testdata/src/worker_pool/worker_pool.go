@@ -0,0 +1,94 @@
+package worker_pool
+
+import "worker_pool/pool"
+
+type Error struct{ TheCode string } // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.TheCode }
+
+// Errors:
+//
+//    - pool-error-fetch --
+func fetch() error { // want fetch:"ErrorCodes: pool-error-fetch"
+	return &Error{"pool-error-fetch"}
+}
+
+// Errors:
+//
+//    - pool-error-fetch --
+//    - pool-error-store --
+func FetchAll() error { // want FetchAll:"ErrorCodes: pool-error-fetch pool-error-store"
+	p := pool.New()
+	p.Submit(fetch)
+	p.TrySubmit(func() error {
+		return &Error{"pool-error-store"}
+	})
+	return p.Wait()
+}
+
+// Errors:
+//
+//    - pool-error-fetch --
+func MissingCode() error { // want MissingCode:"ErrorCodes: pool-error-fetch" `function "MissingCode" has a mismatch of declared and actual error codes: missing codes: \[pool-error-store]`
+	var p pool.Pool
+	p.Submit(fetch)
+	p.Submit(func() error { return &Error{"pool-error-store"} })
+	return p.Wait()
+}
+
+// Errors:
+//
+//    - pool-error-fetch --
+func Leaked() error { // want Leaked:"ErrorCodes: pool-error-fetch"
+	p := pool.New()
+	p.Submit(fetch)
+	submitMore(p) // want `unsupported: error group may not be passed to other functions`
+	return p.Wait()
+}
+
+func submitMore(p *pool.Pool) {}
+
+// Errors: none
+func Parameter(p *pool.Pool) error { // want Parameter:"ErrorCodes:"
+	return p.Wait() // want `error group may not be a parameter, receiver or global variable`
+}
+
+// Queue is a local pool type.
+//
+// Error Group: Go -> Wait
+type Queue struct { // want Queue:"ErrorGroup: {Submit:\\[Go\\], Wait:Wait}"
+	tasks []func() error
+}
+
+func (q *Queue) Go(task func() error) { q.tasks = append(q.tasks, task) }
+
+func (q *Queue) Wait() error {
+	for _, task := range q.tasks {
+		if err := task(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - pool-error-fetch --
+func RunQueue() error { // want RunQueue:"ErrorCodes: pool-error-fetch"
+	var q Queue
+	q.Go(fetch)
+	return q.Wait()
+}
+
+// Error Group: Submit
+type NoArrow struct{} // want `invalid annotation: expected "Error Group:" followed by the submit methods, an arrow and the wait method, e.g. "Submit -> Wait"`
+
+// Error Group: Submit -> Wait
+type NoMethods struct{} // want `error group "NoMethods" has no method "Submit"`
+
+// Error Group: Submit -> Wait
+type WrongSignatures struct{} // want `submit method "Submit" of an error group has to take a single function returning an error`
+
+func (WrongSignatures) Submit(task func()) {}
+func (WrongSignatures) Wait()              {}
@@ -0,0 +1,84 @@
+package wrap
+
+import "fmt"
+
+type Error struct { // want Error:`ErrorType{Field:{Name:"TheCode", Position:0}, Codes:}`
+	TheCode string
+	Message string
+	Cause   error
+}
+
+func (e *Error) Code() string  { return e.TheCode }
+func (e *Error) Error() string { return e.Message }
+
+// Errors:
+//
+//    - param: code --
+func New(code string, cause error) error { // want New:"ErrorConstructor: {CodeParamPosition:0}" New:"ErrorCodes:"
+	return &Error{code, "", cause}
+}
+
+func wrap(code string, cause error) error { // want wrap:"ErrorConstructor: {CodeParamPosition:0}"
+	return &Error{TheCode: code, Message: fmt.Sprintf("%s: %v", code, cause), Cause: cause}
+}
+
+func wrapf(cause error, code string, format string, args ...interface{}) error { // want wrapf:"ErrorConstructor: {CodeParamPosition:1}"
+	if cause == nil {
+		return nil
+	}
+	return &Error{code, fmt.Sprintf(format, args...), cause}
+}
+
+func wrapNew(code string, cause error) error { // want wrapNew:"ErrorConstructor: {CodeParamPosition:0}"
+	return New(code, cause)
+}
+
+// withMessage is not a wrap helper, as it does not attach a cause.
+func withMessage(code string, message string) error {
+	return &Error{code, message, nil} // want `require an error code parameter declaration to use "code" as an error code`
+}
+
+func load() error { return fmt.Errorf("load failed") }
+
+// Errors:
+//
+//    - wrap-error-load --
+func Load() error { // want Load:"ErrorCodes: wrap-error-load"
+	if err := load(); err != nil {
+		return wrap("wrap-error-load", err)
+	}
+	return nil
+}
+
+// Errors:
+//
+//    - wrap-error-load    --
+//    - wrap-error-timeout --
+func LoadFormatted(timeout bool) error { // want LoadFormatted:"ErrorCodes: wrap-error-load wrap-error-timeout"
+	err := load()
+	if timeout {
+		return wrapNew("wrap-error-timeout", err)
+	}
+	return wrapf(err, "wrap-error-load", "loading %d", 1)
+}
+
+// Errors:
+//
+//    - wrap-error-load --
+func MissingCode() error { // want MissingCode:"ErrorCodes: wrap-error-load" `function "MissingCode" has a mismatch of declared and actual error codes: missing codes: \[wrap-error-parse]`
+	return wrap("wrap-error-parse", load())
+}
+
+// Errors:
+//
+//    - wrap-error-load --
+func NonConstantCode(code string) error { // want NonConstantCode:"ErrorCodes: wrap-error-load" `function "NonConstantCode" has a mismatch of declared and actual error codes: unused codes: \[wrap-error-load]`
+	return wrap(code, load()) // want `require an error code parameter declaration to use "code" as an error code`
+}
+
+// Errors:
+//
+//    - wrap-error-message --
+func CallMessage() error { // want CallMessage:"ErrorCodes: wrap-error-message" `function "CallMessage" has a mismatch of declared and actual error codes: unused codes: \[wrap-error-message]`
+	return withMessage("wrap-error-message", "message")
+}
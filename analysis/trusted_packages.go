@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isTrustedPackage checks if the given analysed package is listed in the -trusted-packages flag.
+//
+// The declared error codes of the functions of trusted packages are exported as facts without verifying their bodies,
+// e.g. for packages full of patterns the analysis can't follow. Callers in other packages are still verified against them.
+// Listed package paths also match their sub-packages.
+func isTrustedPackage(pass *analysis.Pass) bool {
+	return isPackageListed(pass.Pkg, cliArguments.trustedPackages)
+}
+
+// trustClaimedErrorCodes trusts the declared error codes of the given functions, if the package is a trusted package,
+// so their bodies are not searched. Functions without declared error codes return no error codes.
+//
+// The result is true, if the package is trusted and the remaining checks of function bodies have to be skipped.
+func trustClaimedErrorCodes(pass *analysis.Pass, lookup *funcLookup, funcsToAnalyse []*ast.FuncDecl, funcClaims funcCodesMap) bool {
+	if !isTrustedPackage(pass) {
+		return false
+	}
+
+	for _, funcDecl := range funcsToAnalyse {
+		codes := Set()
+		if claims, ok := funcClaims[funcDecl]; ok {
+			codes = claims.codes
+		}
+		lookup.foundCodes[funcDecl] = codes
+		lookup.trusted[funcDecl] = struct{}{}
+	}
+	return true
+}
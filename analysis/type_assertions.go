@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// getTypeSwitchGuard returns the type assertion `x.(type)` of the type switch declaring the given ident,
+// e.g. for `e` in `switch e := err.(type)`, or nil if the ident is not declared by a type switch.
+func getTypeSwitchGuard(ident *ast.Ident) *ast.TypeAssertExpr {
+	if ident.Obj == nil {
+		return nil
+	}
+
+	assignment, ok := ident.Obj.Decl.(*ast.AssignStmt)
+	if !ok || len(assignment.Rhs) != 1 {
+		return nil
+	}
+
+	typeAssertion, ok := assignment.Rhs[0].(*ast.TypeAssertExpr)
+	if !ok || typeAssertion.Type != nil {
+		return nil
+	}
+	return typeAssertion
+}
+
+// findErrorCodesInTypeAssertion finds the error codes of the given error expression,
+// after it was asserted to be of the given type, e.g. in `err.(*NotFoundError)` or in a case clause of a type switch.
+// For a type switch, symbol is the object declared by it, otherwise it is nil.
+//
+// If the asserted type is an error type with constant error codes, only those codes remain.
+// For all other types, e.g. interfaces or error types that store their error code in a field, the codes are not narrowed.
+// The same applies if the asserted type is unknown, i.e. nil.
+func findErrorCodesInTypeAssertion(c *context, visitedIdents map[*ast.Object]struct{}, expr ast.Expr, assertedType types.Type, symbol *ast.Object, startingFunc *funcDefinition) CodeSet {
+	pass := c.pass
+
+	if symbol != nil {
+		if _, ok := visitedIdents[symbol]; ok {
+			return Set()
+		}
+	}
+
+	// The narrowed codes must not hide the codes of the expression from other uses of it,
+	// e.g. in the default clause of a type switch, so the expression is analysed using a copy of the visited idents.
+	visited := make(map[*ast.Object]struct{}, len(visitedIdents)+1)
+	for object := range visitedIdents {
+		visited[object] = struct{}{}
+	}
+	if symbol != nil {
+		visited[symbol] = struct{}{}
+	}
+
+	codes := findErrorCodesInExpression(c, visited, expr, startingFunc)
+	if assertedType == nil || types.IsInterface(assertedType) || getNamedType(assertedType) == nil {
+		return codes
+	}
+
+	errorType, err := getErrorTypeForError(pass, assertedType)
+	if err != nil || errorType == nil || errorType.Field != nil {
+		return codes
+	}
+	return Intersection(codes, SliceToSet(errorType.Codes))
+}
@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// typeArgument is the type argument of an instantiation together with the type parameter it is used for.
+type typeArgument struct {
+	param      string
+	arg        types.Type
+	constraint types.Type
+}
+
+// unwrapInstantiation returns the generic function of the given explicit instantiation,
+// e.g. `Get` for `Get[int]` or `Pair` for `Pair[string, int]`.
+// Other expressions are returned as is, without parentheses.
+func unwrapInstantiation(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	expr = astutil.Unparen(expr)
+	if indexExpr, ok := expr.(*ast.IndexExpr); ok {
+		if pass.TypesInfo.Types[indexExpr.Index].IsType() {
+			return astutil.Unparen(indexExpr.X)
+		}
+		return expr
+	}
+
+	if x, ok := getIndexListExprX(expr); ok {
+		return astutil.Unparen(x)
+	}
+	return expr
+}
@@ -0,0 +1,29 @@
+//go:build !go1.18
+// +build !go1.18
+
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// getIndexListExprX returns the indexed expression, if the given expression is an instantiation with multiple type arguments.
+// Type parameters are not supported before Go 1.18.
+func getIndexListExprX(expr ast.Expr) (ast.Expr, bool) {
+	return nil, false
+}
+
+// getInstanceTypeArguments returns the type arguments, if the given expression denotes an instantiation of a generic function or type.
+// Type parameters are not supported before Go 1.18.
+func getInstanceTypeArguments(pass *analysis.Pass, expr ast.Expr) []typeArgument {
+	return nil
+}
+
+// isGenericSignature checks if the given signature belongs to a generic function or to a method of a generic type.
+// Type parameters are not supported before Go 1.18.
+func isGenericSignature(sig *types.Signature) bool {
+	return false
+}
@@ -0,0 +1,134 @@
+//go:build go1.18
+// +build go1.18
+
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// getIndexListExprX returns the indexed expression, if the given expression is an instantiation with multiple type arguments.
+func getIndexListExprX(expr ast.Expr) (ast.Expr, bool) {
+	indexListExpr, ok := expr.(*ast.IndexListExpr)
+	if !ok {
+		return nil, false
+	}
+	return indexListExpr.X, true
+}
+
+// getInstanceTypeArguments returns the type arguments, if the given expression denotes an instantiation of a generic function or type,
+// i.e. an explicit instantiation like `FetchAll[*httpFetcher]` or the function of an inferred call like `FetchAll(&httpFetcher{})`.
+// Type arguments which are type parameters themselves are skipped, as their contract is checked where they are instantiated.
+//
+// The type arguments are taken from the instantiated type recorded for the expression,
+// as the Instances of the type information are not recorded by all drivers.
+func getInstanceTypeArguments(pass *analysis.Pass, expr ast.Expr) []typeArgument {
+	generic := unwrapInstantiation(pass, expr)
+	if selector, ok := generic.(*ast.SelectorExpr); ok {
+		generic = selector.Sel
+	}
+	ident, ok := generic.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	obj := pass.TypesInfo.Uses[ident]
+	if obj == nil {
+		return nil
+	}
+
+	var typeParams *types.TypeParamList
+	typeArgs := map[*types.TypeParam]types.Type{}
+	switch instance := pass.TypesInfo.TypeOf(expr).(type) {
+	case *types.Signature:
+		sig, ok := obj.Type().(*types.Signature)
+		if !ok || instance.TypeParams().Len() > 0 {
+			return nil
+		}
+		typeParams = sig.TypeParams()
+		inferTypeArguments(sig, instance, typeArgs)
+	case *types.Named:
+		if ident == expr {
+			return nil // the generic type itself, e.g. `Cache` of `Cache[T]`
+		}
+		typeParams = instance.Origin().TypeParams()
+		for i := 0; i < instance.TypeArgs().Len() && i < typeParams.Len(); i++ {
+			typeArgs[typeParams.At(i)] = instance.TypeArgs().At(i)
+		}
+	}
+	if typeParams == nil {
+		return nil
+	}
+
+	var result []typeArgument
+	for i := 0; i < typeParams.Len(); i++ {
+		param := typeParams.At(i)
+		arg, ok := typeArgs[param]
+		if !ok {
+			continue
+		}
+		if _, ok := arg.(*types.TypeParam); ok {
+			continue
+		}
+		result = append(result, typeArgument{param.Obj().Name(), arg, param.Constraint()})
+	}
+	return result
+}
+
+// inferTypeArguments adds the types used for the type parameters of the given generic type to the given map,
+// by matching the generic type with its instance, e.g. `*invalidFetcher` for `F` of `func(...F)` and `func(...*invalidFetcher)`.
+// Type parameters only used in constraints are not found.
+func inferTypeArguments(generic, instance types.Type, typeArgs map[*types.TypeParam]types.Type) {
+	switch generic := generic.(type) {
+	case *types.TypeParam:
+		if _, ok := typeArgs[generic]; !ok {
+			typeArgs[generic] = instance
+		}
+	case *types.Pointer:
+		if instance, ok := instance.(*types.Pointer); ok {
+			inferTypeArguments(generic.Elem(), instance.Elem(), typeArgs)
+		}
+	case *types.Slice:
+		if instance, ok := instance.(*types.Slice); ok {
+			inferTypeArguments(generic.Elem(), instance.Elem(), typeArgs)
+		}
+	case *types.Array:
+		if instance, ok := instance.(*types.Array); ok {
+			inferTypeArguments(generic.Elem(), instance.Elem(), typeArgs)
+		}
+	case *types.Chan:
+		if instance, ok := instance.(*types.Chan); ok {
+			inferTypeArguments(generic.Elem(), instance.Elem(), typeArgs)
+		}
+	case *types.Map:
+		if instance, ok := instance.(*types.Map); ok {
+			inferTypeArguments(generic.Key(), instance.Key(), typeArgs)
+			inferTypeArguments(generic.Elem(), instance.Elem(), typeArgs)
+		}
+	case *types.Signature:
+		if instance, ok := instance.(*types.Signature); ok {
+			inferTupleTypeArguments(generic.Params(), instance.Params(), typeArgs)
+			inferTupleTypeArguments(generic.Results(), instance.Results(), typeArgs)
+		}
+	case *types.Named:
+		if instance, ok := instance.(*types.Named); ok && generic.TypeArgs().Len() == instance.TypeArgs().Len() {
+			for i := 0; i < generic.TypeArgs().Len(); i++ {
+				inferTypeArguments(generic.TypeArgs().At(i), instance.TypeArgs().At(i), typeArgs)
+			}
+		}
+	}
+}
+
+// inferTupleTypeArguments matches the types of the given generic tuple with the ones of its instance, see inferTypeArguments.
+func inferTupleTypeArguments(generic, instance *types.Tuple, typeArgs map[*types.TypeParam]types.Type) {
+	for i := 0; i < generic.Len() && i < instance.Len(); i++ {
+		inferTypeArguments(generic.At(i).Type(), instance.At(i).Type(), typeArgs)
+	}
+}
+
+// isGenericSignature checks if the given signature belongs to a generic function or to a method of a generic type.
+func isGenericSignature(sig *types.Signature) bool {
+	return sig.TypeParams().Len() > 0 || sig.RecvTypeParams().Len() > 0
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// undeclaredCallees collects calls to functions of other packages, which do not declare error codes.
+//
+// Such functions are often called from many places of a package, so instead of one diagnostic per call,
+// a single diagnostic is reported for each function, with the other calls as related information.
+type undeclaredCallees struct {
+	callees map[types.Object]*undeclaredCallee
+}
+
+type undeclaredCallee struct {
+	id     MessageID
+	args   []interface{}
+	ranges []analysis.Range
+}
+
+func newUndeclaredCallees() *undeclaredCallees {
+	return &undeclaredCallees{map[types.Object]*undeclaredCallee{}}
+}
+
+// reportUndeclaredCallee reports a call of the given function, which does not declare error codes.
+//
+// Calls of functions of other packages are collected, to be reported once per function by reportUndeclaredCallees.
+// All other calls are reported right away.
+func reportUndeclaredCallee(c *context, callee types.Object, rng analysis.Range, id MessageID, args ...interface{}) {
+	if callee == nil || callee.Pkg() == nil || callee.Pkg() == c.pass.Pkg {
+		report(c.pass, rng, id, args...)
+		return
+	}
+
+	entry, ok := c.undeclaredCallees.callees[callee]
+	if !ok {
+		entry = &undeclaredCallee{id: id, args: args}
+		c.undeclaredCallees.callees[callee] = entry
+	}
+
+	// The same call may be analysed multiple times, e.g. if it is part of a function literal.
+	for _, existing := range entry.ranges {
+		if existing.Pos() == rng.Pos() {
+			return
+		}
+	}
+	entry.ranges = append(entry.ranges, rng)
+}
+
+// reportUndeclaredCallees reports a diagnostic for each collected function at its first call.
+// If the function is called multiple times, the number of calls is added to the message
+// and the other calls are added as related information.
+func reportUndeclaredCallees(c *context) {
+	entries := make([]*undeclaredCallee, 0, len(c.undeclaredCallees.callees))
+	for _, entry := range c.undeclaredCallees.callees {
+		sort.Slice(entry.ranges, func(i, j int) bool {
+			return entry.ranges[i].Pos() < entry.ranges[j].Pos()
+		})
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ranges[0].Pos() < entries[j].ranges[0].Pos()
+	})
+
+	for _, entry := range entries {
+		first := entry.ranges[0]
+		diagnostic := newDiagnostic(c.pass, first.Pos(), first.End(), nil, entry.id, entry.args...)
+		if len(entry.ranges) > 1 {
+			diagnostic.Message = FormatMessage(MsgRepeatedCall, diagnostic.Message, len(entry.ranges))
+		}
+		for _, rng := range entry.ranges[1:] {
+			diagnostic.Related = append(diagnostic.Related, analysis.RelatedInformation{
+				Pos:     rng.Pos(),
+				End:     rng.End(),
+				Message: FormatMessage(MsgRepeatedCallSite),
+			})
+		}
+		c.pass.Report(diagnostic)
+	}
+}
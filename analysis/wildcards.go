@@ -0,0 +1,102 @@
+package analysis
+
+import "strings"
+
+// wildcardSuffix marks a declared error code as wildcard, e.g. `storage-*`,
+// which is satisfied by every error code starting with the given prefix.
+//
+// Wildcards may only be declared in docstrings. They are useful for layers,
+// which intentionally pass through an entire family of error codes.
+const wildcardSuffix = "*"
+
+// isWildcardCode checks if the given declared error code is a wildcard.
+func isWildcardCode(code string) bool {
+	return strings.HasSuffix(code, wildcardSuffix)
+}
+
+// isWildcardValid checks if the given wildcard consists of a valid prefix of an error code followed by the wildcard suffix.
+func isWildcardValid(code string) bool {
+	prefix := strings.TrimSuffix(code, wildcardSuffix)
+	if prefix == code || prefix == "" {
+		return false
+	}
+
+	// A prefix may end with a dash, e.g. in `storage-*`, which is not allowed for error codes.
+	return isErrorCodeValid(strings.TrimRight(prefix, "-"))
+}
+
+// isDeclaredCodeValid checks if the given code is a valid error code or a valid wildcard.
+func isDeclaredCodeValid(code string) bool {
+	if isWildcardCode(code) {
+		return isWildcardValid(code)
+	}
+	return isErrorCodeValid(code)
+}
+
+// checkDeclaredCodeValid returns an error if the given code is neither a valid error code nor a valid wildcard.
+func checkDeclaredCodeValid(code string) error {
+	if isWildcardCode(code) {
+		if !isWildcardValid(code) {
+			return newMessageError(MsgInvalidWildcardFormat)
+		}
+		return nil
+	}
+	return checkErrorCodeValid(code)
+}
+
+// codeMatchesClaim checks if the given code is covered by the given declared code.
+//
+// Codes are covered by the same code, and by wildcards matching their prefix.
+// The given code may be a wildcard itself, which is only covered by wildcards with the same or a shorter prefix.
+func codeMatchesClaim(code, claim string) bool {
+	if code == claim {
+		return true
+	}
+	if !isWildcardCode(claim) {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSuffix(code, wildcardSuffix), strings.TrimSuffix(claim, wildcardSuffix))
+}
+
+// findUncoveredCodes returns all given codes, which are not covered by any of the declared codes.
+// Without wildcards, this is the same as the Difference of both sets.
+func findUncoveredCodes(codes, claims CodeSet) CodeSet {
+	result := Set()
+	for code := range codes {
+		if !isCodeCovered(code, claims) {
+			result.Add(code)
+		}
+	}
+	return result
+}
+
+// findUnusedClaims returns all declared codes, which do not cover any of the given codes.
+// Without wildcards, this is the same as the Difference of both sets.
+func findUnusedClaims(claims, codes CodeSet) CodeSet {
+	result := Set()
+	for claim := range claims {
+		used := false
+		for code := range codes {
+			if codeMatchesClaim(code, claim) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			result.Add(claim)
+		}
+	}
+	return result
+}
+
+func isCodeCovered(code string, claims CodeSet) bool {
+	if _, ok := claims[code]; ok {
+		return true
+	}
+	for claim := range claims {
+		if codeMatchesClaim(code, claim) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodeMatchesClaim(t *testing.T) {
+	tests := []struct {
+		code    string
+		claim   string
+		matches bool
+	}{
+		{"storage-timeout", "storage-timeout", true},
+		{"storage-timeout", "storage-not-found", false},
+		{"storage-timeout", "storage-*", true},
+		{"storage-timeout", "stor*", true},
+		{"storage-timeout", "cache-*", false},
+		{"storage-*", "storage-*", true},
+		{"storage-*", "stor*", true},
+		{"storage-*", "storage-timeout-*", false},
+		{"storage-*", "storage-timeout", false},
+	}
+
+	for _, test := range tests {
+		if result := codeMatchesClaim(test.code, test.claim); result != test.matches {
+			t.Errorf("codeMatchesClaim(%q, %q) should be %v but was %v", test.code, test.claim, test.matches, result)
+		}
+	}
+}
+
+func TestIsWildcardValid(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"storage-*", true},
+		{"storage*", true},
+		{"s*", true},
+		{"*", false},
+		{"-*", false},
+		{"-storage-*", false},
+		{"3storage-*", false},
+		{"storage--*", true},
+		{"storage", false},
+	}
+
+	for _, test := range tests {
+		if result := isWildcardValid(test.code); result != test.valid {
+			t.Errorf("isWildcardValid(%q) should be %v but was %v", test.code, test.valid, result)
+		}
+	}
+}
+
+func TestFindUncoveredCodesAndUnusedClaims(t *testing.T) {
+	codes := Set("storage-timeout", "storage-not-found", "cache-miss")
+	claims := Set("storage-*", "cache-miss", "queue-*")
+
+	if result := findUncoveredCodes(codes, claims); !reflect.DeepEqual(result, Set()) {
+		t.Errorf("findUncoveredCodes(%v, %v) should be empty but was %v", codes, claims, result)
+	}
+	if result := findUnusedClaims(claims, codes); !reflect.DeepEqual(result, Set("queue-*")) {
+		t.Errorf("findUnusedClaims(%v, %v) should be [queue-*] but was %v", claims, codes, result)
+	}
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// exportWrapHelperFacts exports ErrorConstructor facts for the wrap helpers of the package, e.g. `wrap` in:
+//
+//	func wrap(code string, cause error) error {
+//		return &Error{TheCode: code, Message: cause.Error(), Cause: cause}
+//	}
+//
+// Wrap helpers are unexported functions without "Errors:" block, which take an error code and a cause,
+// and return an error with that code. Calls like `wrap("some-error", err)` then return the code of their argument,
+// without the helper having to declare its error code parameter.
+func exportWrapHelperFacts(pass *analysis.Pass, lookup *funcLookup) {
+	lookup.forEach(func(funcDecl *ast.FuncDecl) {
+		if position, ok := findWrapHelperCodeParam(pass, funcDecl); ok {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[funcDecl.Name], &ErrorConstructor{CodeParamPosition: position})
+		}
+	})
+}
+
+// findWrapHelperCodeParam checks if the given function is a wrap helper and returns the position of its error code parameter.
+//
+// Every return statement of a wrap helper has to return nil, or create an error with the same string parameter as error code,
+// either with a composite literal of an error type or by calling an error constructor.
+// One of the parameters has to be an error interface, which is the cause attached to the created error.
+func findWrapHelperCodeParam(pass *analysis.Pass, funcDecl *ast.FuncDecl) (int, bool) {
+	fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	if !ok || fn.Exported() || funcDecl.Recv != nil || funcDecl.Body == nil || hasErrorDocs(pass, funcDecl) || pass.ImportObjectFact(fn, new(ErrorConstructor)) {
+		return -1, false
+	}
+
+	signature := fn.Type().(*types.Signature)
+	if signature.Results().Len() != 1 || !types.Implements(signature.Results().At(0).Type(), tError) || !hasCauseParam(signature) {
+		return -1, false
+	}
+
+	position := -1
+	isWrapHelper := true
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) != 1 || isNilExpr(pass, node.Results[0]) {
+				isWrapHelper = isWrapHelper && len(node.Results) == 1
+				return false
+			}
+
+			codeExpr := findWrapHelperCodeExpr(pass, node.Results[0])
+			ident, ok := codeExpr.(*ast.Ident)
+			if !ok {
+				isWrapHelper = false
+				return false
+			}
+			paramPosition := getParamPosition(funcDecl.Type, ident)
+			if paramPosition == -1 || (position != -1 && position != paramPosition) {
+				isWrapHelper = false
+				return false
+			}
+			position = paramPosition
+			return false
+		}
+		return true
+	})
+
+	if !isWrapHelper || position == -1 {
+		return -1, false
+	}
+	if basic, ok := signature.Params().At(position).Type().(*types.Basic); !ok || basic.Kind() != types.String {
+		return -1, false
+	}
+	return position, true
+}
+
+// hasCauseParam checks if one of the parameters of the given signature is of type error,
+// or of another interface type implementing error, e.g. a named type `type Cause error`.
+func hasCauseParam(signature *types.Signature) bool {
+	for i := 0; i < signature.Params().Len(); i++ {
+		if typ := signature.Params().At(i).Type(); types.IsInterface(typ) && types.Implements(typ, tError) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNilExpr checks if the given expression is the predeclared nil.
+func isNilExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	return pass.TypesInfo.Types[astutil.Unparen(expr)].IsNil()
+}
+
+// findWrapHelperCodeExpr returns the expression passed as error code to the error created by the given expression,
+// or nil if it does not create an error with a code.
+//
+// The error may be created with a composite literal of an error type, e.g. `&Error{TheCode: code}`,
+// or by calling an error constructor, e.g. `NewError(code, cause)`.
+func findWrapHelperCodeExpr(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	expr = astutil.Unparen(expr)
+	if callExpr, ok := expr.(*ast.CallExpr); ok {
+		var fact ErrorConstructor
+		callee := typeutil.Callee(pass.TypesInfo, callExpr)
+		if callee == nil || !pass.ImportObjectFact(callee, &fact) || fact.Prefix != "" || fact.CodeParamPosition >= len(callExpr.Args) {
+			return nil
+		}
+		return astutil.Unparen(callExpr.Args[fact.CodeParamPosition])
+	}
+
+	literal := expr
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		literal = astutil.Unparen(unary.X)
+	}
+	compositeLit, ok := literal.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	errorType, err := getErrorTypeForError(pass, pass.TypesInfo.TypeOf(compositeLit))
+	if err != nil || errorType == nil || errorType.Field == nil {
+		return nil
+	}
+
+	for i, element := range compositeLit.Elts {
+		if keyValue, ok := element.(*ast.KeyValueExpr); ok {
+			if key, ok := keyValue.Key.(*ast.Ident); ok && key.Name == errorType.Field.Name {
+				return astutil.Unparen(keyValue.Value)
+			}
+		} else if i == errorType.Field.Position {
+			return astutil.Unparen(element)
+		}
+	}
+	return nil
+}
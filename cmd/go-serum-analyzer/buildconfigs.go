@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+const buildconfigsUsage = `usage: go-serum-analyzer buildconfigs [-configs=GOOS/GOARCH,...] [-union] [packages]
+
+Buildconfigs analyses the given packages once for each build configuration, so
+files for other platforms, e.g. "open_windows.go" or files with a "//go:build"
+constraint, are analysed as well. The diagnostics of every configuration are
+printed, prefixed with the configuration.
+
+Functions declared in platform-specific files may return different error codes
+per configuration. By default, these functions are reported with their error
+codes in each configuration, including functions that have no error codes in
+some configurations. With -union, the error codes of all functions are printed
+instead, unioned over all configurations.
+
+The exit code is 1 if diagnostics or mismatching error codes were found, and 2 for usage errors.
+
+Flags:
+`
+
+// buildconfigsDefault are the configurations analysed if no -configs flag is given.
+const buildconfigsDefault = "linux/amd64,darwin/amd64,windows/amd64"
+
+// buildconfigs runs the buildconfigs subcommand with the given arguments and returns the exit code.
+func buildconfigs(args []string) int {
+	flags := flag.NewFlagSet("buildconfigs", flag.ContinueOnError)
+	configsFlag := flags.String("configs", buildconfigsDefault, "comma separated list of the analysed build configurations")
+	union := flags.Bool("union", false, "print the error codes of all functions unioned over all configurations instead of reporting mismatches")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), buildconfigsUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	var configurations []analysis.BuildConfiguration
+	for _, value := range strings.Split(*configsFlag, ",") {
+		configuration, err := analysis.ParseBuildConfiguration(strings.TrimSpace(value))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "buildconfigs: %v\n", err)
+			return 2
+		}
+		configurations = append(configurations, configuration)
+	}
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	results, codes, err := analysis.AnalyzeBuildConfigurations(&packages.Config{}, configurations, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "buildconfigs: %v\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			fmt.Fprintf(os.Stderr, "%s: %s: %s\n", result.Configuration, diagnostic.Position, diagnostic.Message)
+			exitCode = 1
+		}
+	}
+
+	if *union {
+		printUnionCodes(os.Stdout, codes)
+	} else if printMismatchingCodes(os.Stdout, configurations, codes) {
+		exitCode = 1
+	}
+	return exitCode
+}
+
+// printUnionCodes prints the error codes of every function, unioned over all configurations.
+func printUnionCodes(w io.Writer, codes []*analysis.ConfigurationCodes) {
+	for _, entry := range codes {
+		fmt.Fprintf(w, "%s.%s: %s\n", entry.Package, entry.Object, formatCodes(entry.Union()))
+	}
+}
+
+// printMismatchingCodes prints the error codes per configuration of every function, whose codes differ between the configurations,
+// and returns true if any were found.
+func printMismatchingCodes(w io.Writer, configurations []analysis.BuildConfiguration, codes []*analysis.ConfigurationCodes) bool {
+	found := false
+	for _, entry := range codes {
+		if !entry.Mismatches(configurations) {
+			continue
+		}
+		found = true
+		fmt.Fprintf(w, "%s.%s: error codes differ between build configurations\n", entry.Package, entry.Object)
+		for _, configuration := range configurations {
+			if configurationCodes, ok := entry.Codes[configuration]; ok {
+				fmt.Fprintf(w, "\t%s: %s\n", configuration, formatCodes(configurationCodes))
+			} else {
+				fmt.Fprintf(w, "\t%s: no error codes\n", configuration)
+			}
+		}
+	}
+	return found
+}
+
+// formatCodes formats the given codes as sorted list, e.g. "[a b]".
+func formatCodes(codes analysis.CodeSet) string {
+	sorted := codes.Slice()
+	sort.Strings(sorted)
+	return "[" + strings.Join(sorted, " ") + "]"
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// buildconfigsModule is a module whose function Open returns different error codes on linux and windows.
+var buildconfigsModule = withFiles(appModule, map[string]string{
+	"open_linux.go": `package app
+
+// Open opens the file.
+//
+// Errors:
+//
+//   - app-not-found -- if the file does not exist
+func Open(found bool) error {
+	if !found {
+		return &Error{NotFound}
+	}
+	return nil
+}
+`,
+	"open_windows.go": `package app
+
+// Open opens the file.
+//
+// Errors:
+//
+//   - app-not-found -- if the file does not exist
+//   - app-sharing-violation -- if the file is opened by another process
+func Open(found, shared bool) error {
+	if !found {
+		return &Error{NotFound}
+	}
+	if shared {
+		return &Error{"app-sharing-violation"}
+	}
+	return nil
+}
+`,
+})
+
+func TestBuildconfigs(t *testing.T) {
+	dir := writeFiles(t, buildconfigsModule)
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{
+			name:     "mismatching codes",
+			args:     []string{"-configs=linux/amd64,windows/amd64,darwin/amd64"},
+			exitCode: 1,
+			stdout: `example.com/app.Open: error codes differ between build configurations
+	linux/amd64: [app-not-found]
+	windows/amd64: [app-not-found app-sharing-violation]
+	darwin/amd64: no error codes
+`,
+		},
+		{
+			name:   "matching codes",
+			args:   []string{"-configs=linux/amd64,linux/arm64", "./..."},
+			stdout: "",
+		},
+		{
+			name: "union",
+			args: []string{"-union"},
+			stdout: `example.com/app.Get: [app-not-found app-timeout]
+example.com/app.Open: [app-not-found app-sharing-violation]
+`,
+		},
+		{
+			name:     "invalid configuration",
+			args:     []string{"-configs=linux"},
+			exitCode: 2,
+			stderr:   "buildconfigs: invalid build configuration \"linux\", expected GOOS/GOARCH\n",
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"-unknown"},
+			exitCode: 2,
+			stderr:   "flag provided but not defined: -unknown",
+		},
+	}
+
+	for _, test := range tests {
+		stdout, stderr, exitCode := runMain(t, dir, "", append([]string{"buildconfigs"}, test.args...)...)
+		if exitCode != test.exitCode {
+			t.Errorf("%s: expected exit code %d, got %d (stderr %q)", test.name, test.exitCode, exitCode, stderr)
+		}
+		if stdout != test.stdout {
+			t.Errorf("%s: expected stdout\n%s\ngot\n%s", test.name, test.stdout, stdout)
+		}
+		if !strings.HasPrefix(stderr, test.stderr) || (test.stderr == "" && stderr != "") {
+			t.Errorf("%s: expected stderr starting with %q, got %q", test.name, test.stderr, stderr)
+		}
+	}
+}
+
+func TestBuildconfigsDiagnostics(t *testing.T) {
+	dir := writeFiles(t, withFiles(buildconfigsModule, map[string]string{
+		"close_windows.go": `package app
+
+// Close closes the file.
+//
+// Errors:
+//
+//   - app-closed -- if the file is already closed
+func Close() error {
+	return &Error{"app-undeclared"}
+}
+`,
+	}))
+
+	stdout, stderr, exitCode := runMain(t, dir, "", "buildconfigs", "-configs=linux/amd64,windows/amd64", "-union")
+	if exitCode != 1 {
+		t.Errorf("expected exit code 1, got %d", exitCode)
+	}
+	if want := `function "Close" has a mismatch of declared and actual error codes`; !strings.HasPrefix(stderr, "windows/amd64: ") || !strings.Contains(stderr, want) {
+		t.Errorf("expected the diagnostic %q for windows/amd64, got %q", want, stderr)
+	}
+	if want := "example.com/app.Close: [app-closed]\n"; !strings.Contains(stdout, want) {
+		t.Errorf("expected stdout containing %q, got\n%s", want, stdout)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const exportUsage = `usage: go-serum-analyzer export -entry=name [-entry=name ...] [-format=typescript|json-schema|proto] [-name=ErrorCode]
+	[-proto-package=name] [-previous=file.proto] [-lockfile=file] [file]
+
+Export reads the facts printed by "go-serum-analyzer facts -output=json" from the
+given file, or from stdin if no file is given, and prints the error codes of the
+given entry point functions as a TypeScript union type, as a JSON schema enum,
+or as a protobuf enum. This allows clients in other languages to consume the
+same verified error codes.
+
+Entry points are named by their package path followed by their name like in the
+output of the facts subcommand, e.g. "example.com/api.Handle" or
+"example.com/api.(*Server).Handle". The error codes of an entry point include
+the codes of all functions it calls, as verified by the analyzer.
+
+Protobuf enum values must never change their number. Pass the previously
+generated file with -previous, so existing codes keep their numbers and the
+numbers and names of removed codes are reserved. With -lockfile, the codes of
+the entry points in the lockfile, which are no longer returned, are reserved
+as well; generate the enum before updating the lockfile.
+
+The exit code is 1 if an entry point has no error codes fact, and 2 for usage errors.
+
+Flags:
+`
+
+// exportHeader starts every generated TypeScript file.
+const exportHeader = "// Code generated by go-serum-analyzer export. DO NOT EDIT.\n"
+
+// entriesFlag collects the values of a flag that may be given several times.
+type entriesFlag []string
+
+func (f *entriesFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *entriesFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// exportFact is the part of a fact printed by the facts subcommand, which is needed for the export.
+type exportFact struct {
+	Object string `json:"object"`
+	Type   string `json:"type"`
+	Value  struct {
+		Codes []string
+	} `json:"value"`
+}
+
+// export runs the export subcommand with the given arguments and returns the exit code.
+func export(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	var entries entriesFlag
+	flags.Var(&entries, "entry", "entry point function whose error codes are exported, may be given several times")
+	format := flags.String("format", "typescript", `format of the output, either "typescript", "json-schema" or "proto"`)
+	name := flags.String("name", "ErrorCode", "name of the generated type")
+	protoPackage := flags.String("proto-package", "", "package of the generated protobuf file")
+	previous := flags.String("previous", "", "previously generated protobuf file, whose enum value numbers are kept")
+	lockfile := flags.String("lockfile", "", "lockfile listing the previously declared error codes of the entry points, whose removed codes are reserved in the protobuf enum")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), exportUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(entries) == 0 || flags.NArg() > 1 || (*format != "typescript" && *format != "json-schema" && *format != "proto") {
+		flags.Usage()
+		return 2
+	}
+
+	input := io.Reader(os.Stdin)
+	if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 2
+		}
+		defer file.Close()
+		input = file
+	}
+
+	codesByFunction, err := readExportFacts(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export: %v\n", err)
+		return 2
+	}
+
+	codes := map[string]struct{}{}
+	for _, entry := range entries {
+		entryCodes, ok := codesByFunction[entry]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "export: entry point %q has no error codes fact, it does not declare error codes or is not part of the facts\n", entry)
+			return 1
+		}
+		for _, code := range entryCodes {
+			codes[code] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(codes))
+	for code := range codes {
+		if !isCoveredByWildcard(code, codes) {
+			sorted = append(sorted, code)
+		}
+	}
+	sort.Strings(sorted)
+
+	var output []byte
+	switch *format {
+	case "typescript":
+		output = generateTypeScript(*name, sorted)
+	case "json-schema":
+		if output, err = generateJSONSchema(*name, sorted); err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 2
+		}
+	case "proto":
+		if output, err = exportProto(*name, *protoPackage, *previous, *lockfile, entries, codes, sorted); err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 1
+		}
+	}
+	os.Stdout.Write(output)
+	return 0
+}
+
+// readExportFacts reads the JSON objects printed by the facts subcommand, one per package,
+// and returns the error codes of each function by its package path and name.
+func readExportFacts(input io.Reader) (map[string][]string, error) {
+	result := map[string][]string{}
+	decoder := json.NewDecoder(input)
+	for {
+		var pkg struct {
+			Package string       `json:"package"`
+			Facts   []exportFact `json:"facts"`
+		}
+		if err := decoder.Decode(&pkg); err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid facts, expected the output of \"facts -output=json\": %v", err)
+		}
+
+		for _, fact := range pkg.Facts {
+			if fact.Type == "ErrorCodes" {
+				result[pkg.Package+"."+fact.Object] = fact.Value.Codes
+			}
+		}
+	}
+}
+
+// isCoveredByWildcard checks if the given code is matched by another code of the set, which is a wildcard like "storage-*".
+func isCoveredByWildcard(code string, codes map[string]struct{}) bool {
+	for wildcard := range codes {
+		if prefix := strings.TrimSuffix(wildcard, "*"); prefix != wildcard && wildcard != code && strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateTypeScript generates a TypeScript union type of the given sorted error codes.
+// Wildcards like "storage-*" become template literal types, which match all codes with the prefix.
+func generateTypeScript(name string, codes []string) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(exportHeader)
+	fmt.Fprintf(&buffer, "\nexport type %s =", name)
+	if len(codes) == 0 {
+		buffer.WriteString(" never;\n")
+		return buffer.Bytes()
+	}
+	for i, code := range codes {
+		if prefix := strings.TrimSuffix(code, "*"); prefix != code {
+			fmt.Fprintf(&buffer, "\n\t| `%s${string}`", prefix)
+		} else {
+			fmt.Fprintf(&buffer, "\n\t| %q", code)
+		}
+		if i == len(codes)-1 {
+			buffer.WriteString(";\n")
+		}
+	}
+	return buffer.Bytes()
+}
+
+// generateJSONSchema generates a JSON schema of a string with the given sorted error codes as enum.
+// Wildcards like "storage-*" are matched by patterns, which match all codes with the prefix.
+func generateJSONSchema(name string, codes []string) ([]byte, error) {
+	enum := []string{}
+	var patterns []interface{}
+	for _, code := range codes {
+		if prefix := strings.TrimSuffix(code, "*"); prefix != code {
+			patterns = append(patterns, map[string]string{"type": "string", "pattern": "^" + prefix})
+		} else {
+			enum = append(enum, code)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   name,
+		"type":    "string",
+		"enum":    enum,
+	}
+	if len(patterns) > 0 {
+		delete(schema, "enum")
+		schema["anyOf"] = append([]interface{}{map[string]interface{}{"enum": enum}}, patterns...)
+	}
+	output, err := json.MarshalIndent(schema, "", "\t")
+	return append(output, '\n'), err
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	protoValuePattern    = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9_]*)\s*=\s*(\d+)\s*;`)
+	protoReservedPattern = regexp.MustCompile(`^\s*reserved\s+(.*);`)
+	protoEnumEndPattern  = regexp.MustCompile(`^\s*}`)
+)
+
+// protoEnum is the state of a previously generated protobuf enum.
+// It is kept between generations, so the numbers of error codes never change
+// and the numbers and names of removed codes are never reused.
+type protoEnum struct {
+	values          map[string]int // key: name of the enum value
+	reservedNumbers map[int]struct{}
+	reservedNames   map[string]struct{}
+}
+
+// readProtoEnum reads the enum values and reserved numbers and names of the enum with the given name of a previously generated .proto file.
+// A missing file results in an empty enum, so the first generation does not need a previous file.
+func readProtoEnum(path, name string) (*protoEnum, error) {
+	if path == "" {
+		return newProtoEnum(), nil
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return newProtoEnum(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseProtoEnum(path, file, name)
+}
+
+func newProtoEnum() *protoEnum {
+	return &protoEnum{map[string]int{}, map[int]struct{}{}, map[string]struct{}{}}
+}
+
+// parseProtoEnum parses the enum values and reserved numbers and names of the enum with the given name in the given .proto file.
+// Lines outside of the enum, e.g. of other enums or messages, are ignored.
+func parseProtoEnum(path string, input io.Reader, name string) (*protoEnum, error) {
+	enum := newProtoEnum()
+	start := regexp.MustCompile(`^\s*enum\s+` + regexp.QuoteMeta(name) + `\s*\{`)
+	inEnum := false
+
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if !inEnum {
+			inEnum = start.MatchString(text)
+			continue
+		}
+		if protoEnumEndPattern.MatchString(text) {
+			inEnum = false
+			continue
+		}
+
+		if match := protoValuePattern.FindStringSubmatch(text); match != nil {
+			number, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid enum value number %q", path, line, match[2])
+			}
+			enum.values[match[1]] = number
+			continue
+		}
+
+		match := protoReservedPattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		for _, reserved := range strings.Split(match[1], ",") {
+			reserved = strings.TrimSpace(reserved)
+			if name, err := strconv.Unquote(reserved); err == nil {
+				enum.reservedNames[name] = struct{}{}
+			} else if number, err := strconv.Atoi(reserved); err == nil {
+				enum.reservedNumbers[number] = struct{}{}
+			} else {
+				return nil, fmt.Errorf("%s:%d: unsupported reserved entry %q, only single numbers and names are supported", path, line, reserved)
+			}
+		}
+	}
+	return enum, scanner.Err()
+}
+
+// readRemovedLockedCodes returns the error codes of the given entry points in the lockfile, which are not part of the given codes anymore.
+// Entry points are named like in the facts subcommand, e.g. "example.com/api.(*Server).Handle",
+// which is listed as "example.com/api  Server.Handle" in the lockfile.
+func readRemovedLockedCodes(path string, entries []string, codes map[string]struct{}) ([]string, error) {
+	locked := map[string]struct{}{}
+	for _, entry := range entries {
+		locked[lockfileSymbol(entry)] = struct{}{}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	removed := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, ok := locked[fields[0]+" "+fields[1]]; !ok {
+			continue
+		}
+		for _, code := range fields[2:] {
+			if _, ok := codes[code]; !ok && !isCoveredByWildcard(code, codes) {
+				removed[code] = struct{}{}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(removed))
+	for code := range removed {
+		result = append(result, code)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// lockfileSymbol converts the name of an entry point to the package path and symbol of its lockfile line, separated by a space.
+func lockfileSymbol(entry string) string {
+	slash := strings.LastIndex(entry, "/")
+	dot := strings.Index(entry[slash+1:], ".")
+	if dot == -1 {
+		return entry
+	}
+	pkg, symbol := entry[:slash+1+dot], entry[slash+2+dot:]
+	symbol = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(symbol)
+	return pkg + " " + symbol
+}
+
+// protoValueName returns the name of the enum value for the given error code,
+// prefixed with the name of the enum like recommended by the protobuf style guide, e.g. "ERROR_CODE_STORAGE_NOT_FOUND".
+func protoValueName(enumName, code string) string {
+	var name strings.Builder
+	previous := rune(0)
+	for _, r := range enumName {
+		if unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)) {
+			name.WriteByte('_')
+		}
+		name.WriteRune(unicode.ToUpper(r))
+		previous = r
+	}
+	name.WriteByte('_')
+	for _, r := range code {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			name.WriteRune(unicode.ToUpper(r))
+		} else {
+			name.WriteByte('_')
+		}
+	}
+	return name.String()
+}
+
+// generateProto generates a protobuf enum of the given sorted error codes.
+//
+// Codes keep their numbers of the previous enum, new codes get numbers higher than all numbers used before.
+// Codes of the previous enum, which are no longer returned, and the given removed codes of the lockfile are reserved,
+// so clients never confuse a removed code with a new one. Wildcards like "storage-*" can not be enumerated and are skipped.
+func generateProto(name, protoPackage string, codes []string, previous *protoEnum, removed []string) ([]byte, error) {
+	unspecified := protoValueName(name, "unspecified")
+	nextNumber := 1
+	for _, number := range previous.values {
+		if number >= nextNumber {
+			nextNumber = number + 1
+		}
+	}
+	for number := range previous.reservedNumbers {
+		if number >= nextNumber {
+			nextNumber = number + 1
+		}
+	}
+
+	type enumValue struct {
+		name   string
+		code   string
+		number int
+	}
+	values := []enumValue{}
+	codesByName := map[string]string{}
+	var wildcards []string
+	for _, code := range codes {
+		if strings.HasSuffix(code, "*") {
+			wildcards = append(wildcards, code)
+			continue
+		}
+
+		valueName := protoValueName(name, code)
+		if other, ok := codesByName[valueName]; ok {
+			return nil, fmt.Errorf("error codes %q and %q result in the same enum value name %s", other, code, valueName)
+		}
+		if valueName == unspecified {
+			return nil, fmt.Errorf("error code %q results in the enum value name %s, which is used for the zero value", code, valueName)
+		}
+		if _, ok := previous.reservedNames[valueName]; ok {
+			return nil, fmt.Errorf("error code %q was removed before, its enum value name %s is reserved", code, valueName)
+		}
+		codesByName[valueName] = code
+
+		number, ok := previous.values[valueName]
+		if !ok {
+			number = nextNumber
+			nextNumber++
+		}
+		values = append(values, enumValue{valueName, code, number})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].number < values[j].number })
+
+	reservedNumbers := []int{}
+	for number := range previous.reservedNumbers {
+		reservedNumbers = append(reservedNumbers, number)
+	}
+	reservedNames := []string{}
+	for valueName := range previous.reservedNames {
+		reservedNames = append(reservedNames, strconv.Quote(valueName))
+	}
+	for valueName, number := range previous.values {
+		if _, ok := codesByName[valueName]; !ok && valueName != unspecified {
+			reservedNumbers = append(reservedNumbers, number)
+			reservedNames = append(reservedNames, strconv.Quote(valueName))
+		}
+	}
+	for _, code := range removed {
+		valueName := protoValueName(name, code)
+		if _, ok := previous.values[valueName]; !ok {
+			if _, ok := previous.reservedNames[valueName]; !ok {
+				reservedNames = append(reservedNames, strconv.Quote(valueName))
+			}
+		}
+	}
+	sort.Ints(reservedNumbers)
+	sort.Strings(reservedNames)
+
+	var buffer bytes.Buffer
+	buffer.WriteString(exportHeader)
+	buffer.WriteString("\nsyntax = \"proto3\";\n")
+	if protoPackage != "" {
+		fmt.Fprintf(&buffer, "\npackage %s;\n", protoPackage)
+	}
+	fmt.Fprintf(&buffer, "\n// %s lists the error codes of the exported entry points.\n", name)
+	for _, wildcard := range wildcards {
+		fmt.Fprintf(&buffer, "// Codes matching the wildcard %q are not listed.\n", wildcard)
+	}
+	fmt.Fprintf(&buffer, "enum %s {\n", name)
+	if len(reservedNumbers) > 0 {
+		numbers := make([]string, len(reservedNumbers))
+		for i, number := range reservedNumbers {
+			numbers[i] = strconv.Itoa(number)
+		}
+		fmt.Fprintf(&buffer, "  reserved %s;\n", strings.Join(numbers, ", "))
+	}
+	if len(reservedNames) > 0 {
+		fmt.Fprintf(&buffer, "  reserved %s;\n", strings.Join(reservedNames, ", "))
+	}
+	if len(reservedNumbers) > 0 || len(reservedNames) > 0 {
+		buffer.WriteString("\n")
+	}
+	fmt.Fprintf(&buffer, "  %s = 0;\n", unspecified)
+	for _, value := range values {
+		fmt.Fprintf(&buffer, "  %s = %d; // %s\n", value.name, value.number, value.code)
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes(), nil
+}
+
+// exportProto generates the protobuf enum of the given sorted error codes of the entry points,
+// keeping the numbers of the previously generated file and reserving the codes removed since the lockfile was updated.
+func exportProto(name, protoPackage, previousPath, lockfilePath string, entries []string, codes map[string]struct{}, sorted []string) ([]byte, error) {
+	previous, err := readProtoEnum(previousPath, name)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	if lockfilePath != "" {
+		if removed, err = readRemovedLockedCodes(lockfilePath, entries, codes); err != nil {
+			return nil, err
+		}
+	}
+	return generateProto(name, protoPackage, sorted, previous, removed)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateProto(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    []string
+		previous string
+		removed  []string
+		expected string
+	}{
+		{
+			name:  "first generation",
+			codes: []string{"not-found", "timeout"},
+			expected: `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_NOT_FOUND = 1; // not-found
+  ERROR_CODE_TIMEOUT = 2; // timeout
+}
+`,
+		},
+		{
+			name:  "stable numbers",
+			codes: []string{"access-denied", "not-found", "timeout"},
+			previous: `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+  ERROR_CODE_NOT_FOUND = 2; // not-found
+}
+`,
+			expected: `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+  ERROR_CODE_NOT_FOUND = 2; // not-found
+  ERROR_CODE_ACCESS_DENIED = 3; // access-denied
+}
+`,
+		},
+		{
+			name:  "removed codes are reserved",
+			codes: []string{"timeout", "unavailable"},
+			previous: `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_NOT_FOUND = 1; // not-found
+  ERROR_CODE_TIMEOUT = 2; // timeout
+}
+`,
+			expected: `enum ErrorCode {
+  reserved 1;
+  reserved "ERROR_CODE_NOT_FOUND";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 2; // timeout
+  ERROR_CODE_UNAVAILABLE = 3; // unavailable
+}
+`,
+		},
+		{
+			name:  "reserved numbers and names are kept and not reused",
+			codes: []string{"timeout", "unavailable"},
+			previous: `enum ErrorCode {
+  reserved 1, 4;
+  reserved "ERROR_CODE_NOT_FOUND", "ERROR_CODE_GONE";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 2; // timeout
+}
+`,
+			expected: `enum ErrorCode {
+  reserved 1, 4;
+  reserved "ERROR_CODE_GONE", "ERROR_CODE_NOT_FOUND";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 2; // timeout
+  ERROR_CODE_UNAVAILABLE = 5; // unavailable
+}
+`,
+		},
+		{
+			name:    "removed codes of the lockfile are reserved by name",
+			codes:   []string{"timeout"},
+			removed: []string{"not-found"},
+			expected: `enum ErrorCode {
+  reserved "ERROR_CODE_NOT_FOUND";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+}
+`,
+		},
+		{
+			name:  "other enums and messages are ignored",
+			codes: []string{"timeout"},
+			previous: `message Request {
+  int32 id = 7;
+  reserved 8;
+}
+
+enum Other {
+  OTHER_TIMEOUT = 9;
+}
+
+enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 3; // timeout
+}
+`,
+			expected: `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 3; // timeout
+}
+`,
+		},
+		{
+			name:  "wildcards are skipped",
+			codes: []string{"storage-*", "timeout"},
+			expected: `// Codes matching the wildcard "storage-*" are not listed.
+enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		previous, err := parseProtoEnum("previous.proto", strings.NewReader(test.previous), "ErrorCode")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		output, err := generateProto("ErrorCode", "", test.codes, previous, test.removed)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		prefix := exportHeader + "\nsyntax = \"proto3\";\n\n// ErrorCode lists the error codes of the exported entry points.\n"
+		if got := string(output); got != prefix+test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, prefix+test.expected, got)
+		}
+	}
+}
+
+func TestGenerateProtoPackage(t *testing.T) {
+	output, err := generateProto("ErrorCode", "example.api", nil, newProtoEnum(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(output), "\npackage example.api;\n") {
+		t.Errorf("expected the package declaration, got\n%s", output)
+	}
+}
+
+func TestGenerateProtoInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    []string
+		previous string
+		expected string
+	}{
+		{
+			name:     "name collision",
+			codes:    []string{"not-found", "not_found"},
+			expected: `error codes "not-found" and "not_found" result in the same enum value name ERROR_CODE_NOT_FOUND`,
+		},
+		{
+			name:     "zero value",
+			codes:    []string{"unspecified"},
+			expected: `error code "unspecified" results in the enum value name ERROR_CODE_UNSPECIFIED, which is used for the zero value`,
+		},
+		{
+			name:     "reserved name",
+			codes:    []string{"not-found"},
+			previous: "enum ErrorCode {\n  reserved \"ERROR_CODE_NOT_FOUND\";\n}\n",
+			expected: `error code "not-found" was removed before, its enum value name ERROR_CODE_NOT_FOUND is reserved`,
+		},
+	}
+
+	for _, test := range tests {
+		previous, err := parseProtoEnum("previous.proto", strings.NewReader(test.previous), "ErrorCode")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		_, err = generateProto("ErrorCode", "", test.codes, previous, nil)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected error %q, got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestParseProtoEnum(t *testing.T) {
+	enum, err := parseProtoEnum("previous.proto", strings.NewReader(`syntax = "proto3";
+
+enum ErrorCode {
+  reserved 3, 5;
+  reserved "ERROR_CODE_GONE";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT=4; // timeout
+}
+`), "ErrorCode")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := map[string]int{"ERROR_CODE_UNSPECIFIED": 0, "ERROR_CODE_TIMEOUT": 4}; !reflect.DeepEqual(enum.values, want) {
+		t.Errorf("expected values %v, got %v", want, enum.values)
+	}
+	if want := map[int]struct{}{3: {}, 5: {}}; !reflect.DeepEqual(enum.reservedNumbers, want) {
+		t.Errorf("expected reserved numbers %v, got %v", want, enum.reservedNumbers)
+	}
+	if want := map[string]struct{}{"ERROR_CODE_GONE": {}}; !reflect.DeepEqual(enum.reservedNames, want) {
+		t.Errorf("expected reserved names %v, got %v", want, enum.reservedNames)
+	}
+
+	for _, invalid := range []string{
+		"enum ErrorCode {\n  reserved 1 to 5;\n}\n",
+		"enum ErrorCode {\n  reserved max;\n}\n",
+	} {
+		if _, err := parseProtoEnum("previous.proto", strings.NewReader(invalid), "ErrorCode"); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestReadProtoEnumMissingFile(t *testing.T) {
+	enum, err := readProtoEnum(filepath.Join(t.TempDir(), "missing.proto"), "ErrorCode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enum.values) != 0 || len(enum.reservedNumbers) != 0 || len(enum.reservedNames) != 0 {
+		t.Errorf("expected an empty enum for a missing file, got %+v", enum)
+	}
+}
+
+func TestLockfileSymbol(t *testing.T) {
+	tests := []struct {
+		entry    string
+		expected string
+	}{
+		{"example.com/api.Handle", "example.com/api Handle"},
+		{"example.com/api.(*Server).Handle", "example.com/api Server.Handle"},
+		{"example.com/api.(Server).Handle", "example.com/api Server.Handle"},
+		{"example.com/api.Server.Handle", "example.com/api Server.Handle"},
+		{"api.Handle", "api Handle"},
+		{"api.(*Server).Handle", "api Server.Handle"},
+		{"Handle", "Handle"},
+	}
+
+	for _, test := range tests {
+		if got := lockfileSymbol(test.entry); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.entry, test.expected, got)
+		}
+	}
+}
+
+func TestReadRemovedLockedCodes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lockfile.txt")
+	content := `# comment
+example.com/api  Handle         not-found timeout gone
+example.com/api  Server.Handle  denied storage-full
+example.com/api  Other          other-gone
+other.com/api    Handle         elsewhere-gone
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []string{"example.com/api.Handle", "example.com/api.(*Server).Handle"}
+	codes := map[string]struct{}{"not-found": {}, "timeout": {}, "storage-*": {}}
+	removed, err := readRemovedLockedCodes(path, entries, codes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"denied", "gone"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected removed codes %v, got %v", want, removed)
+	}
+}
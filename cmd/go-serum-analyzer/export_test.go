@@ -0,0 +1,260 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    []string
+		expected string
+	}{
+		{
+			name:     "no codes",
+			expected: "\nexport type ErrorCode = never;\n",
+		},
+		{
+			name:     "codes",
+			codes:    []string{"not-found", "timeout"},
+			expected: "\nexport type ErrorCode =\n\t| \"not-found\"\n\t| \"timeout\";\n",
+		},
+		{
+			name:     "wildcard",
+			codes:    []string{"storage-*", "timeout"},
+			expected: "\nexport type ErrorCode =\n\t| `storage-${string}`\n\t| \"timeout\";\n",
+		},
+	}
+
+	for _, test := range tests {
+		if got := string(generateTypeScript("ErrorCode", test.codes)); got != exportHeader+test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, exportHeader+test.expected, got)
+		}
+	}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    []string
+		expected string
+	}{
+		{
+			name: "no codes",
+			expected: `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"enum": [],
+	"title": "ErrorCode",
+	"type": "string"
+}
+`,
+		},
+		{
+			name:  "codes",
+			codes: []string{"not-found", "timeout"},
+			expected: `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"enum": [
+		"not-found",
+		"timeout"
+	],
+	"title": "ErrorCode",
+	"type": "string"
+}
+`,
+		},
+		{
+			name:  "wildcard",
+			codes: []string{"storage-*", "timeout"},
+			expected: `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"anyOf": [
+		{
+			"enum": [
+				"timeout"
+			]
+		},
+		{
+			"pattern": "^storage-",
+			"type": "string"
+		}
+	],
+	"title": "ErrorCode",
+	"type": "string"
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		output, err := generateJSONSchema("ErrorCode", test.codes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got := string(output); got != test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestReadExportFacts(t *testing.T) {
+	codes, err := readExportFacts(strings.NewReader(exportFacts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"example.com/api.Handle":           {"not-found", "timeout"},
+		"example.com/api.(*Server).Handle": {"denied", "storage-*", "storage-full"},
+		"example.com/store.Get":            {"not-found"},
+	}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("expected %v, got %v", want, codes)
+	}
+
+	if _, err := readExportFacts(strings.NewReader("package example.com/api\n")); err == nil {
+		t.Errorf("expected an error for the text output of the facts subcommand")
+	}
+}
+
+// exportFacts is the output of "facts -output=json" for two packages, reduced to the fields needed by the export.
+const exportFacts = `{
+	"package": "example.com/api",
+	"facts": [
+		{"object": "Error", "type": "ErrorType", "value": {"Codes": []}},
+		{"object": "Handle", "type": "ErrorCodes", "value": {"Codes": ["not-found", "timeout"]}},
+		{"object": "(*Server).Handle", "type": "ErrorCodes", "value": {"Codes": ["denied", "storage-*", "storage-full"]}}
+	]
+}
+{
+	"package": "example.com/store",
+	"facts": [
+		{"object": "Get", "type": "ErrorCodes", "value": {"Codes": ["not-found"]}}
+	]
+}
+`
+
+func TestExport(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"facts.json": exportFacts,
+		"previous.proto": `enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+  ERROR_CODE_GONE = 2; // gone
+}
+`,
+		"reserved.proto": "enum ErrorCode {\n  reserved \"ERROR_CODE_TIMEOUT\";\n}\n",
+		"lockfile.txt":   "example.com/api Handle not-found timeout moved\n",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{
+			name:   "typescript from stdin",
+			args:   []string{"-entry=example.com/api.Handle"},
+			stdin:  exportFacts,
+			stdout: exportHeader + "\nexport type ErrorCode =\n\t| \"not-found\"\n\t| \"timeout\";\n",
+		},
+		{
+			name:   "typescript of several entry points",
+			args:   []string{"-entry=example.com/api.Handle", "-entry=example.com/api.(*Server).Handle", "-name=APIError", "facts.json"},
+			stdout: exportHeader + "\nexport type APIError =\n\t| \"denied\"\n\t| \"not-found\"\n\t| `storage-${string}`\n\t| \"timeout\";\n",
+		},
+		{
+			name:   "json schema",
+			args:   []string{"-entry=example.com/store.Get", "-format=json-schema", "facts.json"},
+			stdout: "{\n\t\"$schema\": \"http://json-schema.org/draft-07/schema#\",\n\t\"enum\": [\n\t\t\"not-found\"\n\t],\n\t\"title\": \"ErrorCode\",\n\t\"type\": \"string\"\n}\n",
+		},
+		{
+			name: "proto",
+			args: []string{"-entry=example.com/api.Handle", "-format=proto", "-proto-package=example.api", "-previous=previous.proto", "-lockfile=lockfile.txt", "facts.json"},
+			stdout: exportHeader + `
+syntax = "proto3";
+
+package example.api;
+
+// ErrorCode lists the error codes of the exported entry points.
+enum ErrorCode {
+  reserved 2;
+  reserved "ERROR_CODE_GONE", "ERROR_CODE_MOVED";
+
+  ERROR_CODE_UNSPECIFIED = 0;
+  ERROR_CODE_TIMEOUT = 1; // timeout
+  ERROR_CODE_NOT_FOUND = 3; // not-found
+}
+`,
+		},
+		{
+			name:     "invalid proto",
+			args:     []string{"-entry=example.com/api.Handle", "-format=proto", "-previous=reserved.proto", "facts.json"},
+			exitCode: 1,
+			stderr:   "export: error code \"timeout\" was removed before",
+		},
+		{
+			name:     "unknown entry point",
+			args:     []string{"-entry=example.com/api.Unknown", "facts.json"},
+			exitCode: 1,
+			stderr:   "export: entry point \"example.com/api.Unknown\" has no error codes fact",
+		},
+		{
+			name:     "invalid facts",
+			args:     []string{"-entry=example.com/api.Handle"},
+			stdin:    "package example.com/api\n",
+			exitCode: 2,
+			stderr:   "export: invalid facts",
+		},
+		{
+			name:     "missing file",
+			args:     []string{"-entry=example.com/api.Handle", "missing.json"},
+			exitCode: 2,
+			stderr:   "export: open missing.json",
+		},
+		{
+			name:     "missing entry point",
+			args:     []string{"facts.json"},
+			exitCode: 2,
+			stderr:   "usage: go-serum-analyzer export",
+		},
+		{
+			name:     "unknown format",
+			args:     []string{"-entry=example.com/api.Handle", "-format=yaml", "facts.json"},
+			exitCode: 2,
+			stderr:   "usage: go-serum-analyzer export",
+		},
+	}
+
+	for _, test := range tests {
+		stdout, stderr, exitCode := runMain(t, dir, test.stdin, append([]string{"export"}, test.args...)...)
+		if exitCode != test.exitCode {
+			t.Errorf("%s: expected exit code %d, got %d (stderr %q)", test.name, test.exitCode, exitCode, stderr)
+		}
+		if stdout != test.stdout {
+			t.Errorf("%s: expected stdout\n%s\ngot\n%s", test.name, test.stdout, stdout)
+		}
+		if !strings.HasPrefix(stderr, test.stderr) || (test.stderr == "" && stderr != "") {
+			t.Errorf("%s: expected stderr starting with %q, got %q", test.name, test.stderr, stderr)
+		}
+	}
+}
+
+// TestExportFacts exports the error codes from the output of the facts subcommand for an analysed module.
+func TestExportFacts(t *testing.T) {
+	dir := writeFiles(t, appModule)
+	facts, stderr, exitCode := runMain(t, dir, "", "facts", "-output=json", "./...")
+	if exitCode != 0 {
+		t.Fatalf("facts: exit code %d, stderr %q", exitCode, stderr)
+	}
+
+	stdout, stderr, exitCode := runMain(t, dir, facts, "export", "-entry=example.com/app.Get")
+	if want := exportHeader + "\nexport type ErrorCode =\n\t| \"app-not-found\"\n\t| \"app-timeout\";\n"; exitCode != 0 || stdout != want {
+		t.Errorf("expected exit code 0 and\n%s\ngot exit code %d, stderr %q and\n%s", want, exitCode, stderr, stdout)
+	}
+}
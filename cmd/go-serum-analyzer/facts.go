@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis"
+	goanalysis "golang.org/x/tools/go/analysis"
+)
+
+// factsOutput is the format of the facts subcommand, either "text" or "json".
+// The flag can't be called "json", as the checker already uses that name for its diagnostics.
+var factsOutput string
+
+// factsLock serialises the output of packages, which are analysed in parallel.
+var factsLock sync.Mutex
+
+// factsAnalyzer prints the facts the serum analyzer exports for each analysed package.
+// It is used by the facts subcommand to debug why a caller does not see the error codes of a callee:
+// a function without ErrorCodes fact has either no declared error codes or its analysis failed.
+var factsAnalyzer = &goanalysis.Analyzer{
+	Name:     "facts",
+	Doc:      "print the ErrorCodes, ErrorType and ErrorConstructor facts exported by the serum analyzer for each package",
+	Requires: []*goanalysis.Analyzer{analysis.Analyzer},
+	Run:      runFacts,
+	Flags:    factsFlags(),
+}
+
+func factsFlags() flag.FlagSet {
+	flags := flag.NewFlagSet("facts", flag.ExitOnError)
+	flags.StringVar(&factsOutput, "output", "text", `format of the printed facts, either "text" or "json"`)
+	return *flags
+}
+
+// factsPackage is the JSON form of the facts of one package.
+type factsPackage struct {
+	Package string       `json:"package"`
+	Facts   []factsEntry `json:"facts"`
+}
+
+// factsEntry is the JSON form of a single fact.
+type factsEntry struct {
+	Object   string          `json:"object"`
+	Kind     string          `json:"kind"`
+	Position string          `json:"position"`
+	Type     string          `json:"type"`
+	Value    goanalysis.Fact `json:"value"`
+}
+
+func runFacts(pass *goanalysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[analysis.Analyzer].(*analysis.Result)
+
+	output := factsPackage{Package: pass.Pkg.Path(), Facts: []factsEntry{}}
+	for _, fact := range result.Facts() {
+		output.Facts = append(output.Facts, factsEntry{
+			Object:   factsObjectName(fact.Object),
+			Kind:     factsObjectKind(fact.Object),
+			Position: pass.Fset.Position(fact.Object.Pos()).String(),
+			Type:     strings.TrimPrefix(fmt.Sprintf("%T", fact.Fact), "*analysis."),
+			Value:    fact.Fact,
+		})
+	}
+
+	var buffer bytes.Buffer
+	switch factsOutput {
+	case "json":
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetIndent("", "\t")
+		if err := encoder.Encode(output); err != nil {
+			return nil, err
+		}
+	case "text":
+		fmt.Fprintf(&buffer, "package %s\n", output.Package)
+		for _, entry := range output.Facts {
+			fmt.Fprintf(&buffer, "\t%s %s (%s): %v\n", entry.Kind, entry.Object, entry.Position, entry.Value)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected \"text\" or \"json\"", factsOutput)
+	}
+
+	factsLock.Lock()
+	defer factsLock.Unlock()
+	_, err := os.Stdout.Write(buffer.Bytes())
+	return nil, err
+}
+
+// factsObjectName returns the name of the object, qualified with the receiver type for methods.
+func factsObjectName(obj types.Object) string {
+	if function, ok := obj.(*types.Func); ok {
+		if recv := function.Type().(*types.Signature).Recv(); recv != nil {
+			return fmt.Sprintf("(%s).%s", types.TypeString(recv.Type(), types.RelativeTo(obj.Pkg())), obj.Name())
+		}
+	}
+	return obj.Name()
+}
+
+func factsObjectKind(obj types.Object) string {
+	switch obj := obj.(type) {
+	case *types.Func:
+		if obj.Type().(*types.Signature).Recv() != nil {
+			return "method"
+		}
+		return "func"
+	case *types.TypeName:
+		return "type"
+	case *types.Var:
+		if obj.IsField() {
+			return "field"
+		}
+		return "var"
+	default:
+		return "object"
+	}
+}
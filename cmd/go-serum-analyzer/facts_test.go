@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFacts(t *testing.T) {
+	dir := writeFiles(t, appModule)
+	path := filepath.Join(dir, "app.go")
+
+	stdout, stderr, exitCode := runMain(t, dir, "", "facts", "./...")
+	want := "package example.com/app\n" +
+		"\ttype Error (" + path + ":7:6): ErrorType{Field:{Name:\"code\", Position:0}, Codes:}\n" +
+		"\tfunc Get (" + path + ":20:6): ErrorCodes: app-not-found app-timeout\n"
+	if exitCode != 0 || stdout != want {
+		t.Errorf("expected exit code 0 and\n%s\ngot exit code %d, stderr %q and\n%s", want, exitCode, stderr, stdout)
+	}
+
+	stdout, stderr, exitCode = runMain(t, dir, "", "facts", "-output=json", "./...")
+	if exitCode != 0 {
+		t.Errorf("expected exit code 0, got %d, stderr %q", exitCode, stderr)
+	}
+	var pkg struct {
+		Package string
+		Facts   []struct {
+			Object, Kind, Position, Type string
+			Value                        struct{ Codes []string }
+		}
+	}
+	if err := json.Unmarshal([]byte(stdout), &pkg); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if pkg.Package != "example.com/app" || len(pkg.Facts) != 2 {
+		t.Fatalf("expected the two facts of example.com/app, got\n%s", stdout)
+	}
+	if entry := pkg.Facts[1]; entry.Object != "Get" || entry.Kind != "func" || entry.Type != "ErrorCodes" || entry.Position != path+":20:6" || len(entry.Value.Codes) != 2 {
+		t.Errorf("unexpected fact of Get: %+v", entry)
+	}
+
+	_, stderr, exitCode = runMain(t, dir, "", "facts", "-output=yaml", "./...")
+	if want := `unknown output format "yaml", expected "text" or "json"`; exitCode != 1 || !strings.Contains(stderr, want) {
+		t.Errorf("expected exit code 1 and %q, got exit code %d, stderr %q", want, exitCode, stderr)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis"
+)
+
+const fmtcheckUsage = `usage: go-serum-analyzer fmtcheck [-sort] [-l] -
+
+Fmtcheck reads a single doc comment from stdin, validates its "Errors:" block,
+and prints the doc comment in canonical form to stdout.
+This does not need a package to analyse, so it can be used by editors and
+code review tools to check doc comment snippets.
+
+The exit code is 1 if the doc comment is invalid, and 2 for usage errors.
+
+Flags:
+`
+
+// fmtcheck runs the fmtcheck subcommand with the given arguments and returns the exit code.
+func fmtcheck(args []string) int {
+	flags := flag.NewFlagSet("fmtcheck", flag.ContinueOnError)
+	sortCodes := flags.Bool("sort", false, "sort the entries of the Errors: block by error code")
+	list := flags.Bool("l", false, "do not print the formatted doc comment, but fail if it is not in canonical form")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), fmtcheckUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 || flags.Arg(0) != "-" {
+		flags.Usage()
+		return 2
+	}
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fmtcheck: %v\n", err)
+		return 2
+	}
+
+	formatted, err := analysis.FormatErrorDocs(string(input), *sortCodes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fmtcheck: %v\n", err)
+		return 1
+	}
+
+	if *list {
+		if formatted != string(input) {
+			fmt.Fprintln(os.Stderr, "fmtcheck: doc comment is not in canonical form")
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Print(formatted)
+	return 0
+}
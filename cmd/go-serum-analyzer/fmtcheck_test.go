@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFmtcheck(t *testing.T) {
+	const canonical = "// Errors:\n//\n//   - some-error    -- if something happens.\n//   - another-error --\n"
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{
+			name:   "canonical",
+			args:   []string{"-"},
+			stdin:  canonical,
+			stdout: canonical,
+		},
+		{
+			name:   "formatted",
+			args:   []string{"-"},
+			stdin:  "// Errors:\n//\n// - some-error -- if something happens.\n//  - another-error --\n",
+			stdout: canonical,
+		},
+		{
+			name:   "sorted",
+			args:   []string{"-sort", "-"},
+			stdin:  canonical,
+			stdout: "// Errors:\n//\n//   - another-error --\n//   - some-error    -- if something happens.\n",
+		},
+		{
+			name:  "list canonical",
+			args:  []string{"-l", "-"},
+			stdin: canonical,
+		},
+		{
+			name:     "list not canonical",
+			args:     []string{"-l", "-"},
+			stdin:    "// Errors:\n//\n// - some-error -- if something happens.\n",
+			exitCode: 1,
+			stderr:   "fmtcheck: doc comment is not in canonical form\n",
+		},
+		{
+			name:     "invalid",
+			args:     []string{"-"},
+			stdin:    "// Errors:\n//   - some-error --\n",
+			exitCode: 1,
+			stderr:   "fmtcheck: ",
+		},
+		{
+			name:     "missing stdin argument",
+			exitCode: 2,
+			stderr:   "usage: go-serum-analyzer fmtcheck",
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"-unknown", "-"},
+			exitCode: 2,
+			stderr:   "flag provided but not defined: -unknown",
+		},
+	}
+
+	for _, test := range tests {
+		stdout, stderr, exitCode := runMain(t, ".", test.stdin, append([]string{"fmtcheck"}, test.args...)...)
+		if exitCode != test.exitCode {
+			t.Errorf("%s: expected exit code %d, got %d (stderr %q)", test.name, test.exitCode, exitCode, stderr)
+		}
+		if stdout != test.stdout {
+			t.Errorf("%s: expected stdout %q, got %q", test.name, test.stdout, stdout)
+		}
+		if !strings.HasPrefix(stderr, test.stderr) || (test.stderr == "" && stderr != "") {
+			t.Errorf("%s: expected stderr starting with %q, got %q", test.name, test.stderr, stderr)
+		}
+	}
+}
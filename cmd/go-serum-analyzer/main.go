@@ -1,9 +1,59 @@
 // The analyse command runs the error code analyzer.
+//
+// Run as "go-serum-analyzer fmtcheck", it instead validates and formats a single doc comment;
+// see fmtcheck.go.
+//
+// Run as "go-serum-analyzer workspace [flags]", it analyses all modules of the current go.work workspace together;
+// see workspace.go.
+//
+// Run as "go-serum-analyzer facts [-output=json] [packages]", it prints the facts exported by the analyzer for the given packages;
+// see facts.go.
+//
+// Run as "go-serum-analyzer registry [-file=name] [packages]", it generates a file in each package registering its error codes
+// with the runtime registry of the rerr package; see registry.go.
+//
+// Run as "go-serum-analyzer switch -func=name [-var=err] [packages]", it prints a switch statement over the error codes
+// declared by the named function, to be pasted at its call sites; see switch.go.
+//
+// Run as "go-serum-analyzer export -entry=name [-format=typescript|json-schema|proto] [file]", it prints the error codes
+// of the named entry points from the output of the facts subcommand as TypeScript, JSON schema or protobuf enum;
+// see export.go and export_proto.go.
+//
+// Run as "go-serum-analyzer buildconfigs [-configs=GOOS/GOARCH,...] [-union] [packages]", it analyses the packages
+// for each of the given build configurations and reports functions whose error codes differ between them; see buildconfigs.go.
 package main
 
 import (
+	"os"
+
 	"github.com/serum-errors/go-serum-analyzer/analysis"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
-func main() { singlechecker.Main(analysis.Analyzer) }
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fmtcheck" {
+		os.Exit(fmtcheck(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(export(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "buildconfigs" {
+		os.Exit(buildconfigs(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "workspace" {
+		runWorkspace()
+	}
+	if len(os.Args) > 1 && os.Args[1] == "facts" {
+		os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
+		singlechecker.Main(factsAnalyzer)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "registry" {
+		os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
+		singlechecker.Main(registryAnalyzer)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "switch" {
+		os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
+		singlechecker.Main(switchAnalyzer)
+	}
+	singlechecker.Main(analysis.Analyzer)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runMainEnv is set in the environment of the test binary, when it is run as the command by runMain.
+const runMainEnv = "GO_SERUM_ANALYZER_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs the command with the given arguments in the given directory and returns its output and exit code.
+// The test binary itself is run as the command, so subcommands exiting the process can be tested as well.
+func runMain(t *testing.T, dir, stdin string, args ...string) (stdout, stderr string, exitCode int) {
+	t.Helper()
+
+	var stdoutBuffer, stderrBuffer bytes.Buffer
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), runMainEnv+"=1", "GOFLAGS=", "GOTOOLCHAIN=local")
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdoutBuffer
+	cmd.Stderr = &stderrBuffer
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatalf("running %v: %v", args, err)
+	}
+	return stdoutBuffer.String(), stderrBuffer.String(), exitCode
+}
+
+// writeFiles writes the given files, by their slash separated paths, into a new temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// appModule is a module whose function Get declares two error codes, one of them as exported constant.
+var appModule = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.17\n",
+	"app.go": `package app
+
+// NotFound is the error code of missing values.
+const NotFound = "app-not-found"
+
+// Error is an error with an error code.
+type Error struct {
+	code string
+}
+
+func (e *Error) Code() string  { return e.code }
+func (e *Error) Error() string { return e.code }
+
+// Get fetches the value of the given key.
+//
+// Errors:
+//
+//   - app-not-found -- if the value does not exist
+//   - app-timeout -- if the value could not be fetched in time
+func Get(key string) error {
+	if key == "" {
+		return &Error{NotFound}
+	}
+	return &Error{"app-timeout"}
+}
+`,
+}
+
+// withFiles returns a copy of the given files with the other files added or replaced.
+func withFiles(files, other map[string]string) map[string]string {
+	result := map[string]string{}
+	for name, content := range files {
+		result[name] = content
+	}
+	for name, content := range other {
+		result[name] = content
+	}
+	return result
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := writeFiles(t, appModule)
+	stdout, stderr, exitCode := runMain(t, dir, "", "./...")
+	if exitCode != 0 || stdout != "" || stderr != "" {
+		t.Errorf("expected no diagnostics, got exit code %d, stdout %q, stderr %q", exitCode, stdout, stderr)
+	}
+
+	dir = writeFiles(t, withFiles(appModule, map[string]string{
+		"undeclared.go": `package app
+
+// Undeclared returns an error code, which it does not declare.
+//
+// Errors:
+//
+//   - app-timeout -- if the value could not be fetched in time
+func Undeclared() error {
+	return Get("")
+}
+`,
+	}))
+	_, stderr, exitCode = runMain(t, dir, "", "./...")
+	if want := `function "Undeclared" has a mismatch of declared and actual error codes: missing codes: [app-not-found]`; exitCode != 3 || !strings.Contains(stderr, want) {
+		t.Errorf("expected exit code 3 and %q, got exit code %d, stderr %q", want, exitCode, stderr)
+	}
+}
+
+// TestVettool runs the command as tool of go vet, which rejects flags writing one file for all packages.
+func TestVettool(t *testing.T) {
+	dir := writeFiles(t, appModule)
+	tool, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		output string
+	}{
+		{nil, ""},
+		{[]string{"-metrics-out=serum.prom"}, "-metrics-out is not supported with go vet"},
+		{[]string{"-lockfile=serum.lock", "-update-lockfile"}, "-update-lockfile is not supported with go vet"},
+	}
+	for _, test := range tests {
+		args := append(append([]string{"vet", "-vettool=" + tool}, test.args...), "./...")
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), runMainEnv+"=1", "GOFLAGS=", "GOTOOLCHAIN=local")
+		output, err := cmd.CombinedOutput()
+		// Depending on the version of Go, errors of the tool are printed as JSON without failing go vet.
+		if test.output == "" && (err != nil || strings.Contains(string(output), `"error"`)) {
+			t.Errorf("%v: expected no error, got %v: %s", test.args, err, output)
+		}
+		if !strings.Contains(string(output), test.output) {
+			t.Errorf("%v: expected an error containing %q, got %v: %s", test.args, test.output, err, output)
+		}
+	}
+}
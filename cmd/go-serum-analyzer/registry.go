@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis"
+	goanalysis "golang.org/x/tools/go/analysis"
+)
+
+// registryHeader starts every generated registry file, so stale files can be recognised and removed.
+const registryHeader = "// Code generated by go-serum-analyzer registry. DO NOT EDIT.\n"
+
+// registryFile is the name of the generated file in the directory of each package.
+var registryFile string
+
+// registryAnalyzer generates a Go file for each analysed package, which registers the declared error codes
+// with their descriptions in the runtime registry of the rerr package.
+// It is used by the registry subcommand.
+var registryAnalyzer = &goanalysis.Analyzer{
+	Name:     "registry",
+	Doc:      "generate a file registering the error codes declared in each package with the rerr runtime registry",
+	Requires: []*goanalysis.Analyzer{analysis.Analyzer},
+	Run:      runRegistry,
+	Flags:    registryFlags(),
+}
+
+func registryFlags() flag.FlagSet {
+	flags := flag.NewFlagSet("registry", flag.ExitOnError)
+	flags.StringVar(&registryFile, "file", "serum_registry.go", "name of the file generated in the directory of each package")
+	return *flags
+}
+
+// registryEntry collects the declarations of one error code in a package.
+type registryEntry struct {
+	code        string
+	description string
+	details     []string
+	functions   []string
+}
+
+func runRegistry(pass *goanalysis.Pass) (interface{}, error) {
+	if len(pass.Files) == 0 || strings.HasSuffix(pass.Pkg.Name(), "_test") {
+		return nil, nil
+	}
+	path := filepath.Join(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()), registryFile)
+
+	var entries []*registryEntry
+	byCode := map[string]*registryEntry{}
+	for _, declaration := range pass.ResultOf[analysis.Analyzer].(*analysis.Result).Declarations() {
+		entry, ok := byCode[declaration.Code]
+		if !ok {
+			entry = &registryEntry{code: declaration.Code}
+			byCode[declaration.Code] = entry
+			entries = append(entries, entry)
+		}
+		if entry.description == "" {
+			entry.description = declaration.Description
+		}
+		if entry.details == nil {
+			entry.details = declaration.Details
+		}
+		function := pass.Pkg.Path() + "." + factsObjectName(declaration.Function)
+		if len(entry.functions) == 0 || entry.functions[len(entry.functions)-1] != function {
+			entry.functions = append(entry.functions, function)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, removeStaleRegistry(path)
+	}
+
+	source, err := generateRegistry(pass.Pkg.Name(), entries)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.WriteFile(path, source, 0o644)
+}
+
+// generateRegistry generates the source of a registry file for the given package.
+func generateRegistry(pkgName string, entries []*registryEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(registryHeader)
+	fmt.Fprintf(&buffer, "\npackage %s\n\n", pkgName)
+	buffer.WriteString("import \"github.com/serum-errors/go-serum-analyzer/rerr\"\n\n")
+	buffer.WriteString("func init() {\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&buffer, "rerr.Register(%q, rerr.Meta{\n", entry.code)
+		if entry.description != "" {
+			fmt.Fprintf(&buffer, "Description: %q,\n", entry.description)
+		}
+		if entry.details != nil {
+			fmt.Fprintf(&buffer, "Details: %#v,\n", entry.details)
+		}
+		fmt.Fprintf(&buffer, "Functions: %#v,\n", entry.functions)
+		buffer.WriteString("})\n")
+	}
+	buffer.WriteString("}\n")
+
+	return format.Source(buffer.Bytes())
+}
+
+// removeStaleRegistry removes a previously generated registry file, e.g. after the last "Errors:" block was removed.
+// Files with the same name which have not been generated are kept.
+func removeStaleRegistry(path string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(content, []byte(registryHeader)) {
+		return nil
+	}
+	return os.Remove(path)
+}
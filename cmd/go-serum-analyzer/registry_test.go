@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := writeFiles(t, appModule)
+
+	_, stderr, exitCode := runMain(t, dir, "", "registry", "-file=codes_registry.go", "./...")
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d, stderr %q", exitCode, stderr)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "codes_registry.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := registryHeader + `
+package app
+
+import "github.com/serum-errors/go-serum-analyzer/rerr"
+
+func init() {
+	rerr.Register("app-not-found", rerr.Meta{
+		Description: "if the value does not exist",
+		Functions:   []string{"example.com/app.Get"},
+	})
+	rerr.Register("app-timeout", rerr.Meta{
+		Description: "if the value could not be fetched in time",
+		Functions:   []string{"example.com/app.Get"},
+	})
+}
+`
+	if string(content) != want {
+		t.Errorf("expected registry\n%s\ngot\n%s", want, content)
+	}
+}
+
+func TestRegistryStale(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		removed bool
+	}{
+		{"generated", registryHeader + "\npackage app\n", true},
+		{"not generated", "package app\n", false},
+	} {
+		dir := writeFiles(t, map[string]string{
+			"go.mod":            "module example.com/app\n\ngo 1.17\n",
+			"app.go":            "package app\n\n// Get has no error codes.\nfunc Get() {}\n",
+			"serum_registry.go": test.content,
+		})
+
+		_, stderr, exitCode := runMain(t, dir, "", "registry", "./...")
+		if exitCode != 0 {
+			t.Errorf("%s: expected exit code 0, got %d, stderr %q", test.name, exitCode, stderr)
+		}
+		_, err := os.Stat(filepath.Join(dir, "serum_registry.go"))
+		if removed := os.IsNotExist(err); removed != test.removed {
+			t.Errorf("%s: expected the registry file to be removed: %v, got %v", test.name, test.removed, removed)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"os"
+
+	"github.com/serum-errors/go-serum-analyzer/analysis"
+	goanalysis "golang.org/x/tools/go/analysis"
+)
+
+// switchFunc is the function whose error codes the switch subcommand handles, named like in the output of the facts subcommand.
+var switchFunc string
+
+// switchVar is the name of the error variable switched on.
+var switchVar string
+
+// switchAnalyzer prints a switch statement with a case for each error code declared by a function,
+// which jump-starts the exhaustive handling of its errors at call sites.
+// It is used by the switch subcommand.
+var switchAnalyzer = &goanalysis.Analyzer{
+	Name:     "switch",
+	Doc:      "print a switch statement over the error codes declared by a function, to be pasted at its call sites",
+	Requires: []*goanalysis.Analyzer{analysis.Analyzer},
+	Run:      runSwitch,
+	Flags:    switchFlags(),
+}
+
+func switchFlags() flag.FlagSet {
+	flags := flag.NewFlagSet("switch", flag.ExitOnError)
+	flags.StringVar(&switchFunc, "func", "", `function whose error codes are switched on, e.g. "Get" or "(*Store).Get"`)
+	flags.StringVar(&switchVar, "var", "err", "name of the error variable switched on")
+	return *flags
+}
+
+func runSwitch(pass *goanalysis.Pass) (interface{}, error) {
+	if switchFunc == "" {
+		return nil, errors.New("missing function, use -func to name it")
+	}
+
+	var declarations []analysis.Declaration
+	for _, declaration := range pass.ResultOf[analysis.Analyzer].(*analysis.Result).Declarations() {
+		if factsObjectName(declaration.Function) == switchFunc {
+			declarations = append(declarations, declaration)
+		}
+	}
+	if len(declarations) == 0 {
+		return nil, nil
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "// Error codes of %s.%s\n", pass.Pkg.Name(), switchFunc)
+	fmt.Fprintf(&buffer, "switch rerr.CodeOf(%s) {\n", switchVar)
+	for _, declaration := range declarations {
+		fmt.Fprintf(&buffer, "case %s:\n", codeExpression(pass.Pkg, declaration.Code))
+		if declaration.Description != "" {
+			fmt.Fprintf(&buffer, "\t// %s\n", declaration.Description)
+		}
+	}
+	buffer.WriteString("}\n")
+
+	factsLock.Lock()
+	defer factsLock.Unlock()
+	_, err := os.Stdout.Write(buffer.Bytes())
+	return nil, err
+}
+
+// codeExpression returns an expression for the given error code, as used in another package.
+// An exported string constant of the package with the code as value is preferred over a string literal.
+func codeExpression(pkg *types.Package, code string) string {
+	for _, name := range pkg.Scope().Names() { // sorted, so the choice between several constants is stable
+		obj, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok || !obj.Exported() || obj.Val().Kind() != constant.String {
+			continue
+		}
+		if constant.StringVal(obj.Val()) == code {
+			return pkg.Name() + "." + name
+		}
+	}
+	return fmt.Sprintf("%q", code)
+}
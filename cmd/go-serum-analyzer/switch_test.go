@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSwitch(t *testing.T) {
+	dir := writeFiles(t, appModule)
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{
+			name: "function",
+			args: []string{"-func=Get", "./..."},
+			stdout: `// Error codes of app.Get
+switch rerr.CodeOf(err) {
+case app.NotFound:
+	// if the value does not exist
+case "app-timeout":
+	// if the value could not be fetched in time
+}
+`,
+		},
+		{
+			name: "variable",
+			args: []string{"-func=Get", "-var=getErr", "./..."},
+			stdout: `// Error codes of app.Get
+switch rerr.CodeOf(getErr) {
+case app.NotFound:
+	// if the value does not exist
+case "app-timeout":
+	// if the value could not be fetched in time
+}
+`,
+		},
+		{
+			name: "unknown function",
+			args: []string{"-func=Unknown", "./..."},
+		},
+		{
+			name:     "missing function",
+			args:     []string{"./..."},
+			exitCode: 1,
+			stderr:   "switch: missing function, use -func to name it",
+		},
+	}
+
+	for _, test := range tests {
+		stdout, stderr, exitCode := runMain(t, dir, "", append([]string{"switch"}, test.args...)...)
+		if exitCode != test.exitCode {
+			t.Errorf("%s: expected exit code %d, got %d (stderr %q)", test.name, test.exitCode, exitCode, stderr)
+		}
+		if stdout != test.stdout {
+			t.Errorf("%s: expected stdout\n%s\ngot\n%s", test.name, test.stdout, stdout)
+		}
+		if !strings.Contains(stderr, test.stderr) || (test.stderr == "" && stderr != "") {
+			t.Errorf("%s: expected stderr containing %q, got %q", test.name, test.stderr, stderr)
+		}
+	}
+}
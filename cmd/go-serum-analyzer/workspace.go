@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// workspaceArgs returns the command line arguments for the analyzer,
+// to analyse all modules of the go.work workspace of the current directory.
+//
+// The given arguments are kept (e.g. flags for the analyzer) and followed by one package pattern per module of the workspace.
+// All modules are analysed in a single run, so facts about error codes flow between the modules.
+func workspaceArgs(args []string) ([]string, error) {
+	workFile, err := goCommand("env", "GOWORK")
+	if err != nil {
+		return nil, err
+	}
+	if workFile == "" || workFile == "off" {
+		return nil, fmt.Errorf("no go.work file found in the current directory or any parent directory")
+	}
+
+	// In workspace mode, all modules of the workspace are main modules.
+	output, err := goCommand("list", "-m", "-f", "{{.Dir}}")
+	if err != nil {
+		return nil, err
+	}
+
+	result := append([]string{}, args...)
+	for _, dir := range strings.Split(output, "\n") {
+		if dir == "" {
+			continue
+		}
+		result = append(result, filepath.Join(dir, "..."))
+	}
+	return result, nil
+}
+
+// goCommand runs the go command with the given arguments and returns its trimmed output.
+func goCommand(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// runWorkspace rewrites the arguments of the workspace subcommand, so the analyzer is run on all modules of the workspace.
+func runWorkspace() {
+	args, err := workspaceArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "workspace: %v\n", err)
+		os.Exit(1)
+	}
+	os.Args = append([]string{os.Args[0]}, args...)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeWorkspace writes a go.work workspace with the modules example.com/app and example.com/client,
+// whose function Fetch returns the error of app.Get, and returns its directory.
+// The test is skipped if the go command does not support workspaces.
+func writeWorkspace(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"go.work":       "go 1.18\n\nuse (\n\t./app\n\t./client\n)\n",
+		"client/go.mod": "module example.com/client\n\ngo 1.17\n",
+		"client/client.go": `package client
+
+import "example.com/app"
+
+// Fetch fetches the value of the given key from the app.
+//
+// Errors:
+//
+//   - app-not-found -- if the value does not exist
+func Fetch(key string) error {
+	return app.Get(key)
+}
+`,
+	}
+	for name, content := range appModule {
+		files["app/"+name] = content
+	}
+	dir := writeFiles(t, files)
+
+	goEnv := exec.Command("go", "env", "GOWORK")
+	goEnv.Dir = dir
+	if output, err := goEnv.Output(); err != nil || strings.TrimSpace(string(output)) == "" {
+		t.Skip("skipping workspace test: the go command does not support go.work files")
+	}
+	return dir
+}
+
+func TestWorkspaceArgs(t *testing.T) {
+	dir := writeWorkspace(t)
+	t.Setenv("GOFLAGS", "")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join(dir, "client")); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	args, err := workspaceArgs([]string{"-strict"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"-strict", filepath.Join(dir, "app", "..."), filepath.Join(dir, "client", "...")}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("expected arguments %v, got %v", want, args)
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	dir := writeWorkspace(t)
+
+	// The error codes of app.Get are only known in the client module, if both modules are analysed together.
+	_, stderr, exitCode := runMain(t, dir, "", "workspace")
+	if want := `function "Fetch" has a mismatch of declared and actual error codes: missing codes: [app-timeout]`; exitCode != 3 || !strings.Contains(stderr, want) {
+		t.Errorf("expected exit code 3 and %q, got exit code %d, stderr %q", want, exitCode, stderr)
+	}
+
+	// Flags are passed on to the analyzer.
+	_, stderr, exitCode = runMain(t, dir, "", "workspace", "-unknown")
+	if want := "flag provided but not defined: -unknown"; exitCode != 2 || !strings.Contains(stderr, want) {
+		t.Errorf("expected exit code 2 and %q, got exit code %d, stderr %q", want, exitCode, stderr)
+	}
+}
+
+func TestWorkspaceMissing(t *testing.T) {
+	dir := writeFiles(t, appModule)
+	_, stderr, exitCode := runMain(t, dir, "", "workspace")
+	if want := "workspace: no go.work file found in the current directory or any parent directory\n"; exitCode != 1 || stderr != want {
+		t.Errorf("expected exit code 1 and %q, got exit code %d, stderr %q", want, exitCode, stderr)
+	}
+}
@@ -0,0 +1,86 @@
+package rerr
+
+// HasCode checks if the given error, or any error in its chain of causes, has the given error code.
+//
+// The chain is followed through "Cause() error" methods, as used by serum errors,
+// and through "Unwrap() error" methods, as used by wrapping errors of the standard library.
+// Errors without a "Code() string" method are skipped.
+//
+// HasCode is the recommended way to branch on error codes: the analyzer checks its code argument
+// like comparisons with the result of Code(), and reports codes the error can never carry.
+func HasCode(err error, code string) bool {
+	for err != nil {
+		if coded, ok := err.(interface{ Code() string }); ok && coded.Code() == code {
+			return true
+		}
+
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// CodeOf returns the error code of the given error, which is the code of the first error in its chain with a "Code() string" method.
+// The chain is followed like by HasCode.
+//
+// For nil, the empty code is returned. So it is for errors without code, i.e. errors that are not serum errors
+// and do not wrap one, and for serum errors whose code is empty; use CodeOfOr to get another code for those.
+//
+// CodeOf is meant for switch statements handling several codes of an error,
+// whose cases the analyzer checks like the code argument of HasCode:
+//
+//	switch rerr.CodeOf(err) {
+//	case "storage-error-not-found":
+//	case "storage-error-full":
+//	}
+func CodeOf(err error) string {
+	return CodeOfOr(err, "")
+}
+
+// CodeOfOr returns the error code of the given error like CodeOf,
+// but returns the given fallback code for errors without code, e.g. errors of the standard library.
+// For nil, the empty code is returned, as there is no error.
+//
+// CodeOfOr allows converting the errors of other functions into errors with a code:
+//
+//	return NewError(rerr.CodeOfOr(err, "api-error-internal"))
+//
+// The analyzer treats the result of such a call like an error code parameter:
+// the error codes of the calling function are the codes of the given error, plus the fallback code.
+func CodeOfOr(err error, fallback string) string {
+	if err == nil {
+		return ""
+	}
+
+	coded, ok := findCoded(err)
+	if !ok || coded.Code() == "" {
+		return fallback
+	}
+	return coded.Code()
+}
+
+// findCoded returns the first error in the chain of the given error with a "Code() string" method.
+// The chain is followed like by HasCode.
+func findCoded(err error) (interface{ Code() string }, bool) {
+	for err != nil {
+		if coded, ok := err.(interface{ Code() string }); ok {
+			return coded, true
+		}
+
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
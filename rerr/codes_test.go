@@ -0,0 +1,82 @@
+package rerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type codedError struct {
+	code  string
+	cause error
+}
+
+func (e *codedError) Error() string { return e.code }
+func (e *codedError) Code() string  { return e.code }
+func (e *codedError) Cause() error  { return e.cause }
+
+func TestHasCode(t *testing.T) {
+	storage := &codedError{"storage-error-full", nil}
+	api := &codedError{"api-error-internal", storage}
+	wrapped := fmt.Errorf("request failed: %w", api)
+
+	for _, test := range []struct {
+		err      error
+		code     string
+		expected bool
+	}{
+		{nil, "storage-error-full", false},
+		{storage, "storage-error-full", true},
+		{storage, "api-error-internal", false},
+		{api, "api-error-internal", true},
+		{api, "storage-error-full", true},
+		{wrapped, "storage-error-full", true},
+		{wrapped, "api-error-unknown", false},
+		{errors.New("plain"), "plain", false},
+	} {
+		if actual := HasCode(test.err, test.code); actual != test.expected {
+			t.Errorf("HasCode(%v, %q) returned %v, expected %v", test.err, test.code, actual, test.expected)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	storage := &codedError{"storage-error-full", nil}
+	api := &codedError{"api-error-internal", storage}
+
+	for _, test := range []struct {
+		err      error
+		expected string
+	}{
+		{nil, ""},
+		{storage, "storage-error-full"},
+		{api, "api-error-internal"},
+		{fmt.Errorf("request failed: %w", storage), "storage-error-full"},
+		{errors.New("plain"), ""},
+	} {
+		if actual := CodeOf(test.err); actual != test.expected {
+			t.Errorf("CodeOf(%v) returned %q, expected %q", test.err, actual, test.expected)
+		}
+	}
+}
+
+func TestCodeOfOr(t *testing.T) {
+	storage := &codedError{"storage-error-full", nil}
+	empty := &codedError{"", storage}
+
+	for _, test := range []struct {
+		err      error
+		expected string
+	}{
+		{nil, ""},
+		{storage, "storage-error-full"},
+		{fmt.Errorf("request failed: %w", storage), "storage-error-full"},
+		{empty, "api-error-internal"},
+		{errors.New("plain"), "api-error-internal"},
+		{fmt.Errorf("request failed: %w", errors.New("plain")), "api-error-internal"},
+	} {
+		if actual := CodeOfOr(test.err, "api-error-internal"); actual != test.expected {
+			t.Errorf("CodeOfOr(%v) returned %q, expected %q", test.err, actual, test.expected)
+		}
+	}
+}
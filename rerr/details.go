@@ -0,0 +1,63 @@
+package rerr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidateDetails checks the details of the given error against the keys registered for its error code.
+// The code and the details are taken from the first error in its chain with a "Code() string" method,
+// whose details are returned by a "Details() map[string]string" method.
+//
+// The check is opt-in, e.g. for tests or debug builds, as the analyzer only verifies details initialised with map literals:
+//
+//	if err := rerr.ValidateDetails(err); err != nil {
+//		log.Printf("invalid error: %v", err)
+//	}
+//
+// Nil is returned for nil, for errors without code or details,
+// and for codes which are not registered or for which no details have been declared.
+func ValidateDetails(err error) error {
+	coded, ok := findCoded(err)
+	if !ok {
+		return nil
+	}
+	withDetails, ok := coded.(interface{ Details() map[string]string })
+	if !ok {
+		return nil
+	}
+	meta, ok := Lookup(coded.Code())
+	if !ok || meta.Details == nil {
+		return nil
+	}
+
+	details := withDetails.Details()
+	declared := map[string]struct{}{}
+	var missing, unexpected []string
+	for _, key := range meta.Details {
+		declared[key] = struct{}{}
+		if _, ok := details[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	for key := range details {
+		if _, ok := declared[key]; !ok {
+			unexpected = append(unexpected, key)
+		}
+	}
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return nil
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		problems = append(problems, fmt.Sprintf("missing keys: %v", missing))
+	}
+	if len(unexpected) > 0 {
+		sort.Strings(unexpected)
+		problems = append(problems, fmt.Sprintf("unexpected keys: %v", unexpected))
+	}
+	return fmt.Errorf("details of error code %q do not match the declared keys: %s", coded.Code(), strings.Join(problems, " "))
+}
@@ -0,0 +1,53 @@
+package rerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type detailedError struct {
+	code    string
+	details map[string]string
+}
+
+func (e *detailedError) Error() string              { return e.code }
+func (e *detailedError) Code() string               { return e.code }
+func (e *detailedError) Details() map[string]string { return e.details }
+
+func TestValidateDetails(t *testing.T) {
+	resetRegistry()
+	defer resetRegistry()
+
+	Register("storage-error-not-found", Meta{Details: []string{"id", "kind"}})
+	Register("storage-error-full", Meta{Description: "if there is no space left."})
+
+	for _, test := range []struct {
+		err      error
+		expected string
+	}{
+		{nil, ""},
+		{errors.New("plain"), ""},
+		{&codedError{"storage-error-not-found", nil}, ""},
+		{&detailedError{"storage-error-full", map[string]string{"size": "1"}}, ""},
+		{&detailedError{"storage-error-unknown", nil}, ""},
+		{&detailedError{"storage-error-not-found", map[string]string{"id": "1", "kind": "file"}}, ""},
+		{fmt.Errorf("wrapped: %w", &detailedError{"storage-error-not-found", map[string]string{"id": "1", "kind": "file"}}), ""},
+		{
+			&detailedError{"storage-error-not-found", map[string]string{"id": "1"}},
+			`details of error code "storage-error-not-found" do not match the declared keys: missing keys: [kind]`,
+		},
+		{
+			&detailedError{"storage-error-not-found", map[string]string{"id": "1", "kind": "file", "size": "1", "path": "/"}},
+			`details of error code "storage-error-not-found" do not match the declared keys: unexpected keys: [path size]`,
+		},
+	} {
+		actual := ""
+		if err := ValidateDetails(test.err); err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expected {
+			t.Errorf("ValidateDetails(%v) returned %q, expected %q", test.err, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package rerr
+
+import (
+	"sort"
+	"strings"
+)
+
+// ErrorStruct is a general purpose error with an error code, a message and details.
+// It can be returned directly by functions declaring error codes, as the analyzer recognises it
+// as error type with its code in the TheTag field:
+//
+//	return &rerr.ErrorStruct{TheTag: "storage-error-not-found", TheMessage: "no such item"}
+//
+// The code has to be constant like for all error types, see the "Error Types" section of the user guide.
+type ErrorStruct struct {
+	TheTag     string
+	TheMessage string
+	TheDetails map[string]string
+}
+
+// Tag returns the error code of the error.
+// It is kept for compatibility, Code returns the same value.
+func (e *ErrorStruct) Tag() string { return e.TheTag }
+
+// Code returns the error code of the error.
+func (e *ErrorStruct) Code() string { return e.TheTag }
+
+func (e *ErrorStruct) Message() string { return e.TheMessage }
+
+func (e *ErrorStruct) Details() map[string]string { return e.TheDetails }
+
+// Error formats the error as its code followed by the message and the details sorted by key,
+// e.g. "storage-error-not-found: no such item (name=a, table=b)".
+func (e *ErrorStruct) Error() string {
+	var builder strings.Builder
+	builder.WriteString(e.TheTag)
+	if e.TheMessage != "" {
+		if e.TheTag != "" {
+			builder.WriteString(": ")
+		}
+		builder.WriteString(e.TheMessage)
+	}
+
+	keys := make([]string, 0, len(e.TheDetails))
+	for key := range e.TheDetails {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		if i == 0 {
+			builder.WriteString(" (")
+		} else {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(key + "=" + e.TheDetails[key])
+	}
+	if len(keys) > 0 {
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
@@ -0,0 +1,43 @@
+package rerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorStruct(t *testing.T) {
+	for _, test := range []struct {
+		err      *ErrorStruct
+		expected string
+	}{
+		{&ErrorStruct{}, ""},
+		{&ErrorStruct{TheTag: "storage-error-not-found"}, "storage-error-not-found"},
+		{&ErrorStruct{TheMessage: "no such item"}, "no such item"},
+		{&ErrorStruct{TheTag: "storage-error-not-found", TheMessage: "no such item"}, "storage-error-not-found: no such item"},
+		{
+			&ErrorStruct{"storage-error-not-found", "no such item", map[string]string{"table": "b", "name": "a"}},
+			"storage-error-not-found: no such item (name=a, table=b)",
+		},
+		{&ErrorStruct{TheTag: "storage-error-full", TheDetails: map[string]string{"size": "0"}}, "storage-error-full (size=0)"},
+	} {
+		if actual := test.err.Error(); actual != test.expected {
+			t.Errorf("Error() of %#v returned %q, expected %q", test.err, actual, test.expected)
+		}
+	}
+}
+
+func TestErrorStructCode(t *testing.T) {
+	err := &ErrorStruct{"storage-error-not-found", "no such item", map[string]string{"name": "a"}}
+	if err.Code() != "storage-error-not-found" || err.Tag() != err.Code() {
+		t.Errorf("Code() and Tag() should return the tag, but returned %q and %q", err.Code(), err.Tag())
+	}
+	if err.Message() != "no such item" || err.Details()["name"] != "a" {
+		t.Errorf("unexpected message %q or details %v", err.Message(), err.Details())
+	}
+
+	var target interface{ Code() string }
+	if wrapped := fmt.Errorf("reading: %w", err); !errors.As(wrapped, &target) || target.Code() != "storage-error-not-found" {
+		t.Errorf("the code of the wrapped ErrorStruct should be found, but was %v", target)
+	}
+}
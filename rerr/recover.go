@@ -0,0 +1,35 @@
+package rerr
+
+import "fmt"
+
+// Recover converts a panic into an error with the given error code, which is stored in the error pointed to by err.
+// It has to be called by a defer statement, usually with the named error result of the function:
+//
+//	func Handle(request *Request) (err error) {
+//		defer rerr.Recover(&err, "api-error-internal")
+//		...
+//	}
+//
+// Without a panic, the error is left unchanged.
+// The analyzer adds the given code to the error codes of functions deferring Recover with one of their error results.
+func Recover(err *error, code string) {
+	if value := recover(); value != nil {
+		*err = &PanicError{code, value}
+	}
+}
+
+// PanicError is the error created by Recover for a recovered panic.
+type PanicError struct {
+	code  string
+	Value interface{} // value passed to panic
+}
+
+func (e *PanicError) Code() string { return e.code }
+
+func (e *PanicError) Error() string { return fmt.Sprintf("%s: panic: %v", e.code, e.Value) }
+
+// Cause returns the value passed to panic, if it is an error.
+func (e *PanicError) Cause() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -0,0 +1,34 @@
+package rerr
+
+import (
+	"errors"
+	"testing"
+)
+
+func handle(value interface{}) (err error) {
+	defer Recover(&err, "api-error-internal")
+	if value != nil {
+		panic(value)
+	}
+	return errors.New("plain")
+}
+
+func TestRecover(t *testing.T) {
+	if err := handle(nil); err == nil || err.Error() != "plain" {
+		t.Errorf("without panic the returned error should be kept, but was %v", err)
+	}
+
+	err := handle("out of range")
+	if code := CodeOf(err); code != "api-error-internal" {
+		t.Errorf("recovered error should have code %q, but had %q", "api-error-internal", code)
+	}
+	if err.Error() != "api-error-internal: panic: out of range" {
+		t.Errorf("unexpected message of recovered error: %q", err.Error())
+	}
+
+	cause := &codedError{"storage-error-full", nil}
+	err = handle(cause)
+	if !HasCode(err, "api-error-internal") || !HasCode(err, "storage-error-full") {
+		t.Errorf("recovered error %v should have its own code and the code of the panic value", err)
+	}
+}
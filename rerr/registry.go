@@ -0,0 +1,131 @@
+// Package rerr is a runtime registry of error codes and their documentation.
+//
+// The registry is filled by files generated with "go-serum-analyzer registry",
+// which register every error code declared in the "Errors:" blocks of a package from an init function:
+//
+//	func init() {
+//		rerr.Register("storage-error-not-found", rerr.Meta{
+//			Description: "if the key does not exist.",
+//			Functions:   []string{"example.com/storage.Get"},
+//		})
+//	}
+//
+// As the declarations are verified by the analyzer, the registry can back documentation endpoints of servers,
+// e.g. by serving Handler at "/errors".
+package rerr
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Meta is the documentation of an error code.
+type Meta struct {
+	Code        string   `json:"code"`
+	Description string   `json:"description,omitempty"` // comment of the first registered declaration with a comment
+	Functions   []string `json:"functions,omitempty"`   // qualified names of the functions declaring the code, sorted
+	Details     []string `json:"details,omitempty"`     // keys of the details of errors with the code, declared by "details: id, kind"
+}
+
+var registry = struct {
+	sync.RWMutex
+	codes map[string]*Meta
+}{codes: map[string]*Meta{}}
+
+// Register adds the given documentation of an error code to the registry.
+//
+// The same code is usually declared by several functions, possibly in different packages,
+// so registering a code again merges the documentation: the functions are added,
+// and the description and details are only set if none have been registered before.
+func Register(code string, meta Meta) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	existing, ok := registry.codes[code]
+	if !ok {
+		existing = &Meta{Code: code}
+		registry.codes[code] = existing
+	}
+	if existing.Description == "" {
+		existing.Description = meta.Description
+	}
+	if existing.Details == nil {
+		existing.Details = append([]string(nil), meta.Details...)
+	}
+	existing.Functions = mergeSorted(existing.Functions, meta.Functions)
+}
+
+// Lookup returns the documentation of the given error code.
+// The second result is false, if the code has not been registered.
+func Lookup(code string) (Meta, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	meta, ok := registry.codes[code]
+	if !ok {
+		return Meta{}, false
+	}
+	return meta.clone(), true
+}
+
+// All returns the documentation of all registered error codes, sorted by code.
+func All() []Meta {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	result := make([]Meta, 0, len(registry.codes))
+	for _, meta := range registry.codes {
+		result = append(result, meta.clone())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// Handler returns a handler serving the registry as JSON.
+//
+// Without query, all registered error codes are served as a list.
+// With a "code" query parameter, e.g. "/errors?code=storage-error-not-found",
+// only the documentation of the given code is served, or 404 if it is not registered.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body interface{} = All()
+		if code := request.URL.Query().Get("code"); code != "" {
+			meta, ok := Lookup(code)
+			if !ok {
+				http.Error(writer, "unknown error code", http.StatusNotFound)
+				return
+			}
+			body = meta
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "\t")
+		_ = encoder.Encode(body)
+	})
+}
+
+func (m *Meta) clone() Meta {
+	result := *m
+	result.Functions = append([]string(nil), m.Functions...)
+	result.Details = append([]string(nil), m.Details...)
+	return result
+}
+
+// mergeSorted adds the values missing in the given sorted slice and keeps it sorted.
+func mergeSorted(sorted, values []string) []string {
+	for _, value := range values {
+		i := sort.SearchStrings(sorted, value)
+		if i < len(sorted) && sorted[i] == value {
+			continue
+		}
+		sorted = append(sorted, "")
+		copy(sorted[i+1:], sorted[i:])
+		sorted[i] = value
+	}
+	return sorted
+}
@@ -0,0 +1,90 @@
+package rerr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func resetRegistry() {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.codes = map[string]*Meta{}
+}
+
+func TestRegister(t *testing.T) {
+	resetRegistry()
+	defer resetRegistry()
+
+	Register("storage-error-not-found", Meta{Functions: []string{"example.com/storage.Get"}})
+	Register("storage-error-not-found", Meta{Description: "if the key does not exist.", Functions: []string{"example.com/api.Get", "example.com/storage.Get"}})
+	Register("storage-error-not-found", Meta{Description: "ignored, as there already is a description"})
+	Register("storage-error-full", Meta{Description: "if there is no space left."})
+
+	meta, ok := Lookup("storage-error-not-found")
+	if !ok {
+		t.Fatal("registered code not found")
+	}
+	expected := Meta{
+		Code:        "storage-error-not-found",
+		Description: "if the key does not exist.",
+		Functions:   []string{"example.com/api.Get", "example.com/storage.Get"},
+	}
+	if !reflect.DeepEqual(meta, expected) {
+		t.Errorf("Lookup returned %#v, expected %#v", meta, expected)
+	}
+
+	if _, ok := Lookup("storage-error-unknown"); ok {
+		t.Error("Lookup found an unregistered code")
+	}
+
+	var codes []string
+	for _, meta := range All() {
+		codes = append(codes, meta.Code)
+	}
+	if !reflect.DeepEqual(codes, []string{"storage-error-full", "storage-error-not-found"}) {
+		t.Errorf("All returned codes %v", codes)
+	}
+
+	// Results are copies, which can't modify the registry.
+	meta.Functions[0] = "modified"
+	if meta, _ := Lookup("storage-error-not-found"); meta.Functions[0] != "example.com/api.Get" {
+		t.Error("modifying a result changed the registry")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	resetRegistry()
+	defer resetRegistry()
+
+	Register("storage-error-full", Meta{Description: "if there is no space left."})
+
+	for _, test := range []struct {
+		url    string
+		status int
+		body   interface{}
+	}{
+		{"/errors", http.StatusOK, &[]Meta{{Code: "storage-error-full", Description: "if there is no space left."}}},
+		{"/errors?code=storage-error-full", http.StatusOK, &Meta{Code: "storage-error-full", Description: "if there is no space left."}},
+		{"/errors?code=storage-error-unknown", http.StatusNotFound, nil},
+	} {
+		recorder := httptest.NewRecorder()
+		Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.url, nil))
+		if recorder.Code != test.status {
+			t.Errorf("%s: status %d, expected %d", test.url, recorder.Code, test.status)
+			continue
+		}
+		if test.body == nil {
+			continue
+		}
+
+		actual := reflect.New(reflect.TypeOf(test.body).Elem()).Interface()
+		if err := json.Unmarshal(recorder.Body.Bytes(), actual); err != nil {
+			t.Errorf("%s: %v", test.url, err)
+		} else if !reflect.DeepEqual(actual, test.body) {
+			t.Errorf("%s: served %#v, expected %#v", test.url, actual, test.body)
+		}
+	}
+}